@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
@@ -8,60 +9,278 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"bdtui/internal/daemon"
 	"bdtui/internal/orch"
 )
 
+// handoffTimeout bounds how long a restarting daemon waits for its
+// successor to report ready before giving up and serving on.
+const handoffTimeout = 15 * time.Second
+
+type config struct {
+	socketPath   string
+	dbPath       string
+	pidPath      string
+	drainTimeout time.Duration
+	tcpAddr      string
+	tlsCert      string
+	tlsKey       string
+	clientCA     string
+	clientScope  string
+	tokensFile   string
+	beadsSync    bool
+	bdBin        string
+	beadsLabel   string
+}
+
+// tcpConfig builds the remote listener config from flags, re-reading the
+// tokens file each time so SIGHUP picks up edits. It returns nil when the
+// TCP listener is disabled.
+func (c config) tcpConfig() (*daemon.TCPConfig, error) {
+	if c.tcpAddr == "" {
+		return nil, nil
+	}
+	tcp := &daemon.TCPConfig{
+		Addr:            c.tcpAddr,
+		CertFile:        c.tlsCert,
+		KeyFile:         c.tlsKey,
+		ClientCAFile:    c.clientCA,
+		ClientCertScope: daemon.Scope(c.clientScope),
+	}
+	if c.tokensFile != "" {
+		tokens, err := daemon.LoadTokens(c.tokensFile)
+		if err != nil {
+			return nil, err
+		}
+		tcp.Tokens = tokens
+	}
+	return tcp, nil
+}
+
 func main() {
-	socketPath := flag.String("socket", daemon.DefaultSocketPath(), "Unix domain socket path")
-	dbPath := flag.String("db", daemon.DefaultDBPath(), "SQLite database path")
-	pidPath := flag.String("pidfile", "", "Path to write the daemon PID (defaults to <socket>.pid)")
+	// "bdtuid restart" asks the running daemon to hand its socket over to a
+	// fresh copy of the binary; every other flag still applies.
+	restart := len(os.Args) > 1 && os.Args[1] == "restart"
+	if restart {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+
+	var cfg config
+	flag.StringVar(&cfg.socketPath, "socket", daemon.DefaultSocketPath(), "Unix domain socket path")
+	flag.StringVar(&cfg.dbPath, "db", daemon.DefaultDBPath(), "SQLite database path")
+	flag.StringVar(&cfg.pidPath, "pidfile", "", "Path to write the daemon PID (defaults to <socket>.pid)")
+	flag.DurationVar(&cfg.drainTimeout, "drain-timeout", 30*time.Second, "On SIGTERM, how long to wait for running executions before detaching them")
+	showVersion := flag.Bool("version", false, "Print the daemon version and exit")
+	flag.StringVar(&cfg.tcpAddr, "tcp-addr", "", "Also serve remote clients on this host:port (TLS required; off by default)")
+	flag.StringVar(&cfg.tlsCert, "tls-cert", "", "PEM server certificate for --tcp-addr")
+	flag.StringVar(&cfg.tlsKey, "tls-key", "", "PEM server key for --tcp-addr")
+	flag.StringVar(&cfg.clientCA, "tls-client-ca", "", "PEM CA that signs accepted client certificates (enables mTLS)")
+	flag.StringVar(&cfg.clientScope, "tls-client-scope", string(daemon.ScopeRead), "Scope granted to verified client certificates: read or operator")
+	flag.StringVar(&cfg.tokensFile, "tokens-file", "", "File of \"<scope> <token>\" lines accepted as bearer tokens on --tcp-addr")
+	flag.BoolVar(&cfg.beadsSync, "beads-sync", true, "Sync run status, labels and notes back to the task's bd issue")
+	flag.StringVar(&cfg.bdBin, "bd-bin", "bd", "bd executable used by --beads-sync")
+	flag.StringVar(&cfg.beadsLabel, "beads-label-prefix", daemon.DefaultBeadsLabelPrefix, "Prefix of the bd label that mirrors a run's status")
 	flag.Parse()
 
-	if err := run(*socketPath, *dbPath, *pidPath); err != nil {
+	if *showVersion {
+		fmt.Printf("bdtuid %s (api %d)\n", daemon.Version, daemon.APIVersion)
+		return
+	}
+	if cfg.pidPath == "" {
+		cfg.pidPath = daemon.PIDPath(cfg.socketPath)
+	}
+
+	var err error
+	if restart {
+		err = requestRestart(cfg)
+	} else {
+		err = run(cfg)
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func run(socketPath, dbPath, pidPath string) error {
-	if pidPath == "" {
-		pidPath = socketPath + ".pid"
-	}
-	lockPath := daemon.LockPath(socketPath)
+func run(cfg config) error {
+	lockPath := daemon.LockPath(cfg.socketPath)
 
-	if err := daemon.EnsureStateDirs(socketPath, dbPath, pidPath, lockPath); err != nil {
+	if err := daemon.EnsureStateDirs(cfg.socketPath, cfg.dbPath, cfg.pidPath, lockPath); err != nil {
 		return fmt.Errorf("create state dirs: %w", err)
 	}
 
-	lock, err := daemon.AcquireLock(lockPath)
+	tcp, err := cfg.tcpConfig()
 	if err != nil {
 		return err
 	}
-	defer daemon.ReleaseLock(lock)
 
-	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())), 0o600); err != nil {
+	handoff, err := daemon.InheritedHandoff()
+	if err != nil {
+		return err
+	}
+	var lock *os.File
+	if handoff != nil {
+		// The inherited descriptor already holds the flock.
+		lock = handoff.Lock
+	} else if lock, err = daemon.AcquireLock(lockPath); err != nil {
+		return err
+	}
+	handedOff := false
+	defer func() {
+		if handedOff {
+			// The successor shares this lock; unlocking would drop it
+			// for both processes, so only close our descriptor.
+			_ = lock.Close()
+			return
+		}
+		daemon.ReleaseLock(lock)
+	}()
+
+	pid := []byte(strconv.Itoa(os.Getpid()))
+	if err := os.WriteFile(cfg.pidPath, pid, 0o600); err != nil {
 		return fmt.Errorf("write pidfile: %w", err)
 	}
-	defer os.Remove(pidPath)
+	defer func() {
+		// After a handoff the successor has rewritten the pidfile.
+		if cur, err := os.ReadFile(cfg.pidPath); err == nil && bytes.Equal(cur, pid) {
+			_ = os.Remove(cfg.pidPath)
+		}
+	}()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		<-sigCh
-		cancel()
-	}()
-
-	store, err := orch.Open(ctx, dbPath)
+	store, err := orch.Open(ctx, cfg.dbPath)
 	if err != nil {
 		return fmt.Errorf("open store: %w", err)
 	}
 	defer store.Close()
 
-	srv := daemon.NewServer(store, socketPath)
-	return srv.Serve(ctx)
+	srv := daemon.NewServer(store, cfg.socketPath)
+	if cfg.beadsSync {
+		srv.SetBeadsSync(daemon.BdCLI{Bin: cfg.bdBin}, cfg.beadsLabel)
+	}
+	if handoff != nil {
+		srv.Inherit(handoff)
+	}
+	if tcp != nil {
+		addr, err := srv.ListenTCP(*tcp)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "bdtuid: serving remote clients on %s\n", addr)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR2)
+	go handleSignals(ctx, cancel, sigCh, srv, cfg, lock)
+
+	if handoff != nil {
+		if err := handoff.Ready(); err != nil {
+			return fmt.Errorf("signal handoff ready: %w", err)
+		}
+	}
+	err = srv.Serve(ctx)
+	handedOff = srv.HandedOff()
+	return err
+}
+
+// handleSignals maps signals onto daemon lifecycle actions:
+//
+//	SIGTERM/SIGINT  drain (no new runs, wait for executions), then exit;
+//	                a second signal exits immediately
+//	SIGHUP          reload TCP tokens and certificate
+//	SIGUSR2         hand the listeners to a fresh binary, then exit
+func handleSignals(ctx context.Context, cancel context.CancelFunc, sigCh <-chan os.Signal, srv *daemon.Server, cfg config, lock *os.File) {
+	draining := false
+	for {
+		var sig os.Signal
+		select {
+		case <-ctx.Done():
+			return
+		case sig = <-sigCh:
+		}
+		switch sig {
+		case syscall.SIGHUP:
+			tcp, err := cfg.tcpConfig()
+			if err == nil && tcp != nil {
+				err = srv.ReloadTCP(*tcp)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "bdtuid: reload failed, keeping previous config: %v\n", err)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, "bdtuid: configuration reloaded")
+		case syscall.SIGUSR2:
+			pid, err := srv.Handoff(lock, handoffTimeout)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "bdtuid: %v\n", err)
+				continue
+			}
+			// Handoff already stopped this process accepting new runs;
+			// shutting down now only finishes in-flight RPCs.
+			fmt.Fprintf(os.Stderr, "bdtuid: handed off to pid %d\n", pid)
+			cancel()
+			return
+		default:
+			if draining {
+				cancel()
+				return
+			}
+			draining = true
+			go func() {
+				n, err := srv.Drain(ctx, cfg.drainTimeout)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "bdtuid: drain: %v\n", err)
+				} else if n > 0 {
+					fmt.Fprintf(os.Stderr, "bdtuid: detached %d running execution(s)\n", n)
+				}
+				cancel()
+			}()
+		}
+	}
+}
+
+// requestRestart signals the running daemon to hand off and waits until a
+// new pid is serving the socket. The pid to signal comes from the daemon
+// itself rather than the pidfile, which may name a recycled pid.
+func requestRestart(cfg config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), handoffTimeout+5*time.Second)
+	defer cancel()
+
+	oldPID, err := servingPID(ctx, cfg.socketPath)
+	if err != nil {
+		return fmt.Errorf("no running daemon: %w", err)
+	}
+	if err := syscall.Kill(oldPID, syscall.SIGUSR2); err != nil {
+		return fmt.Errorf("signal daemon pid %d: %w", oldPID, err)
+	}
+
+	for {
+		if pid, err := servingPID(ctx, cfg.socketPath); err == nil && pid != oldPID {
+			fmt.Printf("bdtuid restarted: pid %d -> %d\n", oldPID, pid)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("daemon pid %d did not hand off: %w", oldPID, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// servingPID asks the daemon on socketPath for its pid.
+func servingPID(ctx context.Context, socketPath string) (int, error) {
+	client, err := daemon.Dial(socketPath)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+	info, err := client.Handshake(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(info.Pid), nil
 }
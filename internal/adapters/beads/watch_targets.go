@@ -7,9 +7,17 @@ import (
 	"github.com/fsnotify/fsnotify"
 )
 
+// IssuesFileName is the JSONL export bd keeps next to its database.
+const IssuesFileName = "issues.jsonl"
+
+// IssuesFile returns the issues.jsonl path of the .beads dir root.
+func IssuesFile(root string) string {
+	return filepath.Join(root, IssuesFileName)
+}
+
 func WatchTargets(root string) []string {
 	return []string{
-		filepath.Join(root, "issues.jsonl"),
+		IssuesFile(root),
 		root,
 	}
 }
@@ -19,5 +27,5 @@ func IsWatchEventRelevant(ev fsnotify.Event) bool {
 		return false
 	}
 	base := strings.TrimSpace(filepath.Base(ev.Name))
-	return base == "issues.jsonl"
+	return base == IssuesFileName
 }
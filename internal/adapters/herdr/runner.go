@@ -0,0 +1,45 @@
+// Package herdr is the thin command layer over the herdr terminal
+// multiplexer CLI shared by the TUI plugin and the agent runtime.
+package herdr
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Runner runs one herdr subcommand and returns its trimmed output.
+type Runner interface {
+	Run(args ...string) (string, error)
+}
+
+// DefaultTimeout bounds a single herdr invocation.
+const DefaultTimeout = 4 * time.Second
+
+// ShellRunner runs the herdr binary found on PATH.
+type ShellRunner struct {
+	// Timeout overrides DefaultTimeout when positive.
+	Timeout time.Duration
+}
+
+func (r ShellRunner) Run(args ...string) (string, error) {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "herdr", args...)
+	out, err := cmd.CombinedOutput()
+	text := strings.TrimSpace(string(out))
+	if err != nil {
+		if text == "" {
+			return "", fmt.Errorf("herdr %s failed: %w", strings.Join(args, " "), err)
+		}
+		return "", fmt.Errorf("herdr %s failed: %s", strings.Join(args, " "), text)
+	}
+	return text, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 )
 
 // Adapter is the provider-agnostic boundary that translates a controller-
@@ -30,7 +32,37 @@ type Adapter interface {
 //
 // RunAgent NEVER Spawns when req.Reattach is true, so a duplicate writer
 // execution cannot be triggered by accident during recovery.
+//
+// A read-only req.Sandbox runs the agent in a read-only copy of
+// WorkingDir (the copy outlives a ctx that ends while the execution keeps
+// running, for a later Reattach) and fails the attempt with
+// ErrWorkspaceModified if the original worktree changed.
 func RunAgent(ctx context.Context, adapter Adapter, runtime Runtime, req Request) (Result, error) {
+	if !req.Sandbox.ReadOnly || req.WorkingDir == "" || req.ExecutionID == "" {
+		return runAgent(ctx, adapter, runtime, req)
+	}
+	workdir := req.WorkingDir
+	if !req.Reattach {
+		tree, err := prepareSandbox(ctx, req.Sandbox, req.ExecutionID, workdir)
+		if err != nil {
+			return Result{IsError: true, ExecutionID: req.ExecutionID}, err
+		}
+		req.WorkingDir = tree
+	} else {
+		req.WorkingDir = filepath.Join(sandboxRoot(req.ExecutionID), sandboxTree)
+	}
+	res, err := runAgent(ctx, adapter, runtime, req)
+	if ctx.Err() != nil {
+		return res, err
+	}
+	if checkErr := finishSandbox(ctx, req.Sandbox, req.ExecutionID, workdir); checkErr != nil && err == nil {
+		res.IsError = true
+		err = checkErr
+	}
+	return res, err
+}
+
+func runAgent(ctx context.Context, adapter Adapter, runtime Runtime, req Request) (Result, error) {
 	if adapter == nil {
 		return Result{IsError: true, ExecutionID: req.ExecutionID}, errors.New("agent: RunAgent: adapter is nil")
 	}
@@ -74,6 +106,9 @@ func RunAgent(ctx context.Context, adapter Adapter, runtime Runtime, req Request
 	if inv.ExecutionID != exec.ID {
 		return Result{IsError: true, ExecutionID: exec.ID}, fmt.Errorf("agent: RunAgent: adapter produced Invocation.ExecutionID=%q, want %q", inv.ExecutionID, exec.ID)
 	}
+	if req.Sandbox.ReadOnly {
+		inv.Env = req.Sandbox.environ(os.Environ())
+	}
 
 	spawned, err := runtime.Spawn(ctx, inv)
 	if err != nil {
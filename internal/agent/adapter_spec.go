@@ -0,0 +1,224 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"bdtui/internal/workflow"
+)
+
+// NewSpecAdapter builds the generic adapter for a declaratively configured
+// runner, chosen by spec.Protocol. nil sessions get an in-memory default.
+func NewSpecAdapter(spec workflow.RunnerSpec, sessions SessionStore) (Adapter, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("agent: %w", err)
+	}
+	base := newSpecAdapter(spec, sessions)
+	switch spec.Protocol {
+	case workflow.RunnerStdinJSONL:
+		return &StdinJSONLAdapter{base}, nil
+	case workflow.RunnerPromptFile:
+		return &PromptFileAdapter{base}, nil
+	}
+	return nil, fmt.Errorf("agent: runner %q: unsupported protocol %q", spec.ID, spec.Protocol)
+}
+
+// StdinJSONLAdapter drives a CLI that reads the prompt on stdin and streams
+// JSONL events on stdout, such as the built-in runner's stream-json mode.
+// Everything CLI-specific (binary, arguments, resume flag, which event
+// carries the result) comes from the workflow.RunnerSpec.
+type StdinJSONLAdapter struct {
+	specAdapter
+}
+
+// PromptFileAdapter drives a CLI that takes the prompt as a file argument.
+// The prompt is written next to the controller-assigned result.json so it
+// stays in run storage with the rest of the attempt.
+type PromptFileAdapter struct {
+	specAdapter
+}
+
+// specAdapter holds what the generic adapters share: argument expansion,
+// session resume and result parsing.
+type specAdapter struct {
+	spec   workflow.RunnerSpec
+	parser workflow.ResultParser
+	sess   SessionStore
+}
+
+func newSpecAdapter(spec workflow.RunnerSpec, sessions SessionStore) specAdapter {
+	if sessions == nil {
+		sessions = newMemorySessionStore()
+	}
+	return specAdapter{spec: spec, parser: spec.Result.WithDefaults(), sess: sessions}
+}
+
+// BuildInvocation passes the prompt on stdin.
+func (a *StdinJSONLAdapter) BuildInvocation(_ context.Context, req Request) (Invocation, error) {
+	if err := a.check(req); err != nil {
+		return Invocation{}, err
+	}
+	inv := a.invocation(req, map[string]string{
+		workflow.PlaceholderWorkdir: req.WorkingDir,
+		workflow.PlaceholderResult:  req.OutputPaths.Result,
+	})
+	inv.Stdin = []byte(req.Prompt)
+	if !strings.HasSuffix(req.Prompt, "\n") {
+		inv.Stdin = append(inv.Stdin, '\n')
+	}
+	return inv, nil
+}
+
+// BuildInvocation writes the prompt to prompt-<execution id>.md beside the
+// result path and passes that path via {prompt_file}.
+func (a *PromptFileAdapter) BuildInvocation(_ context.Context, req Request) (Invocation, error) {
+	if err := a.check(req); err != nil {
+		return Invocation{}, err
+	}
+	if req.OutputPaths.Result == "" {
+		return Invocation{}, fmt.Errorf("agent: runner %q: result path is required to place the prompt file", a.spec.ID)
+	}
+	if !validSpoolID(req.ExecutionID) {
+		return Invocation{}, fmt.Errorf("agent: runner %q: invalid ExecutionID %q", a.spec.ID, req.ExecutionID)
+	}
+	promptFile := filepath.Join(filepath.Dir(req.OutputPaths.Result), "prompt-"+req.ExecutionID+".md")
+	if err := os.MkdirAll(filepath.Dir(promptFile), 0o700); err != nil {
+		return Invocation{}, err
+	}
+	if err := os.WriteFile(promptFile, []byte(req.Prompt), 0o600); err != nil {
+		return Invocation{}, fmt.Errorf("agent: runner %q: write prompt file: %w", a.spec.ID, err)
+	}
+	return a.invocation(req, map[string]string{
+		workflow.PlaceholderPromptFile: promptFile,
+		workflow.PlaceholderWorkdir:    req.WorkingDir,
+		workflow.PlaceholderResult:     req.OutputPaths.Result,
+	}), nil
+}
+
+func (a *specAdapter) check(req Request) error {
+	if req.WorkingDir == "" {
+		return fmt.Errorf("agent: runner %q: working dir is required", a.spec.ID)
+	}
+	if req.Prompt == "" {
+		return fmt.Errorf("agent: runner %q: prompt is required", a.spec.ID)
+	}
+	return nil
+}
+
+// invocation expands the argument template and, when a prior session id
+// exists for req.SessionKey, appends the expanded resume arguments. A
+// pending fork also appends fork_args; a runner without fork_args cannot
+// branch a conversation, so it starts a fresh session instead of resuming
+// (and so changing) the one being forked.
+func (a *specAdapter) invocation(req Request, vars map[string]string) Invocation {
+	args := expandArgs(a.spec.Args, vars)
+	if req.SessionKey != "" && len(a.spec.ResumeArgs) > 0 {
+		sid, ok := a.sess.Get(req.SessionKey)
+		fork := ok && sid != "" && forkPending(a.sess, req.SessionKey)
+		if ok && sid != "" && (!fork || len(a.spec.ForkArgs) > 0) {
+			vars[workflow.PlaceholderSession] = sid
+			args = append(args, expandArgs(a.spec.ResumeArgs, vars)...)
+			if fork {
+				args = append(args, expandArgs(a.spec.ForkArgs, vars)...)
+			}
+		}
+	}
+	return Invocation{
+		ExecutionID: req.ExecutionID,
+		Bin:         a.spec.Bin,
+		Args:        args,
+		Dir:         req.WorkingDir,
+		Transcript:  req.OutputPaths.Transcript,
+		Timeout:     req.Timeout,
+		IdleTimeout: req.IdleTimeout,
+	}
+}
+
+var argPlaceholder = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// expandArgs substitutes placeholders in one pass, so a value that happens
+// to contain placeholder text is not expanded again. Each template entry
+// stays a single argument; values are never re-split.
+func expandArgs(tmpl []string, vars map[string]string) []string {
+	out := make([]string, len(tmpl))
+	for i, a := range tmpl {
+		out[i] = argPlaceholder.ReplaceAllStringFunc(a, func(ph string) string {
+			if v, ok := vars[ph]; ok {
+				return v
+			}
+			return ph
+		})
+	}
+	return out
+}
+
+// ParseResult turns the captured stdout into a normalized Result according
+// to the spec's result parser (including the usage the result event
+// reports), and records the captured session id.
+func (a *specAdapter) ParseResult(_ context.Context, req Request, raw RuntimeResult) (Result, error) {
+	res := Result{IsError: raw.ExitErr != nil}
+
+	if len(raw.Stdout) == 0 {
+		res.IsError = true
+		if raw.ExitErr != nil {
+			return res, fmt.Errorf("agent: runner %q exited non-zero with no output: %w (stderr=%s)", a.spec.ID, raw.ExitErr, truncate(raw.Stderr))
+		}
+		return res, fmt.Errorf("agent: runner %q produced no stdout", a.spec.ID)
+	}
+
+	if a.parser.Format == workflow.ResultText {
+		res.Raw = string(raw.Stdout)
+		return res, nil
+	}
+
+	found := false
+	for _, line := range splitLines(raw.Stdout) {
+		var ev map[string]any
+		if err := json.Unmarshal(line, &ev); err != nil {
+			continue
+		}
+		if sid, ok := lookupField(ev, a.parser.SessionField).(string); ok && sid != "" {
+			res.SessionID = sid
+		}
+		if typ, _ := lookupField(ev, a.parser.TypeField).(string); typ != a.parser.ResultType {
+			continue
+		}
+		found = true
+		res.Raw, _ = lookupField(ev, a.parser.TextField).(string)
+		res.StopReason, _ = lookupField(ev, a.parser.StopField).(string)
+		if isErr, _ := lookupField(ev, a.parser.ErrorField).(bool); isErr || strings.EqualFold(res.StopReason, "error") {
+			res.IsError = true
+		}
+		in, _ := lookupField(ev, a.parser.InputTokensField).(float64)
+		out, _ := lookupField(ev, a.parser.OutputTokensField).(float64)
+		cost, _ := lookupField(ev, a.parser.CostField).(float64)
+		res.Usage = Usage{InputTokens: int64(in), OutputTokens: int64(out), CostUSD: cost}
+	}
+
+	if !found {
+		res.IsError = true
+		return res, fmt.Errorf("agent: runner %q: no %q event in output (stderr=%s)", a.spec.ID, a.parser.ResultType, truncate(raw.Stderr))
+	}
+	if req.SessionKey != "" && res.SessionID != "" {
+		a.sess.Put(req.SessionKey, res.SessionID)
+	}
+	return res, nil
+}
+
+// lookupField follows a dotted path through nested JSON objects.
+func lookupField(ev map[string]any, path string) any {
+	var cur any = ev
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+	return cur
+}
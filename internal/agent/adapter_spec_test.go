@@ -0,0 +1,258 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bdtui/internal/workflow"
+)
+
+// fakeJSONLAgent reads the prompt on stdin and streams events in a shape
+// unlike the built-in runner's: "kind" instead of "type", a nested session
+// id and a "final" result event. With --resume it echoes the session back.
+const fakeJSONLAgent = `#!/bin/sh
+prompt=$(cat)
+sid=fresh
+[ "$1" = "--resume" ] && sid="$2"
+printf '{"kind":"init","meta":{"sid":"%s"}}\n' "$sid"
+printf 'not json\n'
+printf '{"kind":"final","text":"got %s","failed":false,"why":"done"}\n' "$prompt"
+`
+
+// fakePromptFileAgent reads the prompt from the file named by --prompt,
+// writes result.json and prints plain text.
+const fakePromptFileAgent = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+	--prompt) prompt="$2"; shift ;;
+	--out) out="$2"; shift ;;
+	esac
+	shift
+done
+printf '{"outcome":"done","data":{}}' > "$out"
+echo "read: $(cat "$prompt") in $PWD"
+`
+
+func writeFakeAgent(t *testing.T, script string) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), "agent")
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return bin
+}
+
+func TestStdinJSONLAdapterRunsAndResumes(t *testing.T) {
+	spec := workflow.RunnerSpec{
+		ID:         "acme",
+		Protocol:   workflow.RunnerStdinJSONL,
+		Bin:        writeFakeAgent(t, fakeJSONLAgent),
+		ResumeArgs: []string{"--resume", "{session}"},
+		Result: workflow.ResultParser{
+			TypeField:    "kind",
+			ResultType:   "final",
+			TextField:    "text",
+			SessionField: "meta.sid",
+			ErrorField:   "failed",
+			StopField:    "why",
+		},
+	}
+	sessions := newMemorySessionStore()
+	a, err := NewSpecAdapter(spec, sessions)
+	if err != nil {
+		t.Fatalf("NewSpecAdapter: %v", err)
+	}
+	if _, ok := a.(*StdinJSONLAdapter); !ok {
+		t.Fatalf("adapter = %T, want *StdinJSONLAdapter", a)
+	}
+
+	req := Request{ExecutionID: AllocateExecutionID(), SessionKey: "run-1/planner", Prompt: "hello", WorkingDir: t.TempDir()}
+	res, err := RunAgent(context.Background(), a, NewExecRuntime(), req)
+	if err != nil {
+		t.Fatalf("RunAgent: %v", err)
+	}
+	if res.IsError || res.Raw != "got hello" || res.SessionID != "fresh" || res.StopReason != "done" {
+		t.Fatalf("result = %+v", res)
+	}
+	if sid, _ := sessions.Get("run-1/planner"); sid != "fresh" {
+		t.Fatalf("stored session = %q", sid)
+	}
+
+	// The second call for the same key resumes the captured session.
+	sessions.Put("run-1/planner", "sid-7")
+	req.ExecutionID = AllocateExecutionID()
+	inv, err := a.BuildInvocation(context.Background(), req)
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	if !equalStrings(inv.Args, []string{"--resume", "sid-7"}) || string(inv.Stdin) != "hello\n" {
+		t.Fatalf("resume invocation args=%v stdin=%q", inv.Args, inv.Stdin)
+	}
+	res, err = RunAgent(context.Background(), a, NewExecRuntime(), req)
+	if err != nil || res.SessionID != "sid-7" {
+		t.Fatalf("resumed RunAgent = %+v, %v", res, err)
+	}
+}
+
+func TestSpecAdapterForkSession(t *testing.T) {
+	store := forkingStore{newMemorySessionStore(), map[string]bool{"run-1/planner": true}}
+	store.Put("run-1/planner", "sid-1")
+	req := Request{SessionKey: "run-1/planner", Prompt: "p", WorkingDir: "/w"}
+	spec := workflow.RunnerSpec{
+		ID:         "acme",
+		Protocol:   workflow.RunnerStdinJSONL,
+		Bin:        "acme",
+		ResumeArgs: []string{"--resume", "{session}"},
+		ForkArgs:   []string{"--fork"},
+	}
+
+	a, err := NewSpecAdapter(spec, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv, err := a.BuildInvocation(context.Background(), req)
+	if err != nil || !equalStrings(inv.Args, []string{"--resume", "sid-1", "--fork"}) {
+		t.Fatalf("fork args = %v, %v", inv.Args, err)
+	}
+
+	// Without fork_args the runner cannot branch, so it starts fresh.
+	spec.ForkArgs = nil
+	if a, err = NewSpecAdapter(spec, store); err != nil {
+		t.Fatal(err)
+	}
+	inv, err = a.BuildInvocation(context.Background(), req)
+	if err != nil || len(inv.Args) != 0 {
+		t.Fatalf("fork without fork_args = %v, %v", inv.Args, err)
+	}
+}
+
+func TestStdinJSONLAdapterParseErrors(t *testing.T) {
+	a, err := NewSpecAdapter(workflow.RunnerSpec{ID: "x", Protocol: workflow.RunnerStdinJSONL, Bin: "x"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	res, err := a.ParseResult(ctx, Request{}, RuntimeResult{Stdout: []byte(`{"type":"result","is_error":true,"result":"no"}` + "\n")})
+	if err != nil || !res.IsError || res.Raw != "no" {
+		t.Fatalf("error result = %+v, %v", res, err)
+	}
+	if _, err := a.ParseResult(ctx, Request{}, RuntimeResult{Stdout: []byte(`{"type":"progress"}` + "\n")}); err == nil {
+		t.Fatal("expected error without a result event")
+	}
+	if _, err := a.ParseResult(ctx, Request{}, RuntimeResult{ExitErr: errors.New("boom")}); err == nil {
+		t.Fatal("expected error for empty stdout")
+	}
+}
+
+func TestSpecAdapterParseUsage(t *testing.T) {
+	ctx := context.Background()
+	spec := workflow.RunnerSpec{ID: "acme", Protocol: workflow.RunnerStdinJSONL, Bin: "acme"}
+	a, err := NewSpecAdapter(spec, nil)
+	if err != nil {
+		t.Fatalf("NewSpecAdapter: %v", err)
+	}
+	stdout := `{"type":"result","result":"ok","usage":{"input_tokens":12,"output_tokens":5},"total_cost_usd":0.01}` + "\n"
+	res, err := a.ParseResult(ctx, Request{}, RuntimeResult{Stdout: []byte(stdout)})
+	if err != nil || res.Usage != (Usage{InputTokens: 12, OutputTokens: 5, CostUSD: 0.01}) {
+		t.Fatalf("default usage fields = %+v, %v", res.Usage, err)
+	}
+
+	spec.Result = workflow.ResultParser{InputTokensField: "spend.in", OutputTokensField: "spend.out", CostField: "spend.usd"}
+	if a, err = NewSpecAdapter(spec, nil); err != nil {
+		t.Fatalf("NewSpecAdapter: %v", err)
+	}
+	stdout = `{"type":"result","result":"ok","spend":{"in":7,"out":2,"usd":0.5}}` + "\n"
+	res, err = a.ParseResult(ctx, Request{}, RuntimeResult{Stdout: []byte(stdout)})
+	if err != nil || res.Usage != (Usage{InputTokens: 7, OutputTokens: 2, CostUSD: 0.5}) {
+		t.Fatalf("custom usage fields = %+v, %v", res.Usage, err)
+	}
+}
+
+func TestPromptFileAdapterRun(t *testing.T) {
+	runDir := t.TempDir()
+	spec := workflow.RunnerSpec{
+		ID:       "filer",
+		Protocol: workflow.RunnerPromptFile,
+		Bin:      writeFakeAgent(t, fakePromptFileAgent),
+		Args:     []string{"--prompt", "{prompt_file}", "--out", "{result}"},
+		Result:   workflow.ResultParser{Format: workflow.ResultText},
+	}
+	a, err := NewSpecAdapter(spec, nil)
+	if err != nil {
+		t.Fatalf("NewSpecAdapter: %v", err)
+	}
+	work := t.TempDir()
+	req := Request{
+		ExecutionID: AllocateExecutionID(),
+		Prompt:      "do the thing",
+		WorkingDir:  work,
+		OutputPaths: OutputPaths{Result: filepath.Join(runDir, "attempt", "result.json")},
+	}
+	res, err := RunAgent(context.Background(), a, NewExecRuntime(), req)
+	if err != nil {
+		t.Fatalf("RunAgent: %v", err)
+	}
+	if res.IsError || res.Raw != "read: do the thing in "+work+"\n" {
+		t.Fatalf("result = %+v", res)
+	}
+	if string(res.ResultJSON) != `{"outcome":"done","data":{}}` {
+		t.Fatalf("ResultJSON = %q", res.ResultJSON)
+	}
+	prompt, err := os.ReadFile(filepath.Join(runDir, "attempt", "prompt-"+req.ExecutionID+".md"))
+	if err != nil || string(prompt) != "do the thing" {
+		t.Fatalf("prompt file = %q, %v", prompt, err)
+	}
+
+	if _, err := a.BuildInvocation(context.Background(), Request{ExecutionID: "e", Prompt: "p", WorkingDir: work}); err == nil {
+		t.Fatal("expected error without a result path")
+	}
+}
+
+func TestRegistryForRole(t *testing.T) {
+	reg := NewRegistry()
+	maki := NewMakiAdapter("", nil)
+	reg.Register(workflow.DefaultRunner, maki)
+	err := reg.RegisterSpecs(map[string]workflow.RunnerSpec{
+		"acme": {ID: "acme", Protocol: workflow.RunnerStdinJSONL, Bin: "acme"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("RegisterSpecs: %v", err)
+	}
+
+	if a, err := reg.ForRole(workflow.RoleContract{ID: "planner"}); err != nil || a != Adapter(maki) {
+		t.Fatalf("default runner = %T, %v", a, err)
+	}
+	if a, err := reg.ForRole(workflow.RoleContract{ID: "planner", Runner: "acme"}); err != nil {
+		t.Fatalf("acme runner: %v", err)
+	} else if _, ok := a.(*StdinJSONLAdapter); !ok {
+		t.Fatalf("acme runner = %T", a)
+	}
+	if _, err := reg.ForRole(workflow.RoleContract{ID: "planner", Runner: "nope"}); !errors.Is(err, ErrUnknownRunner) {
+		t.Fatalf("unknown runner = %v, want ErrUnknownRunner", err)
+	}
+
+	bad := map[string]workflow.RunnerSpec{
+		"ok":  {ID: "ok", Protocol: workflow.RunnerStdinJSONL, Bin: "ok"},
+		"bad": {ID: "bad", Protocol: workflow.RunnerPromptFile, Bin: "bad"},
+	}
+	if err := reg.RegisterSpecs(bad, nil); err == nil || !strings.Contains(err.Error(), "{prompt_file}") {
+		t.Fatalf("RegisterSpecs(invalid) = %v", err)
+	}
+	if got := reg.Runners(); !equalStrings(got, []string{"acme", "maki"}) {
+		t.Fatalf("Runners = %v, invalid batch must not be registered", got)
+	}
+}
+
+func TestExpandArgsSinglePass(t *testing.T) {
+	got := expandArgs([]string{"--cwd={workdir}", "{result}", "{other}"}, map[string]string{
+		workflow.PlaceholderWorkdir: "/w/{result}",
+		workflow.PlaceholderResult:  "/r",
+	})
+	if !equalStrings(got, []string{"--cwd=/w/{result}", "/r", "{other}"}) {
+		t.Fatalf("expandArgs = %v", got)
+	}
+}
@@ -71,9 +71,22 @@ func BuildEnvelope(in EnvelopeInput) (string, error) {
 	fmt.Fprintf(&b, "id: %s\n", in.Task.ID)
 	fmt.Fprintf(&b, "title: %s\n", in.Task.Title)
 	b.WriteString("description: |\n")
-	for _, ln := range strings.Split(strings.TrimRight(in.Task.Description, "\n"), "\n") {
-		fmt.Fprintf(&b, "  %s\n", ln)
+	writeIndented(&b, "  ", in.Task.Description)
+	if strings.TrimSpace(in.Task.Notes) != "" {
+		b.WriteString("notes: |\n")
+		writeIndented(&b, "  ", in.Task.Notes)
+	}
+	if p := in.Task.Parent; p != nil {
+		b.WriteString("parent:\n")
+		fmt.Fprintf(&b, "  id: %s\n", p.ID)
+		fmt.Fprintf(&b, "  title: %s\n", p.Title)
+		if strings.TrimSpace(p.Description) != "" {
+			b.WriteString("  description: |\n")
+			writeIndented(&b, "    ", p.Description)
+		}
 	}
+	writeTaskRefs(&b, "siblings", in.Task.Siblings)
+	writeTaskRefs(&b, "blockers", in.Task.Blockers)
 
 	b.WriteString("\n# Project Instructions\n")
 	if len(in.Instructions) == 0 {
@@ -133,6 +146,32 @@ func BuildEnvelope(in EnvelopeInput) (string, error) {
 	return b.String(), nil
 }
 
+// writeIndented writes text line by line under a YAML block scalar.
+func writeIndented(b *strings.Builder, indent, text string) {
+	for _, ln := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		fmt.Fprintf(b, "%s%s\n", indent, ln)
+	}
+}
+
+// writeTaskRefs lists related tasks as "id: title [status]", in snapshot
+// order; nothing is written for an empty list.
+func writeTaskRefs(b *strings.Builder, key string, refs []TaskRef) {
+	if len(refs) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", key)
+	for _, r := range refs {
+		line := r.ID
+		if r.Title != "" {
+			line += ": " + r.Title
+		}
+		if r.Status != "" {
+			line += " [" + r.Status + "]"
+		}
+		fmt.Fprintf(b, "  - %s\n", line)
+	}
+}
+
 // missingArtifactPaths returns the declared output names whose
 // controller-assigned path is empty or missing.
 func missingArtifactPaths(declared []string, paths OutputPaths) []string {
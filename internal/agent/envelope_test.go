@@ -143,6 +143,70 @@ func TestBuildEnvelopeContainsContract(t *testing.T) {
 	}
 }
 
+func TestBuildEnvelopeTaskContext(t *testing.T) {
+	snap := TaskSnapshot{
+		ID:          "bdtui-7",
+		Title:       "Add export",
+		Description: "Export the board.",
+		Notes:       "Customer asked for CSV.",
+		Parent:      &TaskRef{ID: "bdtui-2", Title: "Reporting epic", Description: "Everything about reports."},
+		Siblings:    []TaskRef{{ID: "bdtui-8", Title: "Add import", Status: "open"}},
+		Blockers:    []TaskRef{{ID: "bdtui-5", Title: "Schema v2", Status: "closed"}},
+	}
+
+	// A role that asks for no context sees only the task.
+	in := validEnvelopeInput()
+	in.Task = snap.ForRole(in.Role)
+	got, err := BuildEnvelope(in)
+	if err != nil {
+		t.Fatalf("BuildEnvelope: %v", err)
+	}
+	for _, needle := range []string{"notes:", "parent:", "siblings:", "blockers:"} {
+		if strings.Contains(got, needle) {
+			t.Fatalf("envelope without context has %q\n---\n%s", needle, got)
+		}
+	}
+
+	in.Role.Context = []workflow.TaskContext{workflow.ContextParent, workflow.ContextSiblings, workflow.ContextBlockers, workflow.ContextNotes}
+	in.Task = snap.ForRole(in.Role)
+	got, err = BuildEnvelope(in)
+	if err != nil {
+		t.Fatalf("BuildEnvelope: %v", err)
+	}
+	want := `description: |
+  Export the board.
+notes: |
+  Customer asked for CSV.
+parent:
+  id: bdtui-2
+  title: Reporting epic
+  description: |
+    Everything about reports.
+siblings:
+  - bdtui-8: Add import [open]
+blockers:
+  - bdtui-5: Schema v2 [closed]
+`
+	if !strings.Contains(got, want) {
+		t.Fatalf("envelope task section missing context\n---\n%s", got)
+	}
+
+	in.Role.Context = []workflow.TaskContext{workflow.ContextBlockers}
+	if got := snap.ForRole(in.Role); got.Parent != nil || got.Notes != "" || got.Siblings != nil || len(got.Blockers) != 1 {
+		t.Fatalf("ForRole(blockers) = %+v", got)
+	}
+}
+
+func TestParseTaskSnapshot(t *testing.T) {
+	got, err := ParseTaskSnapshot(`{"id":"bdtui-7","title":"Add export","parent":{"id":"bdtui-2"}}`)
+	if err != nil || got.ID != "bdtui-7" || got.Parent == nil || got.Parent.ID != "bdtui-2" {
+		t.Fatalf("ParseTaskSnapshot = %+v, %v", got, err)
+	}
+	if _, err := ParseTaskSnapshot(`{"title":"no id"}`); err == nil {
+		t.Fatal("expected an error for a snapshot without id")
+	}
+}
+
 // TestResolveContractEqualsRole asserts that ResolveContract makes
 // DeclaredOutputs and AllowedOutcomes agree with the role — the bypass the
 // reviewer flagged ("Role.Outputs=[plan], Contract.DeclaredOutputs=[]") is
@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrExecutionTimeout and ErrExecutionIdle are the RuntimeResult.ExitErr of
+// an execution the runtime killed for exceeding Invocation.Timeout or
+// Invocation.IdleTimeout.
+var (
+	ErrExecutionTimeout = errors.New("agent: execution exceeded its timeout")
+	ErrExecutionIdle    = errors.New("agent: execution produced no output within its idle timeout")
+)
+
+// Exit-file markers for executions killed by a limit; see exitStopped.
+const (
+	exitTimeout = "timeout"
+	exitIdle    = "idle"
+
+	spoolLimits = "limits"
+)
+
+// execLimits is an execution's wall-clock and idle timeouts, measured from
+// start. A zero duration is no limit.
+type execLimits struct {
+	start   time.Time
+	timeout time.Duration
+	idle    time.Duration
+}
+
+func limitsFor(inv Invocation, start time.Time) execLimits {
+	return execLimits{start: start, timeout: inv.Timeout, idle: inv.IdleTimeout}
+}
+
+func (l execLimits) enabled() bool {
+	return l.timeout > 0 || l.idle > 0
+}
+
+// exceeded returns the limit an execution that last wrote output at
+// lastOutput has crossed by now, or nil.
+func (l execLimits) exceeded(now, lastOutput time.Time) error {
+	if l.timeout > 0 && now.Sub(l.start) >= l.timeout {
+		return ErrExecutionTimeout
+	}
+	if lastOutput.Before(l.start) {
+		lastOutput = l.start
+	}
+	if l.idle > 0 && now.Sub(lastOutput) >= l.idle {
+		return ErrExecutionIdle
+	}
+	return nil
+}
+
+// checkInterval is how often a watchdog looks at the limits: often enough
+// to stop within a tenth of the shortest limit, at most once a second.
+func (l execLimits) checkInterval() time.Duration {
+	shortest := l.timeout
+	if l.idle > 0 && (shortest == 0 || l.idle < shortest) {
+		shortest = l.idle
+	}
+	return min(max(shortest/10, 10*time.Millisecond), time.Second)
+}
+
+// limitMarker is the exit-file marker recording that err stopped an
+// execution.
+func limitMarker(err error) string {
+	if errors.Is(err, ErrExecutionIdle) {
+		return exitIdle
+	}
+	return exitTimeout
+}
+
+// activityWriter records when output last arrived, for idle detection.
+type activityWriter struct {
+	w    io.Writer
+	last *atomic.Int64 // UnixNano
+}
+
+func (a activityWriter) Write(p []byte) (int, error) {
+	a.last.Store(time.Now().UnixNano())
+	return a.w.Write(p)
+}
+
+// writeSpoolLimits records the limits of a spooled execution so a Wait in a
+// later daemon process enforces them too. Nothing is written without
+// limits.
+func writeSpoolLimits(dir string, l execLimits) error {
+	if !l.enabled() {
+		return nil
+	}
+	data := fmt.Sprintf("%d %d %d\n", l.start.UnixNano(), l.timeout, l.idle)
+	return os.WriteFile(filepath.Join(dir, spoolLimits), []byte(data), 0o600)
+}
+
+func readSpoolLimits(dir string) (execLimits, bool) {
+	b, err := os.ReadFile(filepath.Join(dir, spoolLimits))
+	if err != nil {
+		return execLimits{}, false
+	}
+	var n [3]int64
+	fields := strings.Fields(string(b))
+	if len(fields) != len(n) {
+		return execLimits{}, false
+	}
+	for i, f := range fields {
+		if n[i], err = strconv.ParseInt(f, 10, 64); err != nil {
+			return execLimits{}, false
+		}
+	}
+	return execLimits{start: time.Unix(0, n[0]), timeout: time.Duration(n[1]), idle: time.Duration(n[2])}, true
+}
+
+// spoolLastOutput is when the wrapper last wrote stdout or stderr, going by
+// the spool files' modification times.
+func spoolLastOutput(dir string) time.Time {
+	var last time.Time
+	for _, name := range []string{spoolStdout, spoolStderr} {
+		if fi, err := os.Stat(filepath.Join(dir, name)); err == nil && fi.ModTime().After(last) {
+			last = fi.ModTime()
+		}
+	}
+	return last
+}
+
+// spoolWatchdog enforces recorded limits from a spool runtime's Wait loop.
+// check is called once per poll; when a limit is crossed it calls
+// terminate with the limit's marker, after which the wrapper's exit file
+// carries the marker and Wait returns the limit error as ExitErr.
+type spoolWatchdog struct {
+	dir       string
+	limits    execLimits
+	ok        bool
+	next      time.Time
+	terminate func(marker string) error
+}
+
+func newSpoolWatchdog(dir string, terminate func(marker string) error) *spoolWatchdog {
+	l, ok := readSpoolLimits(dir)
+	return &spoolWatchdog{dir: dir, limits: l, ok: ok && l.enabled(), terminate: terminate}
+}
+
+func (w *spoolWatchdog) check(now time.Time) error {
+	if !w.ok || now.Before(w.next) {
+		return nil
+	}
+	w.next = now.Add(w.limits.checkInterval())
+	err := w.limits.exceeded(now, spoolLastOutput(w.dir))
+	if err == nil {
+		return nil
+	}
+	w.ok = false
+	return w.terminate(limitMarker(err))
+}
@@ -12,11 +12,28 @@ import (
 // SessionStore persists the mapping from a controller-side session key
 // (typically "<run-id>/<role-id>") to the underlying agent session id. The
 // adapter uses it to resume sessions across calls.
+//
+// The daemon's store is orch.Store.Sessions(), which survives restarts; the
+// in-memory default used when none is given does not.
 type SessionStore interface {
 	Get(key string) (sessionID string, ok bool)
 	Put(key string, sessionID string)
 }
 
+// SessionForker is implemented by session stores that let an operator fork
+// a session: the next invocation for key resumes the stored conversation
+// under a new session id, leaving the original untouched. The following
+// Put consumes the request.
+type SessionForker interface {
+	ForkPending(key string) bool
+}
+
+// forkPending reports whether sess has a fork request for key.
+func forkPending(sess SessionStore, key string) bool {
+	f, ok := sess.(SessionForker)
+	return ok && f.ForkPending(key)
+}
+
 // MakiAdapter implements Adapter on top of Maki's SDK streaming mode. It
 // hides the CLI flags, the stream-json wire format, and the session resume
 // primitive from the controller.
@@ -31,15 +48,16 @@ type SessionStore interface {
 // the SessionStore and adds `--session <sid>` to the invocation, so revise
 // and review loops for the same role continue the same conversation
 // context. The captured session id from each result event is written back
-// to the store.
+// to the store. A pending fork (see SessionForker) adds `--fork-session`.
 type MakiAdapter struct {
 	bin  string
 	sess SessionStore
 }
 
 // NewMakiAdapter builds a MakiAdapter. bin is the path to the `maki` binary.
-// sessions is the session store; pass an in-memory or persistent
-// implementation. nil sessions get an in-memory default.
+// sessions is the session store, normally orch.Store.Sessions() so session
+// reuse survives daemon restarts. nil sessions get an in-memory default,
+// which is only suitable for tests and one-shot use.
 func NewMakiAdapter(bin string, sessions SessionStore) *MakiAdapter {
 	if bin == "" {
 		bin = "maki"
@@ -67,6 +85,9 @@ func (a *MakiAdapter) BuildInvocation(_ context.Context, req Request) (Invocatio
 	if req.SessionKey != "" {
 		if sid, ok := a.sess.Get(req.SessionKey); sid != "" && ok {
 			args = append(args, "--session", sid)
+			if forkPending(a.sess, req.SessionKey) {
+				args = append(args, "--fork-session")
+			}
 		}
 	}
 	stdin, err := encodeUserMessage(req.Prompt)
@@ -79,12 +100,16 @@ func (a *MakiAdapter) BuildInvocation(_ context.Context, req Request) (Invocatio
 		Args:        args,
 		Dir:         req.WorkingDir,
 		Stdin:       stdin,
+		Transcript:  req.OutputPaths.Transcript,
+		Timeout:     req.Timeout,
+		IdleTimeout: req.IdleTimeout,
 	}, nil
 }
 
 // ParseResult turns the captured stdout into a normalized Result. It scans
-// the JSONL stream for the `result` event, captures the agent session id
-// from any event wrapper, and writes it back to the SessionStore.
+// the JSONL stream for the `result` event, takes the token usage and cost
+// it reports, captures the agent session id from any event wrapper, and
+// writes it back to the SessionStore.
 func (a *MakiAdapter) ParseResult(_ context.Context, req Request, raw RuntimeResult) (Result, error) {
 	res := Result{IsError: raw.ExitErr != nil}
 
@@ -117,6 +142,7 @@ func (a *MakiAdapter) ParseResult(_ context.Context, req Request, raw RuntimeRes
 			resultText = ev.Result
 			resultSubtype = ev.Subtype
 			resultIsErr = ev.IsError || strings.EqualFold(ev.Subtype, "error")
+			res.Usage = ev.usage()
 		}
 	}
 
@@ -143,11 +169,31 @@ func (a *MakiAdapter) ParseResult(_ context.Context, req Request, raw RuntimeRes
 // Every line carries a `session_id`; the `result` line carries the final
 // outcome.
 type makiWireEvent struct {
-	Type      string `json:"type"`
-	Subtype   string `json:"subtype"`
-	IsError   bool   `json:"is_error"`
-	Result    string `json:"result"`
-	SessionID string `json:"session_id"`
+	Type         string    `json:"type"`
+	Subtype      string    `json:"subtype"`
+	IsError      bool      `json:"is_error"`
+	Result       string    `json:"result"`
+	SessionID    string    `json:"session_id"`
+	TotalCostUSD float64   `json:"total_cost_usd"`
+	Usage        makiUsage `json:"usage"`
+}
+
+// makiUsage is the token accounting of a result event. Cache reads and
+// writes are input tokens too, so they count towards InputTokens.
+type makiUsage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+}
+
+func (ev makiWireEvent) usage() Usage {
+	u := ev.Usage
+	return Usage{
+		InputTokens:  u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens,
+		OutputTokens: u.OutputTokens,
+		CostUSD:      ev.TotalCostUSD,
+	}
 }
 
 // encodeUserMessage wraps the prompt in the single inbound user message that
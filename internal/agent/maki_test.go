@@ -76,6 +76,31 @@ func TestMakiAdapterBuildInvocationResumeSession(t *testing.T) {
 	}
 }
 
+// forkingStore is an in-memory SessionStore with a pending fork per key.
+type forkingStore struct {
+	*memorySessionStore
+	fork map[string]bool
+}
+
+func (s forkingStore) ForkPending(key string) bool { return s.fork[key] }
+
+func TestMakiAdapterBuildInvocationForkSession(t *testing.T) {
+	store := forkingStore{newMemorySessionStore(), map[string]bool{"run-1/planner": true}}
+	store.Put("run-1/planner", "sid-existing")
+	a := NewMakiAdapter("maki", store)
+	inv, err := a.BuildInvocation(context.Background(), Request{
+		SessionKey: "run-1/planner",
+		Prompt:     "again",
+		WorkingDir: "/tmp",
+	})
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	if n := len(inv.Args); n < 3 || !equalStrings(inv.Args[n-3:], []string{"--session", "sid-existing", "--fork-session"}) {
+		t.Fatalf("args=%v, want resume with --fork-session", inv.Args)
+	}
+}
+
 func TestMakiAdapterBuildInvocationRequiresFields(t *testing.T) {
 	a := NewMakiAdapter("maki", nil)
 	if _, err := a.BuildInvocation(context.Background(), Request{Prompt: "p"}); err == nil {
@@ -111,6 +136,19 @@ func TestMakiAdapterParseResultSuccess(t *testing.T) {
 	}
 }
 
+func TestMakiAdapterParseResultUsage(t *testing.T) {
+	a := NewMakiAdapter("maki", nil)
+	stdout := []byte(`{"type":"result","subtype":"success","result":"ok","session_id":"sid-1","total_cost_usd":0.125,` +
+		`"usage":{"input_tokens":100,"cache_creation_input_tokens":20,"cache_read_input_tokens":3000,"output_tokens":450}}` + "\n")
+	res, err := a.ParseResult(context.Background(), Request{}, RuntimeResult{Stdout: stdout})
+	if err != nil {
+		t.Fatalf("ParseResult: %v", err)
+	}
+	if want := (Usage{InputTokens: 3120, OutputTokens: 450, CostUSD: 0.125}); res.Usage != want {
+		t.Fatalf("Usage = %+v, want %+v", res.Usage, want)
+	}
+}
+
 func TestMakiAdapterParseResultIsError(t *testing.T) {
 	a := NewMakiAdapter("maki", nil)
 	stdout := []byte(`{"type":"result","subtype":"error","is_error":true,"result":"oops","session_id":"sid-e"}` + "\n")
@@ -3,12 +3,16 @@
 package agent
 
 import (
+	"context"
 	"os"
 	"os/exec"
+	"time"
 )
 
 func configureProcess(_ *exec.Cmd) {}
 
-func stopProcess(proc *os.Process) error {
+// stopProcess kills proc. There is no SIGTERM to deliver here, so the
+// grace period is ignored.
+func stopProcess(_ context.Context, proc *os.Process, _ time.Duration) error {
 	return proc.Kill()
 }
@@ -3,20 +3,69 @@
 package agent
 
 import (
+	"context"
 	"errors"
 	"os"
 	"os/exec"
 	"syscall"
+	"time"
 )
 
+// stopPollInterval is how often stopProcess checks whether the process
+// group has exited during its grace period.
+const stopPollInterval = 50 * time.Millisecond
+
 func configureProcess(cmd *exec.Cmd) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 }
 
-func stopProcess(proc *os.Process) error {
-	err := syscall.Kill(-proc.Pid, syscall.SIGKILL)
+// stopProcess stops proc's whole process group. With a grace period the
+// group first gets SIGTERM, so an agent can flush partial results, and
+// SIGKILL only if it is still there when grace runs out or ctx ends.
+// Without one it is killed at once.
+func stopProcess(ctx context.Context, proc *os.Process, grace time.Duration) error {
+	pgid, err := syscall.Getpgid(proc.Pid)
+	if err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+		return err
+	}
+	if grace > 0 {
+		if err := signalGroup(pgid, syscall.SIGTERM); err != nil || !groupAlive(pgid) {
+			return err
+		}
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		ticker := time.NewTicker(stopPollInterval)
+		defer ticker.Stop()
+	wait:
+		for {
+			select {
+			case <-ticker.C:
+				if !groupAlive(pgid) {
+					return nil
+				}
+			case <-timer.C:
+				break wait
+			case <-ctx.Done():
+				break wait
+			}
+		}
+	}
+	return signalGroup(pgid, syscall.SIGKILL)
+}
+
+// signalGroup sends sig to a process group; a group that is already gone
+// is not an error.
+func signalGroup(pgid int, sig syscall.Signal) error {
+	err := syscall.Kill(-pgid, sig)
 	if errors.Is(err, syscall.ESRCH) {
 		return nil
 	}
 	return err
 }
+
+func groupAlive(pgid int) bool {
+	return syscall.Kill(-pgid, 0) == nil
+}
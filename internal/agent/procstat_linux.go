@@ -0,0 +1,49 @@
+//go:build linux
+
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strconv"
+)
+
+// processStartTime returns the start time of pid in clock ticks since boot
+// (field 22 of /proc/<pid>/stat). Together with the pid it identifies a
+// process across pid reuse.
+func processStartTime(pid int) (uint64, error) {
+	fields, err := procStatFields(pid)
+	if err != nil {
+		return 0, err
+	}
+	// fields[0] is field 3 (state), so starttime (22) is fields[19].
+	if len(fields) < 20 {
+		return 0, errors.New("agent: short /proc stat")
+	}
+	return strconv.ParseUint(string(fields[19]), 10, 64)
+}
+
+// processAlive reports whether pid exists and is not a zombie.
+func processAlive(pid int) bool {
+	fields, err := procStatFields(pid)
+	if err != nil || len(fields) == 0 {
+		return false
+	}
+	state := string(fields[0])
+	return state != "Z" && state != "X"
+}
+
+// procStatFields returns the fields of /proc/<pid>/stat after the command
+// name, which may itself contain spaces and parentheses.
+func procStatFields(pid int) ([][]byte, error) {
+	b, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return nil, err
+	}
+	i := bytes.LastIndexByte(b, ')')
+	if i < 0 {
+		return nil, errors.New("agent: malformed /proc stat")
+	}
+	return bytes.Fields(b[i+1:]), nil
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package agent
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// processStartTime is unavailable without /proc; callers fall back to a
+// pid-only liveness check.
+func processStartTime(int) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
+
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return p.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"bdtui/internal/workflow"
+)
+
+// ErrUnknownRunner is returned by Registry lookups for a runner id with no
+// registered adapter.
+var ErrUnknownRunner = errors.New("agent: unknown runner")
+
+// Registry maps runner ids (workflow.RoleContract.Runner) to adapters so
+// the controller can pick the adapter per role. The built-in runner is
+// registered in Go; declarative runners come from the workflow bundle:
+//
+//	reg := NewRegistry()
+//	reg.Register(workflow.DefaultRunner, NewMakiAdapter("", sessions))
+//	err := reg.RegisterSpecs(bundle.Runners, sessions)
+//	adapter, err := reg.ForRole(role)
+type Registry struct {
+	mu       sync.RWMutex
+	adapters map[string]Adapter
+}
+
+func NewRegistry() *Registry {
+	return &Registry{adapters: map[string]Adapter{}}
+}
+
+// Register installs a for runner id, replacing any previous adapter.
+func (r *Registry) Register(id string, a Adapter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.adapters[id] = a
+}
+
+// RegisterSpecs builds and registers a generic adapter for each runner
+// spec. A spec may override a Go-registered runner of the same id. Nothing
+// is registered if any spec is invalid.
+func (r *Registry) RegisterSpecs(specs map[string]workflow.RunnerSpec, sessions SessionStore) error {
+	built := make(map[string]Adapter, len(specs))
+	for id, spec := range specs {
+		if spec.ID != id {
+			return fmt.Errorf("agent: runner %q declares id %q", id, spec.ID)
+		}
+		a, err := NewSpecAdapter(spec, sessions)
+		if err != nil {
+			return err
+		}
+		built[id] = a
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, a := range built {
+		r.adapters[id] = a
+	}
+	return nil
+}
+
+// Lookup returns the adapter registered for runner id.
+func (r *Registry) Lookup(id string) (Adapter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.adapters[id]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRunner, id)
+	}
+	return a, nil
+}
+
+// ForRole returns the adapter for the role's runner, DefaultRunner when the
+// role names none.
+func (r *Registry) ForRole(role workflow.RoleContract) (Adapter, error) {
+	return r.Lookup(role.RunnerID())
+}
+
+// Runners returns the registered runner ids, sorted.
+func (r *Registry) Runners() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.adapters))
+	for id := range r.adapters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
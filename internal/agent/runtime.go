@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // Invocation is a provider-agnostic description of what the runtime should
@@ -15,11 +16,27 @@ type Invocation struct {
 	Args        []string
 	Dir         string
 	Stdin       []byte
+	// Transcript, when set, is a file the runtime writes stdout to as it
+	// arrives (not only at exit), so the agent's stream can be tailed while
+	// it runs. It is created or truncated on spawn.
+	Transcript string
+	// Timeout and IdleTimeout, when positive, make the runtime kill the
+	// execution once it has run that long or has written nothing to stdout
+	// or stderr for that long. The result then carries ErrExecutionTimeout
+	// or ErrExecutionIdle as ExitErr.
+	Timeout     time.Duration
+	IdleTimeout time.Duration
+	// Env, when non-nil, is the process's entire environment (KEY=VALUE);
+	// nil inherits the daemon's.
+	Env []string
 }
 
 // Execution is the durable, runtime-side identity of a single attempt.
 type Execution struct {
 	ID string
+	// PaneID is the herdr pane running the attempt (HerdrRuntime only). The
+	// controller persists it in orch.Execution.PaneID.
+	PaneID string
 }
 
 // RuntimeResult is the captured outcome of a runtime invocation. It is the
@@ -40,8 +57,9 @@ type InspectResult struct {
 	Running bool
 }
 
-// Runtime owns the lifecycle of an external process invocation. It is the
-// seam where the future HerdrRuntime plugs in.
+// Runtime owns the lifecycle of an external process invocation.
+// ExecRuntime runs the process directly; HerdrRuntime runs it in a herdr
+// pane and can reattach after a daemon restart.
 //
 // Durable-id contract:
 //
@@ -55,8 +73,12 @@ type InspectResult struct {
 //   - Inspect(ctx, Execution{ID}) exposes the Found/Running state for
 //     callers that want to decide between Wait and Stop without Wait
 //     blocking.
-//   - Wait blocks until the execution completes.
-//   - Stop terminates a running execution.
+//   - Wait blocks until the execution completes, is killed for crossing
+//     Invocation.Timeout/IdleTimeout, or ctx is done (ctx.Err(); the
+//     execution keeps running).
+//   - Stop terminates a running execution: SIGTERM first, then SIGKILL
+//     once the runtime's StopGrace has passed (or ctx ends), so the agent
+//     can flush partial results. It returns when the process is gone.
 type Runtime interface {
 	Spawn(ctx context.Context, inv Invocation) (Execution, error)
 	Reattach(ctx context.Context, exec Execution) (RuntimeResult, error)
@@ -65,6 +87,10 @@ type Runtime interface {
 	Stop(ctx context.Context, exec Execution) error
 }
 
+// DefaultStopGrace is how long the runtime constructors let a stopped
+// execution exit on SIGTERM before killing it.
+const DefaultStopGrace = 10 * time.Second
+
 // ErrDuplicateExecution is returned by Spawn when inv.ExecutionID already
 // has a live record in the runtime.
 var ErrDuplicateExecution = errors.New("agent: runtime: duplicate execution id")
\ No newline at end of file
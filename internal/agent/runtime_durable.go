@@ -0,0 +1,252 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurableExecRuntime is the restart-safe variant of ExecRuntime. Instead of
+// buffering output in memory it runs the invocation through a small wrapper
+// script that spools stdout/stderr under spoolDir (normally in run
+// storage) and writes the exit status when the command finishes. Spawn also
+// records the wrapper's pid and /proc start time, so after a daemon
+// restart Reattach can tell the still-running process apart from an
+// unrelated one that reused the pid:
+//
+//   - exit file present: completed; the spooled output is the result.
+//   - recorded pid alive with the recorded start time: still running.
+//   - neither: the process died without finishing; ErrLostExecution.
+//
+// The wrapper runs in its own process group (as with ExecRuntime), so it
+// outlives the daemon and Stop can kill the whole group.
+type DurableExecRuntime struct {
+	spoolDir string
+	// PollInterval is how often Wait checks the spool for completion.
+	PollInterval time.Duration
+	// StopGrace is how long Stop (and a crossed limit) waits after
+	// SIGTERM before SIGKILL; 0 kills at once.
+	StopGrace time.Duration
+}
+
+const defaultDurablePollInterval = 100 * time.Millisecond
+
+func NewDurableExecRuntime(spoolDir string) *DurableExecRuntime {
+	return &DurableExecRuntime{spoolDir: spoolDir, PollInterval: defaultDurablePollInterval, StopGrace: DefaultStopGrace}
+}
+
+// procRecord identifies the wrapper process of an execution.
+type procRecord struct {
+	pid   int
+	start uint64 // 0 when the platform has no /proc start time
+}
+
+func (p procRecord) alive() bool {
+	if p.pid <= 0 || !processAlive(p.pid) {
+		return false
+	}
+	if p.start == 0 {
+		return true
+	}
+	cur, err := processStartTime(p.pid)
+	return err == nil && cur == p.start
+}
+
+func (r *DurableExecRuntime) execDir(id string) string {
+	return filepath.Join(r.spoolDir, id)
+}
+
+// Spawn reserves the spool directory for inv.ExecutionID, starts the
+// wrapper and records its identity. A duplicate ID fails with
+// ErrDuplicateExecution, including across daemon restarts.
+func (r *DurableExecRuntime) Spawn(_ context.Context, inv Invocation) (Execution, error) {
+	if inv.ExecutionID == "" {
+		return Execution{}, errors.New("agent: DurableExecRuntime: Invocation.ExecutionID is required")
+	}
+	if inv.Bin == "" {
+		return Execution{}, errors.New("agent: DurableExecRuntime: Invocation.Bin is required")
+	}
+	if !validSpoolID(inv.ExecutionID) {
+		return Execution{}, fmt.Errorf("agent: DurableExecRuntime: invalid ExecutionID %q", inv.ExecutionID)
+	}
+
+	dir, err := reserveSpool(r.spoolDir, inv.ExecutionID)
+	if err != nil {
+		return Execution{}, err
+	}
+	if err := r.start(dir, inv); err != nil {
+		_ = os.RemoveAll(dir)
+		return Execution{}, err
+	}
+	return Execution{ID: inv.ExecutionID}, nil
+}
+
+func (r *DurableExecRuntime) start(dir string, inv Invocation) error {
+	if err := writeSpoolScript(dir, inv, false); err != nil {
+		return err
+	}
+	if err := writeSpoolLimits(dir, limitsFor(inv, time.Now())); err != nil {
+		return err
+	}
+	cmd := exec.Command("/bin/sh", filepath.Join(dir, spoolScript))
+	configureProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	rec := procRecord{pid: cmd.Process.Pid}
+	if start, err := processStartTime(rec.pid); err == nil {
+		rec.start = start
+	}
+	if err := writeProcRecord(dir, rec); err != nil {
+		_ = stopProcess(context.Background(), cmd.Process, 0)
+		_ = cmd.Wait()
+		return err
+	}
+	// Reap the wrapper so it does not linger as a zombie that processAlive
+	// would have to skip; the result itself is read from the spool.
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// writeProcRecord writes "<pid> <start>" via rename so readers never see a
+// partial record.
+func writeProcRecord(dir string, rec procRecord) error {
+	tmp := filepath.Join(dir, spoolProc+".tmp")
+	data := fmt.Sprintf("%d %d\n", rec.pid, rec.start)
+	if err := os.WriteFile(tmp, []byte(data), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, spoolProc))
+}
+
+func readProcRecord(dir string) (procRecord, bool) {
+	b, err := os.ReadFile(filepath.Join(dir, spoolProc))
+	if err != nil {
+		return procRecord{}, false
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) != 2 {
+		return procRecord{}, false
+	}
+	pid, err1 := strconv.Atoi(fields[0])
+	start, err2 := strconv.ParseUint(fields[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return procRecord{}, false
+	}
+	return procRecord{pid: pid, start: start}, true
+}
+
+// wrapperRunning reports whether the wrapper recorded in dir's proc file
+// is still running. HerdrRuntime wrappers record themselves.
+func wrapperRunning(dir string) bool {
+	rec, ok := readProcRecord(dir)
+	return ok && rec.alive()
+}
+
+// Inspect reports Found while the execution has a recorded exit status or
+// a live wrapper process, without blocking.
+func (r *DurableExecRuntime) Inspect(_ context.Context, exec Execution) (InspectResult, error) {
+	dir := r.execDir(exec.ID)
+	if ok, err := spoolExists(dir); !ok {
+		return InspectResult{}, err
+	}
+	if _, done := spoolExitStatus(dir); done {
+		return InspectResult{Found: true}, nil
+	}
+	if wrapperRunning(dir) {
+		return InspectResult{Found: true, Running: true}, nil
+	}
+	// The wrapper may have exited between the two checks.
+	if _, done := spoolExitStatus(dir); done {
+		return InspectResult{Found: true}, nil
+	}
+	return InspectResult{}, nil
+}
+
+// Reattach waits on an execution spawned by any DurableExecRuntime sharing
+// the same spool directory, including one in a previous daemon process.
+func (r *DurableExecRuntime) Reattach(ctx context.Context, exec Execution) (RuntimeResult, error) {
+	return r.Wait(ctx, exec)
+}
+
+// Wait polls the spool until the wrapper records an exit status. It
+// returns ErrLostExecution if the spool is missing or the wrapper is gone
+// without one. While it polls it enforces the limits recorded at Spawn,
+// so an execution outliving its daemon is still killed by the next Wait
+// (or Reattach). A stopped execution is done once its wrapper has exited,
+// so output flushed during the grace period is part of the result.
+func (r *DurableExecRuntime) Wait(ctx context.Context, exec Execution) (RuntimeResult, error) {
+	dir := r.execDir(exec.ID)
+	if ok, err := spoolExists(dir); !ok {
+		if err != nil {
+			return RuntimeResult{}, err
+		}
+		return RuntimeResult{}, ErrLostExecution
+	}
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = defaultDurablePollInterval
+	}
+	watchdog := newSpoolWatchdog(dir, func(marker string) error { return r.terminate(ctx, dir, marker) })
+	for {
+		if status, done := spoolExitStatus(dir); done && !(stopMarker(status) && wrapperRunning(dir)) {
+			return readSpoolResult(dir, status)
+		}
+		if err := watchdog.check(time.Now()); err != nil {
+			return RuntimeResult{}, err
+		}
+		if !wrapperRunning(dir) {
+			if status, done := spoolExitStatus(dir); done {
+				return readSpoolResult(dir, status)
+			}
+			return RuntimeResult{}, ErrLostExecution
+		}
+		select {
+		case <-ctx.Done():
+			return RuntimeResult{}, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Stop terminates the wrapper's process group (SIGTERM, then SIGKILL after
+// StopGrace), but only after confirming via the start time that the
+// recorded pid still belongs to it, and records the stop so Wait returns
+// ErrExecutionStopped. No-op if the ID is unknown or already finished.
+func (r *DurableExecRuntime) Stop(ctx context.Context, exec Execution) error {
+	dir := r.execDir(exec.ID)
+	if ok, err := spoolExists(dir); !ok {
+		return err
+	}
+	if _, done := spoolExitStatus(dir); done {
+		return nil
+	}
+	return r.terminate(ctx, dir, exitStopped)
+}
+
+// terminate records marker as the exit status and stops the wrapper's
+// process group. The wrapper's signal trap waits for the command, so the
+// group exits once the agent has handled SIGTERM.
+func (r *DurableExecRuntime) terminate(ctx context.Context, dir, marker string) error {
+	// Mark first so the wrapper's signal trap cannot record 143 instead.
+	if err := markSpoolExit(dir, marker); err != nil {
+		return err
+	}
+	if rec, ok := readProcRecord(dir); ok && rec.alive() {
+		proc, err := os.FindProcess(rec.pid)
+		if err != nil {
+			return err
+		}
+		if err := stopProcess(ctx, proc, r.StopGrace); err != nil {
+			return fmt.Errorf("agent: DurableExecRuntime: stop pid %d: %w", rec.pid, err)
+		}
+	}
+	return nil
+}
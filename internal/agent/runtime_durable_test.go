@@ -0,0 +1,349 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func newDurableRuntime(t *testing.T) (*DurableExecRuntime, string) {
+	t.Helper()
+	spool := filepath.Join(t.TempDir(), "executions")
+	r := NewDurableExecRuntime(spool)
+	r.PollInterval = 10 * time.Millisecond
+	// A SIGTERM that lands before the wrapper starts its command is only
+	// acted on after it; keep the escalation to SIGKILL short.
+	r.StopGrace = 2 * time.Second
+	return r, spool
+}
+
+func TestDurableExecRuntimeSpawnWait(t *testing.T) {
+	r, _ := newDurableRuntime(t)
+	ctx := waitCtx(t)
+	execID := AllocateExecutionID()
+
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: execID,
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "cat; echo oops >&2; exit 4"},
+		Stdin:       []byte("hello\n"),
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if exec.ID != execID {
+		t.Fatalf("Execution.ID=%q want %q", exec.ID, execID)
+	}
+	if _, err := r.Spawn(ctx, Invocation{ExecutionID: execID, Bin: "/bin/true"}); !errors.Is(err, ErrDuplicateExecution) {
+		t.Fatalf("second Spawn = %v, want ErrDuplicateExecution", err)
+	}
+
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	var code *ExitCodeError
+	if string(res.Stdout) != "hello\n" || string(res.Stderr) != "oops\n" || !errors.As(res.ExitErr, &code) || code.Code != 4 {
+		t.Fatalf("result stdout=%q stderr=%q exit=%v", res.Stdout, res.Stderr, res.ExitErr)
+	}
+	ins, err := r.Inspect(ctx, exec)
+	if err != nil || !ins.Found || ins.Running {
+		t.Fatalf("Inspect after completion = %+v, %v", ins, err)
+	}
+}
+
+// TestDurableExecRuntimeReattachAfterRestart reattaches from a fresh
+// runtime over the same spool while the wrapper is still running, as a
+// restarted daemon would.
+func TestDurableExecRuntimeReattachAfterRestart(t *testing.T) {
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+	gate := filepath.Join(t.TempDir(), "go")
+
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "while [ ! -f \"$0\" ]; do sleep 0.02; done; echo finished", gate},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	restarted := NewDurableExecRuntime(spool)
+	restarted.PollInterval = 10 * time.Millisecond
+	ins, err := restarted.Inspect(ctx, exec)
+	if err != nil || !ins.Found || !ins.Running {
+		t.Fatalf("Inspect while running = %+v, %v", ins, err)
+	}
+	if err := os.WriteFile(gate, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	res, err := restarted.Reattach(ctx, exec)
+	if err != nil {
+		t.Fatalf("Reattach: %v", err)
+	}
+	if string(res.Stdout) != "finished\n" || res.ExitErr != nil {
+		t.Fatalf("Reattach result = %q, %v", res.Stdout, res.ExitErr)
+	}
+}
+
+func TestDurableExecRuntimeStop(t *testing.T) {
+	r, _ := newDurableRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait after Stop: %v", err)
+	}
+	if !errors.Is(res.ExitErr, ErrExecutionStopped) {
+		t.Fatalf("ExitErr = %v, want ErrExecutionStopped", res.ExitErr)
+	}
+}
+
+// TestDurableExecRuntimeStopGrace checks a stopped agent gets to flush
+// output before Wait reports the stop.
+func TestDurableExecRuntimeStopGrace(t *testing.T) {
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sh", Args: []string{"-c", gracefulAgent}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForFile(t, ctx, filepath.Join(spool, exec.ID, spoolStdout), "ready")
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait after Stop: %v", err)
+	}
+	if !errors.Is(res.ExitErr, ErrExecutionStopped) || !strings.Contains(string(res.Stdout), "flushed") {
+		t.Fatalf("result = %q, %v; want flushed output and ErrExecutionStopped", res.Stdout, res.ExitErr)
+	}
+}
+
+// TestDurableExecRuntimeEnv checks Invocation.Env replaces the command's
+// environment.
+func TestDurableExecRuntimeEnv(t *testing.T) {
+	r, _ := newDurableRuntime(t)
+	ctx := waitCtx(t)
+	t.Setenv("DURABLE_SECRET", "s3cret")
+
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", `echo "[$DURABLE_SECRET] [$KEPT]"`},
+		Env:         []string{"PATH=" + os.Getenv("PATH"), "KEPT=it's kept"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := strings.TrimSpace(string(res.Stdout)); got != "[] [it's kept]" {
+		t.Fatalf("stdout = %q", got)
+	}
+}
+
+// TestDurableExecRuntimeLostProcess kills the wrapper behind the runtime's
+// back, so it never records an exit status.
+func TestDurableExecRuntimeLostProcess(t *testing.T) {
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	rec, ok := readProcRecord(filepath.Join(spool, exec.ID))
+	if !ok {
+		t.Fatal("no proc record after Spawn")
+	}
+	if err := syscall.Kill(-rec.pid, syscall.SIGKILL); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+
+	if _, err := r.Reattach(ctx, exec); !errors.Is(err, ErrLostExecution) {
+		t.Fatalf("Reattach after kill = %v, want ErrLostExecution", err)
+	}
+	if ins, err := r.Inspect(ctx, exec); err != nil || ins.Found {
+		t.Fatalf("Inspect after kill = %+v, %v", ins, err)
+	}
+	if _, err := r.Reattach(ctx, Execution{ID: "never-spawned"}); !errors.Is(err, ErrLostExecution) {
+		t.Fatalf("Reattach unknown id = %v, want ErrLostExecution", err)
+	}
+}
+
+// TestDurableExecRuntimeRejectsReusedPID points a record at a live process
+// (the test binary) with the wrong start time, as if the wrapper's pid had
+// been recycled.
+func TestDurableExecRuntimeRejectsReusedPID(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("start-time verification needs /proc")
+	}
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+
+	start, err := processStartTime(os.Getpid())
+	if err != nil {
+		t.Fatalf("processStartTime: %v", err)
+	}
+	dir, err := reserveSpool(spool, "recycled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeProcRecord(dir, procRecord{pid: os.Getpid(), start: start + 1}); err != nil {
+		t.Fatal(err)
+	}
+	if ins, err := r.Inspect(ctx, Execution{ID: "recycled"}); err != nil || ins.Found {
+		t.Fatalf("Inspect with recycled pid = %+v, %v", ins, err)
+	}
+	// Stop must not signal the unrelated process.
+	if err := r.Stop(ctx, Execution{ID: "recycled"}); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	if err := writeProcRecord(dir, procRecord{pid: os.Getpid(), start: start}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, spoolExit)); err != nil {
+		t.Fatal(err)
+	}
+	if ins, err := r.Inspect(context.Background(), Execution{ID: "recycled"}); err != nil || !ins.Running {
+		t.Fatalf("Inspect with matching start time = %+v, %v", ins, err)
+	}
+}
+
+// TestDurableExecRuntimeInterrupted sends Ctrl-C to the wrapper's process
+// group; the wrapper must still record an exit status.
+func TestDurableExecRuntimeInterrupted(t *testing.T) {
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	rec, ok := readProcRecord(filepath.Join(spool, exec.ID))
+	if !ok {
+		t.Fatal("no proc record after Spawn")
+	}
+	// Give the wrapper time to install its traps.
+	time.Sleep(100 * time.Millisecond)
+	if err := syscall.Kill(-rec.pid, syscall.SIGINT); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	var code *ExitCodeError
+	if !errors.As(res.ExitErr, &code) || code.Code != 130 {
+		t.Fatalf("ExitErr = %v, want exit status 130", res.ExitErr)
+	}
+}
+
+// TestDurableExecRuntimeStopMarkerSurvivesExit lets the wrapper finish
+// after the stop marker is written, as when Stop races a completing
+// command.
+func TestDurableExecRuntimeStopMarkerSurvivesExit(t *testing.T) {
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+	gate := filepath.Join(t.TempDir(), "go")
+
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "while [ ! -f \"$0\" ]; do sleep 0.02; done", gate},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	dir := filepath.Join(spool, exec.ID)
+	if err := markSpoolExit(dir, exitStopped); err != nil {
+		t.Fatalf("markSpoolExit: %v", err)
+	}
+	if err := os.WriteFile(gate, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for wrapperRunning(dir) {
+		select {
+		case <-ctx.Done():
+			t.Fatal("wrapper did not exit")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if status, _ := spoolExitStatus(dir); status != exitStopped {
+		t.Fatalf("exit status = %q, want %q", status, exitStopped)
+	}
+}
+
+// TestDurableExecRuntimeTranscript verifies the wrapper copies stdout to the
+// transcript without losing the command's exit status to the pipeline.
+func TestDurableExecRuntimeTranscript(t *testing.T) {
+	r, _ := newDurableRuntime(t)
+	ctx := waitCtx(t)
+	transcript := filepath.Join(t.TempDir(), "run", "transcript.jsonl")
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "echo '{\"type\":\"assistant\"}'; exit 4"},
+		Transcript:  transcript,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	var code *ExitCodeError
+	if !errors.As(res.ExitErr, &code) || code.Code != 4 {
+		t.Fatalf("exit = %v, want status 4", res.ExitErr)
+	}
+	b, err := os.ReadFile(transcript)
+	if err != nil || string(b) != string(res.Stdout) || string(b) != "{\"type\":\"assistant\"}\n" {
+		t.Fatalf("transcript=%q stdout=%q err=%v", b, res.Stdout, err)
+	}
+}
+
+// TestDurableExecRuntimeIdleTimeout checks the recorded idle limit is
+// enforced by a Wait in a fresh runtime, as after a daemon restart.
+func TestDurableExecRuntimeIdleTimeout(t *testing.T) {
+	r, spool := newDurableRuntime(t)
+	ctx := waitCtx(t)
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "echo started; sleep 30"},
+		IdleTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	restarted := NewDurableExecRuntime(spool)
+	restarted.PollInterval = 10 * time.Millisecond
+	res, err := restarted.Wait(ctx, exec)
+	if err != nil || !errors.Is(res.ExitErr, ErrExecutionIdle) || string(res.Stdout) != "started\n" {
+		t.Fatalf("Wait = %v (stdout %q), %v", res.ExitErr, res.Stdout, err)
+	}
+	if ins, _ := r.Inspect(ctx, exec); ins.Running {
+		t.Fatal("the idle wrapper should have been killed")
+	}
+}
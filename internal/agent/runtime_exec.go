@@ -4,24 +4,28 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // ExecRuntime is the MVP default Runtime. It uses os/exec to spawn the
 // agent binary, captures stdout/stderr asynchronously, and exposes the same
-// Spawn/Reattach/Inspect/Wait/Stop lifecycle the future HerdrRuntime will
-// satisfy. It exists so the agent package can be developed and tested
-// end-to-end before HerdrRuntime is implemented.
+// Spawn/Reattach/Inspect/Wait/Stop lifecycle as HerdrRuntime without
+// needing a herdr session.
 //
 // ExecRuntime keeps completed handles in its map after Wait so a same-process
 // Reattach can still retrieve the buffered result. After a daemon restart
 // the in-memory map is empty and Reattach returns ErrLostExecution; that
 // is the crash-recovery signal the controller resolves per bdtui-6pc.
+// DurableExecRuntime is the variant whose executions survive a restart.
 //
 // Durable-ID atomicity: Spawn reserves inv.ExecutionID in the map (with a
 // "reserved" placeholder) BEFORE calling cmd.Start(). Two concurrent
@@ -29,6 +33,10 @@ import (
 // reservation and returns ErrDuplicateExecution. Only one cmd.Start() ever
 // runs per ID, so a writer execution cannot be duplicated.
 type ExecRuntime struct {
+	// StopGrace is how long Stop (and a crossed limit) waits after
+	// SIGTERM before SIGKILL; 0 kills at once.
+	StopGrace time.Duration
+
 	mu    sync.Mutex
 	procs map[string]*execHandle
 }
@@ -49,17 +57,24 @@ type execHandle struct {
 	done       chan struct{}
 	exitErr    error
 	finishOnce sync.Once
+	// lastOutput is when stdout or stderr was last written (UnixNano).
+	lastOutput atomic.Int64
+	// limitErr is set by the watchdog before it kills the process and
+	// replaces the exit error.
+	limitErr error
 }
 
 func NewExecRuntime() *ExecRuntime {
-	return &ExecRuntime{procs: map[string]*execHandle{}}
+	return &ExecRuntime{StopGrace: DefaultStopGrace, procs: map[string]*execHandle{}}
 }
 
 // Spawn atomically reserves inv.ExecutionID and then starts the child
 // process. The reservation is committed before cmd.Start() so concurrent
 // Spawn calls with the same ID cannot both reach Start. If Start fails the
 // reservation is rolled back; if it succeeds the handle is moved to
-// handleStarted and a goroutine waits for completion.
+// handleStarted and a goroutine waits for completion. With
+// inv.Timeout/IdleTimeout a second goroutine kills the process once a limit
+// is crossed.
 func (r *ExecRuntime) Spawn(_ context.Context, inv Invocation) (Execution, error) {
 	if inv.ExecutionID == "" {
 		return Execution{}, errors.New("agent: ExecRuntime: Invocation.ExecutionID is required")
@@ -86,15 +101,29 @@ func (r *ExecRuntime) Spawn(_ context.Context, inv Invocation) (Execution, error
 	if inv.Dir != "" {
 		cmd.Dir = inv.Dir
 	}
+	if inv.Env != nil {
+		cmd.Env = inv.Env
+	}
 	if len(inv.Stdin) > 0 {
 		cmd.Stdin = bytes.NewReader(inv.Stdin)
 	} else {
 		cmd.Stdin = io.NopCloser(bytes.NewReader(nil))
 	}
-	cmd.Stdout = &h.stdoutBuf
-	cmd.Stderr = &h.stderrBuf
-
-	if err := cmd.Start(); err != nil {
+	var stdout io.Writer = &h.stdoutBuf
+	transcript, err := openTranscript(inv.Transcript)
+	if err == nil && transcript != nil {
+		stdout = &transcriptTee{buf: &h.stdoutBuf, f: transcript}
+	}
+	cmd.Stdout = activityWriter{w: stdout, last: &h.lastOutput}
+	cmd.Stderr = activityWriter{w: &h.stderrBuf, last: &h.lastOutput}
+	limits := limitsFor(inv, time.Now())
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		if transcript != nil {
+			_ = transcript.Close()
+		}
 		// Roll back the reservation and unblock any waiters with the
 		// start error so they do not deadlock on h.done.
 		r.mu.Lock()
@@ -113,13 +142,50 @@ func (r *ExecRuntime) Spawn(_ context.Context, inv Invocation) (Execution, error
 	r.mu.Unlock()
 
 	go func() {
-		h.exitErr = cmd.Wait()
+		err := cmd.Wait()
+		if transcript != nil {
+			_ = transcript.Close()
+		}
+		r.mu.Lock()
+		if h.limitErr != nil {
+			err = h.limitErr
+		}
+		r.mu.Unlock()
+		h.exitErr = err
 		h.finishOnce.Do(func() { close(h.done) })
 	}()
+	if limits.enabled() {
+		go r.watch(h, limits)
+	}
 
 	return Execution{ID: inv.ExecutionID}, nil
 }
 
+// watch kills h's process group once it crosses a limit. The limit error
+// is recorded first so the exit goroutine reports it instead of the kill
+// signal.
+func (r *ExecRuntime) watch(h *execHandle, limits execLimits) {
+	ticker := time.NewTicker(limits.checkInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.done:
+			return
+		case now := <-ticker.C:
+			err := limits.exceeded(now, time.Unix(0, h.lastOutput.Load()))
+			if err == nil {
+				continue
+			}
+			r.mu.Lock()
+			h.limitErr = err
+			proc := h.cmd.Process
+			r.mu.Unlock()
+			_ = stopProcess(context.Background(), proc, r.StopGrace)
+			return
+		}
+	}
+}
+
 // Reattach is the recovery entry point. If the in-memory map still holds
 // the ID, it Waits and returns the (possibly already-completed) result.
 // Otherwise it returns ErrLostExecution so the controller can resolve
@@ -147,8 +213,8 @@ func (r *ExecRuntime) Inspect(_ context.Context, exec Execution) (InspectResult,
 	}
 }
 
-// Wait blocks until the execution completes. Repeated calls on the same ID
-// return the same buffered result.
+// Wait blocks until the execution completes or ctx is done. Repeated calls
+// on the same ID return the same buffered result.
 func (r *ExecRuntime) Wait(ctx context.Context, exec Execution) (RuntimeResult, error) {
 	h, ok := r.lookup(exec.ID)
 	if !ok {
@@ -157,8 +223,12 @@ func (r *ExecRuntime) Wait(ctx context.Context, exec Execution) (RuntimeResult,
 	return r.waitHandle(ctx, exec.ID, h)
 }
 
-func (r *ExecRuntime) waitHandle(_ context.Context, _ string, h *execHandle) (RuntimeResult, error) {
-	<-h.done
+func (r *ExecRuntime) waitHandle(ctx context.Context, _ string, h *execHandle) (RuntimeResult, error) {
+	select {
+	case <-h.done:
+	case <-ctx.Done():
+		return RuntimeResult{}, ctx.Err()
+	}
 	return RuntimeResult{
 		Stdout:  append([]byte(nil), h.stdoutBuf.Bytes()...),
 		Stderr:  append([]byte(nil), h.stderrBuf.Bytes()...),
@@ -166,8 +236,9 @@ func (r *ExecRuntime) waitHandle(_ context.Context, _ string, h *execHandle) (Ru
 	}, nil
 }
 
-// Stop terminates a running execution. No-op if the ID is unknown or the
-// process already exited.
+// Stop terminates a running execution's process group, giving it
+// StopGrace to exit on SIGTERM, and returns once it is gone. No-op if the
+// ID is unknown or the process already exited.
 func (r *ExecRuntime) Stop(ctx context.Context, exec Execution) error {
 	h, ok := r.lookup(exec.ID)
 	if !ok {
@@ -201,7 +272,48 @@ func (r *ExecRuntime) Stop(ctx context.Context, exec Execution) error {
 		return nil
 	default:
 	}
-	return stopProcess(proc)
+	if err := stopProcess(ctx, proc, r.StopGrace); err != nil {
+		return err
+	}
+	select {
+	case <-h.done:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// transcriptTee captures stdout into buf and copies it to the transcript.
+// The buffer is the result; a failing transcript write (e.g. a full disk)
+// stops the copy but never the capture.
+type transcriptTee struct {
+	buf    *bytes.Buffer
+	f      *os.File
+	failed bool
+}
+
+func (t *transcriptTee) Write(p []byte) (int, error) {
+	if !t.failed {
+		if _, err := t.f.Write(p); err != nil {
+			t.failed = true
+		}
+	}
+	return t.buf.Write(p)
+}
+
+// openTranscript creates (or truncates) the transcript file of an
+// invocation, with its directory. An empty path means no transcript.
+func openTranscript(path string) (*os.File, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("agent: transcript: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("agent: transcript: %w", err)
+	}
+	return f, nil
 }
 
 func (r *ExecRuntime) lookup(id string) (*execHandle, bool) {
@@ -3,6 +3,9 @@ package agent
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -254,3 +257,163 @@ func TestExecRuntimeStop(t *testing.T) {
 		t.Fatal("Wait did not return after Stop")
 	}
 }
+
+// gracefulAgent traps SIGTERM to flush a last line, as an agent writing
+// out partial results would.
+const gracefulAgent = `trap 'echo flushed; exit 0' TERM; echo ready; while :; do sleep 0.05; done`
+
+// waitForFile polls until path contains want.
+func waitForFile(t *testing.T, ctx context.Context, path, want string) {
+	t.Helper()
+	for {
+		if b, _ := os.ReadFile(path); strings.Contains(string(b), want) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("%s never contained %q", path, want)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// TestExecRuntimeStopGrace checks Stop lets an agent handle SIGTERM and
+// kills one that ignores it once StopGrace has passed.
+func TestExecRuntimeStopGrace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	r := NewExecRuntime()
+	transcript := filepath.Join(t.TempDir(), "transcript")
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", gracefulAgent},
+		Transcript:  transcript,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForFile(t, ctx, transcript, "ready")
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if !strings.Contains(string(res.Stdout), "flushed") || res.ExitErr != nil {
+		t.Fatalf("result = %q, %v; want the SIGTERM handler's output and a clean exit", res.Stdout, res.ExitErr)
+	}
+
+	r.StopGrace = 200 * time.Millisecond
+	exec, err = r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "trap '' TERM; echo ready; sleep 30"},
+		Transcript:  transcript,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForFile(t, ctx, transcript, "ready")
+	start := time.Now()
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < r.StopGrace || elapsed > 5*time.Second {
+		t.Fatalf("Stop took %v, want about the %v grace period", elapsed, r.StopGrace)
+	}
+	if info, _ := r.Inspect(ctx, exec); info.Running {
+		t.Fatal("execution still running after Stop")
+	}
+}
+
+// TestExecRuntimeTranscriptStreams verifies stdout reaches the transcript
+// file while the process is still running, not only at exit.
+func TestExecRuntimeTranscriptStreams(t *testing.T) {
+	dir := t.TempDir()
+	transcript := filepath.Join(dir, "exec", "transcript.jsonl")
+	release := filepath.Join(dir, "release")
+	r := NewExecRuntime()
+	ctx := waitCtx(t)
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", `echo first; while [ ! -e "$1" ]; do sleep 0.02; done; echo second`, "sh", release},
+		Transcript:  transcript,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	for {
+		if b, _ := os.ReadFile(transcript); string(b) == "first\n" {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatal("first line never reached the transcript")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := os.WriteFile(release, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil || res.ExitErr != nil {
+		t.Fatalf("Wait = %+v, %v", res, err)
+	}
+	b, err := os.ReadFile(transcript)
+	if err != nil || string(b) != "first\nsecond\n" || string(res.Stdout) != string(b) {
+		t.Fatalf("transcript=%q stdout=%q err=%v", b, res.Stdout, err)
+	}
+}
+
+// TestExecRuntimeLimits covers the wall-clock and idle timeouts, and a
+// Wait that gives up when its context ends while the process keeps
+// running.
+func TestExecRuntimeLimits(t *testing.T) {
+	r := NewExecRuntime()
+	ctx := waitCtx(t)
+
+	timeout, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "while :; do echo tick; sleep 0.02; done"},
+		Timeout:     200 * time.Millisecond,
+		IdleTimeout: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	idle, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "echo started; sleep 30"},
+		IdleTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	res, err := r.Wait(ctx, timeout)
+	if err != nil || !errors.Is(res.ExitErr, ErrExecutionTimeout) || !strings.HasPrefix(string(res.Stdout), "tick\n") {
+		t.Fatalf("timeout Wait = %v (stdout %q), %v", res.ExitErr, res.Stdout, err)
+	}
+	res, err = r.Wait(ctx, idle)
+	if err != nil || !errors.Is(res.ExitErr, ErrExecutionIdle) || string(res.Stdout) != "started\n" {
+		t.Fatalf("idle Wait = %v (stdout %q), %v", res.ExitErr, res.Stdout, err)
+	}
+
+	slow, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := r.Wait(short, slow); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait past its context = %v, want DeadlineExceeded", err)
+	}
+	if ins, _ := r.Inspect(ctx, slow); !ins.Running {
+		t.Fatal("an abandoned Wait must not stop the execution")
+	}
+	_ = r.Stop(ctx, slow)
+}
@@ -0,0 +1,266 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bdtui/internal/adapters/herdr"
+)
+
+// HerdrRuntime runs each invocation in a fresh herdr pane so a human can
+// watch (and, if needed, take over) the agent. The pane id is the durable
+// handle: it is returned as Execution.PaneID for the controller to persist
+// in orch.Execution.PaneID.
+//
+// Nothing is held in memory. Spawn writes a per-execution spool directory
+// under spoolDir (keyed by the ExecutionID) containing a wrapper script
+// that runs the agent with stdout/stderr captured to files and finally
+// writes its exit status. Inspect, Wait and Reattach only read that
+// directory and ask herdr whether the pane is still alive, so a restarted
+// daemon recovers executions that outlived it:
+//
+//   - exit file present: completed; the spooled output is the result.
+//   - pane alive, no exit file: still running.
+//   - pane gone, no exit file: the process died with its pane; Reattach
+//     returns ErrLostExecution.
+//
+// herdr commands used: `pane create [--cwd DIR]`, `pane send-text ID TEXT`,
+// `pane get ID` and `pane close ID`.
+type HerdrRuntime struct {
+	runner   herdr.Runner
+	spoolDir string
+	// PollInterval is how often Wait checks for the exit file. The pane is
+	// probed every paneProbeEvery polls.
+	PollInterval time.Duration
+	// StopGrace is how long Stop (and a crossed limit) waits after
+	// SIGTERM to the wrapper before closing the pane; 0 closes it at once.
+	StopGrace time.Duration
+}
+
+const (
+	defaultHerdrPollInterval = 200 * time.Millisecond
+	paneProbeEvery           = 5
+)
+
+// NewHerdrRuntime returns a runtime that drives herdr through runner (nil
+// means the herdr binary on PATH) and spools executions under spoolDir,
+// normally a directory in run storage.
+func NewHerdrRuntime(runner herdr.Runner, spoolDir string) *HerdrRuntime {
+	if runner == nil {
+		runner = herdr.ShellRunner{}
+	}
+	return &HerdrRuntime{runner: runner, spoolDir: spoolDir, PollInterval: defaultHerdrPollInterval, StopGrace: DefaultStopGrace}
+}
+
+func (r *HerdrRuntime) execDir(id string) string {
+	return filepath.Join(r.spoolDir, id)
+}
+
+// Spawn claims the spool directory for inv.ExecutionID (os.Mkdir is the
+// atomic reservation, so a second Spawn with the same ID fails with
+// ErrDuplicateExecution), opens a pane and types the wrapper command into
+// it. Any failure after the reservation removes the directory again.
+func (r *HerdrRuntime) Spawn(_ context.Context, inv Invocation) (Execution, error) {
+	if inv.ExecutionID == "" {
+		return Execution{}, errors.New("agent: HerdrRuntime: Invocation.ExecutionID is required")
+	}
+	if inv.Bin == "" {
+		return Execution{}, errors.New("agent: HerdrRuntime: Invocation.Bin is required")
+	}
+	if !validSpoolID(inv.ExecutionID) {
+		return Execution{}, fmt.Errorf("agent: HerdrRuntime: invalid ExecutionID %q", inv.ExecutionID)
+	}
+
+	dir, err := reserveSpool(r.spoolDir, inv.ExecutionID)
+	if err != nil {
+		return Execution{}, err
+	}
+	paneID, err := r.start(dir, inv)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return Execution{}, err
+	}
+	return Execution{ID: inv.ExecutionID, PaneID: paneID}, nil
+}
+
+func (r *HerdrRuntime) start(dir string, inv Invocation) (string, error) {
+	if err := writeSpoolScript(dir, inv, true); err != nil {
+		return "", err
+	}
+	if err := writeSpoolLimits(dir, limitsFor(inv, time.Now())); err != nil {
+		return "", err
+	}
+
+	args := []string{"pane", "create"}
+	if inv.Dir != "" {
+		args = append(args, "--cwd", inv.Dir)
+	}
+	out, err := r.runner.Run(args...)
+	if err != nil {
+		return "", fmt.Errorf("agent: HerdrRuntime: create pane: %w", err)
+	}
+	paneID, err := parseHerdrPaneID(out)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, spoolPane), []byte(paneID), 0o600); err != nil {
+		_, _ = r.runner.Run("pane", "close", paneID)
+		return "", err
+	}
+	command := "sh " + shellQuote(filepath.Join(dir, spoolScript)) + "\n"
+	if _, err := r.runner.Run("pane", "send-text", paneID, command); err != nil {
+		_, _ = r.runner.Run("pane", "close", paneID)
+		return "", fmt.Errorf("agent: HerdrRuntime: start in pane %s: %w", paneID, err)
+	}
+	return paneID, nil
+}
+
+// parseHerdrPaneID reads the pane id from `herdr pane create` output, which
+// uses the same {"result": ...} envelope as `pane list`.
+func parseHerdrPaneID(raw string) (string, error) {
+	var out struct {
+		Result struct {
+			Pane struct {
+				PaneID string `json:"pane_id"`
+			} `json:"pane"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return "", fmt.Errorf("agent: HerdrRuntime: parse pane create output: %w", err)
+	}
+	id := strings.TrimSpace(out.Result.Pane.PaneID)
+	if id == "" {
+		return "", errors.New("agent: HerdrRuntime: pane create returned no pane_id")
+	}
+	return id, nil
+}
+
+// paneID prefers the spooled pane id, which is authoritative for this
+// execution directory, over the caller-supplied handle.
+func (r *HerdrRuntime) paneID(exec Execution) string {
+	if b, err := os.ReadFile(filepath.Join(r.execDir(exec.ID), spoolPane)); err == nil {
+		if id := strings.TrimSpace(string(b)); id != "" {
+			return id
+		}
+	}
+	return exec.PaneID
+}
+
+func (r *HerdrRuntime) paneAlive(paneID string) bool {
+	if paneID == "" {
+		return false
+	}
+	_, err := r.runner.Run("pane", "get", paneID)
+	return err == nil
+}
+
+// Inspect reports the execution as Found while it has a recorded exit
+// status or a live pane. A spool directory whose pane vanished without an
+// exit status is reported as not found: there is neither a process nor a
+// result to attach to.
+func (r *HerdrRuntime) Inspect(_ context.Context, exec Execution) (InspectResult, error) {
+	dir := r.execDir(exec.ID)
+	if ok, err := spoolExists(dir); !ok {
+		return InspectResult{}, err
+	}
+	if _, done := spoolExitStatus(dir); done {
+		return InspectResult{Found: true}, nil
+	}
+	if r.paneAlive(r.paneID(exec)) {
+		return InspectResult{Found: true, Running: true}, nil
+	}
+	// The pane may have closed right after the wrapper finished.
+	if _, done := spoolExitStatus(dir); done {
+		return InspectResult{Found: true}, nil
+	}
+	return InspectResult{}, nil
+}
+
+// Reattach waits on an execution spawned by any HerdrRuntime sharing the
+// same spool directory, including one in a previous daemon process.
+func (r *HerdrRuntime) Reattach(ctx context.Context, exec Execution) (RuntimeResult, error) {
+	return r.Wait(ctx, exec)
+}
+
+// Wait polls for the exit file and returns the spooled output. It returns
+// ErrLostExecution if the spool is missing or the pane disappears before
+// the wrapper records an exit status. Limits recorded at Spawn are enforced
+// while it polls by stopping the execution. A stopped execution is done
+// once its wrapper has exited.
+func (r *HerdrRuntime) Wait(ctx context.Context, exec Execution) (RuntimeResult, error) {
+	dir := r.execDir(exec.ID)
+	if ok, err := spoolExists(dir); !ok {
+		if err != nil {
+			return RuntimeResult{}, err
+		}
+		return RuntimeResult{}, ErrLostExecution
+	}
+	paneID := r.paneID(exec)
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = defaultHerdrPollInterval
+	}
+	watchdog := newSpoolWatchdog(dir, func(marker string) error { return r.terminate(ctx, dir, paneID, marker) })
+	for poll := 0; ; poll++ {
+		if status, done := spoolExitStatus(dir); done && !(stopMarker(status) && wrapperRunning(dir)) {
+			return readSpoolResult(dir, status)
+		}
+		if err := watchdog.check(time.Now()); err != nil {
+			return RuntimeResult{}, err
+		}
+		if poll%paneProbeEvery == 0 && !r.paneAlive(paneID) {
+			if status, done := spoolExitStatus(dir); done {
+				return readSpoolResult(dir, status)
+			}
+			return RuntimeResult{}, ErrLostExecution
+		}
+		select {
+		case <-ctx.Done():
+			return RuntimeResult{}, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Stop sends SIGTERM to the wrapper's process group, closes the
+// execution's pane once the group has exited or StopGrace has passed
+// (which kills whatever is left), and records the stop so Wait returns
+// ErrExecutionStopped instead of ErrLostExecution. No-op if the ID is
+// unknown or already finished.
+func (r *HerdrRuntime) Stop(ctx context.Context, exec Execution) error {
+	dir := r.execDir(exec.ID)
+	if ok, err := spoolExists(dir); !ok {
+		return err
+	}
+	if _, done := spoolExitStatus(dir); done {
+		return nil
+	}
+	return r.terminate(ctx, dir, r.paneID(exec), exitStopped)
+}
+
+// terminate records marker as the exit status, stops the wrapper and
+// closes the pane.
+func (r *HerdrRuntime) terminate(ctx context.Context, dir, paneID, marker string) error {
+	// Mark first: once the pane dies the wrapper's signal trap would
+	// otherwise record an ordinary exit status.
+	if err := markSpoolExit(dir, marker); err != nil {
+		return err
+	}
+	if rec, ok := readProcRecord(dir); ok && rec.alive() && r.StopGrace > 0 {
+		if proc, err := os.FindProcess(rec.pid); err == nil {
+			_ = stopProcess(ctx, proc, r.StopGrace)
+		}
+	}
+	if r.paneAlive(paneID) {
+		if _, err := r.runner.Run("pane", "close", paneID); err != nil {
+			return fmt.Errorf("agent: HerdrRuntime: close pane %s: %w", paneID, err)
+		}
+	}
+	return nil
+}
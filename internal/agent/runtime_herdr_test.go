@@ -0,0 +1,291 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"bdtui/internal/adapters/herdr"
+)
+
+// fakeHerdr emulates the pane subcommands HerdrRuntime uses. A "pane" is a
+// marker file; send-text runs the text with sh in its own session so close
+// can kill the whole pane like a real multiplexer would.
+const fakeHerdr = `#!/bin/sh
+state="$FAKE_HERDR_STATE"
+case "$1 $2" in
+"pane create")
+	n=$(ls "$state" | grep -c '\.alive$')
+	id="pane-$((n + 1))"
+	touch "$state/$id.alive"
+	printf '{"result":{"pane":{"pane_id":"%s"}}}\n' "$id"
+	;;
+"pane send-text")
+	[ -f "$state/$3.alive" ] || { echo "no such pane: $3"; exit 1; }
+	setsid sh -c "$4" </dev/null >/dev/null 2>&1 &
+	echo $! > "$state/$3.pid"
+	;;
+"pane get")
+	[ -f "$state/$3.alive" ] || { echo "no such pane: $3"; exit 1; }
+	printf '{"result":{"pane":{"pane_id":"%s"}}}\n' "$3"
+	;;
+"pane close")
+	[ -f "$state/$3.pid" ] && kill -KILL -"$(cat "$state/$3.pid")" 2>/dev/null
+	rm -f "$state/$3.alive"
+	;;
+*)
+	echo "unsupported: $*"; exit 2
+	;;
+esac
+`
+
+func newFakeHerdrRuntime(t *testing.T) (*HerdrRuntime, string) {
+	t.Helper()
+	if _, err := exec.LookPath("setsid"); err != nil {
+		t.Skip("setsid not available")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "herdr"), []byte(fakeHerdr), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	state := t.TempDir()
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_HERDR_STATE", state)
+
+	spool := filepath.Join(t.TempDir(), "executions")
+	r := NewHerdrRuntime(nil, spool)
+	r.PollInterval = 20 * time.Millisecond
+	// A SIGTERM that lands before the wrapper starts its command is only
+	// acted on after it; keep the escalation to SIGKILL short.
+	r.StopGrace = 2 * time.Second
+	return r, spool
+}
+
+func waitCtx(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestHerdrRuntimeSpawnWait(t *testing.T) {
+	r, _ := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+	execID := AllocateExecutionID()
+
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: execID,
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "cat; echo \"it's $PWD\"; echo oops >&2", "ignored"},
+		Dir:         t.TempDir(),
+		Stdin:       []byte("prompt\n"),
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if exec.ID != execID || exec.PaneID != "pane-1" {
+		t.Fatalf("Spawn = %+v, want id %s in pane-1", exec, execID)
+	}
+	if _, err := r.Spawn(ctx, Invocation{ExecutionID: execID, Bin: "/bin/true"}); !errors.Is(err, ErrDuplicateExecution) {
+		t.Fatalf("second Spawn = %v, want ErrDuplicateExecution", err)
+	}
+
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := string(res.Stdout); !strings.HasPrefix(got, "prompt\nit's /") {
+		t.Fatalf("stdout = %q", got)
+	}
+	if string(res.Stderr) != "oops\n" || res.ExitErr != nil {
+		t.Fatalf("stderr = %q, exit = %v", res.Stderr, res.ExitErr)
+	}
+	ins, err := r.Inspect(ctx, exec)
+	if err != nil || !ins.Found || ins.Running {
+		t.Fatalf("Inspect after completion = %+v, %v", ins, err)
+	}
+}
+
+func TestHerdrRuntimeExitCode(t *testing.T) {
+	r, _ := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sh", Args: []string{"-c", "exit 3"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	var code *ExitCodeError
+	if !errors.As(res.ExitErr, &code) || code.Code != 3 {
+		t.Fatalf("ExitErr = %v, want exit status 3", res.ExitErr)
+	}
+}
+
+// TestHerdrRuntimeReattachAfterRestart spawns with one runtime and
+// reattaches with a fresh one over the same spool, as a restarted daemon
+// would.
+func TestHerdrRuntimeReattachAfterRestart(t *testing.T) {
+	r, spool := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+	gate := filepath.Join(t.TempDir(), "go")
+
+	exec, err := r.Spawn(ctx, Invocation{
+		ExecutionID: AllocateExecutionID(),
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", "while [ ! -f \"$0\" ]; do sleep 0.02; done; echo finished", gate},
+	})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	restarted := NewHerdrRuntime(herdr.ShellRunner{}, spool)
+	restarted.PollInterval = 20 * time.Millisecond
+	// Only the durable id survives the restart; the pane id comes from the spool.
+	durable := Execution{ID: exec.ID}
+	ins, err := restarted.Inspect(ctx, durable)
+	if err != nil || !ins.Found || !ins.Running {
+		t.Fatalf("Inspect while running = %+v, %v", ins, err)
+	}
+
+	if err := os.WriteFile(gate, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	res, err := restarted.Reattach(ctx, durable)
+	if err != nil {
+		t.Fatalf("Reattach: %v", err)
+	}
+	if string(res.Stdout) != "finished\n" || res.ExitErr != nil {
+		t.Fatalf("Reattach result = %q, %v", res.Stdout, res.ExitErr)
+	}
+}
+
+func TestHerdrRuntimeStop(t *testing.T) {
+	r, _ := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait after Stop: %v", err)
+	}
+	if !errors.Is(res.ExitErr, ErrExecutionStopped) {
+		t.Fatalf("ExitErr = %v, want ErrExecutionStopped", res.ExitErr)
+	}
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("second Stop: %v", err)
+	}
+}
+
+// TestHerdrRuntimeStopGrace checks Stop signals the wrapper the pane runs
+// and lets the agent flush before the pane is closed.
+func TestHerdrRuntimeStopGrace(t *testing.T) {
+	r, spool := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sh", Args: []string{"-c", gracefulAgent}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	waitForFile(t, ctx, filepath.Join(spool, exec.ID, spoolStdout), "ready")
+	if err := r.Stop(ctx, exec); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait after Stop: %v", err)
+	}
+	if !errors.Is(res.ExitErr, ErrExecutionStopped) || !strings.Contains(string(res.Stdout), "flushed") {
+		t.Fatalf("result = %q, %v; want flushed output and ErrExecutionStopped", res.Stdout, res.ExitErr)
+	}
+}
+
+func TestHerdrRuntimeTimeout(t *testing.T) {
+	r, _ := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}, Timeout: 200 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil || !errors.Is(res.ExitErr, ErrExecutionTimeout) {
+		t.Fatalf("Wait = %v, %v; want ErrExecutionTimeout", res.ExitErr, err)
+	}
+	if r.paneAlive(exec.PaneID) {
+		t.Fatal("the pane of a timed-out execution should be closed")
+	}
+}
+
+// TestHerdrRuntimeHangup delivers SIGHUP to the pane's processes, as when
+// its terminal goes away, while the pane itself stays listed.
+func TestHerdrRuntimeHangup(t *testing.T) {
+	r, _ := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	pidFile := filepath.Join(os.Getenv("FAKE_HERDR_STATE"), exec.PaneID+".pid")
+	time.Sleep(100 * time.Millisecond)
+	b, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Kill(-pid, syscall.SIGHUP); err != nil {
+		t.Fatalf("hangup: %v", err)
+	}
+	res, err := r.Wait(ctx, exec)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	var code *ExitCodeError
+	if !errors.As(res.ExitErr, &code) || code.Code != 129 {
+		t.Fatalf("ExitErr = %v, want exit status 129", res.ExitErr)
+	}
+}
+
+func TestHerdrRuntimeLostPane(t *testing.T) {
+	r, _ := newFakeHerdrRuntime(t)
+	ctx := waitCtx(t)
+
+	exec, err := r.Spawn(ctx, Invocation{ExecutionID: AllocateExecutionID(), Bin: "/bin/sleep", Args: []string{"30"}})
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+	// The user closes the pane behind the runtime's back.
+	if _, err := (herdr.ShellRunner{}).Run("pane", "close", exec.PaneID); err != nil {
+		t.Fatalf("close pane: %v", err)
+	}
+
+	ins, err := r.Inspect(ctx, exec)
+	if err != nil || ins.Found {
+		t.Fatalf("Inspect after pane loss = %+v, %v", ins, err)
+	}
+	if _, err := r.Reattach(ctx, exec); !errors.Is(err, ErrLostExecution) {
+		t.Fatalf("Reattach after pane loss = %v, want ErrLostExecution", err)
+	}
+	if _, err := r.Reattach(ctx, Execution{ID: "never-spawned"}); !errors.Is(err, ErrLostExecution) {
+		t.Fatalf("Reattach unknown id = %v, want ErrLostExecution", err)
+	}
+}
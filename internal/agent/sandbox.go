@@ -0,0 +1,272 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bdtui/internal/workflow"
+)
+
+// ErrWorkspaceModified fails the attempt of a read-only role that changed
+// tracked files, HEAD or refs in its worktree.
+var ErrWorkspaceModified = errors.New("agent: read-only role modified the worktree")
+
+// DefaultEnvAllowlist is the environment a sandboxed agent keeps: enough
+// to find binaries, its home directory and locale. Sandbox.Env adds to it.
+var DefaultEnvAllowlist = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TERM", "TMPDIR", "TZ",
+	"LANG", "LC_*", "XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_DATA_HOME",
+}
+
+// DiffChecker reports whether a worktree has changes to tracked files
+// against HEAD and fingerprints its HEAD and refs. recovery.GitWorktree
+// implements it.
+type DiffChecker interface {
+	DiffEmpty(ctx context.Context, workdir string) (bool, error)
+	RefState(ctx context.Context, workdir string) (string, error)
+}
+
+// Sandbox confines a read-only role. With ReadOnly set, RunAgent runs the
+// agent in a read-only copy of WorkingDir without its .git, so git in the
+// copy cannot reach the repository, and with an environment scrubbed to
+// DefaultEnvAllowlist plus Env. The copy only guards against accidents:
+// the agent runs as the same user and may still know WorkingDir's path.
+// So afterwards RunAgent fails the attempt with ErrWorkspaceModified if
+// Worktree reports changes to WorkingDir itself: to tracked files, which
+// needs a worktree clean before the run, or to HEAD and refs.
+type Sandbox struct {
+	ReadOnly bool
+	Env      []string
+	Worktree DiffChecker
+}
+
+// SandboxFor returns the sandbox a role's workspace mode asks for.
+func SandboxFor(role workflow.RoleContract, worktree DiffChecker) Sandbox {
+	return Sandbox{
+		ReadOnly: role.Workspace == workflow.WorkspaceRead,
+		Env:      role.Env,
+		Worktree: worktree,
+	}
+}
+
+// environ filters env (KEY=VALUE pairs) down to the allowlisted names.
+func (s Sandbox) environ(env []string) []string {
+	allow := append(append([]string(nil), DefaultEnvAllowlist...), s.Env...)
+	out := []string{}
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if envAllowed(name, allow) {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
+// envAllowed matches name against NAME and PREFIX* patterns.
+func envAllowed(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == p {
+			return true
+		}
+	}
+	return false
+}
+
+// sandboxRoot holds the read-only copy of an execution's worktree, a note
+// of whether the original was clean and its refs. It is named after the execution
+// so a Reattach in a later daemon process finds and removes it.
+func sandboxRoot(executionID string) string {
+	return filepath.Join(os.TempDir(), "bdtui-sandbox-"+executionID)
+}
+
+const (
+	sandboxTree  = "tree"
+	sandboxClean = "clean"
+	sandboxRefs  = "refs"
+)
+
+// prepareSandbox copies workdir into the execution's sandbox and makes the
+// copy read-only, returning its path.
+func prepareSandbox(ctx context.Context, sb Sandbox, executionID, workdir string) (string, error) {
+	root := sandboxRoot(executionID)
+	if err := removeSandbox(root); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return "", fmt.Errorf("agent: sandbox: %w", err)
+	}
+	if sb.Worktree != nil {
+		if empty, err := sb.Worktree.DiffEmpty(ctx, workdir); err == nil && empty {
+			if err := os.WriteFile(filepath.Join(root, sandboxClean), nil, 0o600); err != nil {
+				return "", fmt.Errorf("agent: sandbox: %w", err)
+			}
+		}
+		if refs, err := sb.Worktree.RefState(ctx, workdir); err == nil {
+			if err := os.WriteFile(filepath.Join(root, sandboxRefs), []byte(refs), 0o600); err != nil {
+				return "", fmt.Errorf("agent: sandbox: %w", err)
+			}
+		}
+	}
+	tree := filepath.Join(root, sandboxTree)
+	if err := copyTree(workdir, tree); err != nil {
+		_ = removeSandbox(root)
+		return "", fmt.Errorf("agent: sandbox: copy worktree: %w", err)
+	}
+	if err := setTreeWritable(tree, false); err != nil {
+		_ = removeSandbox(root)
+		return "", fmt.Errorf("agent: sandbox: %w", err)
+	}
+	return tree, nil
+}
+
+// finishSandbox removes the execution's sandbox and checks the original
+// worktree was left alone.
+func finishSandbox(ctx context.Context, sb Sandbox, executionID, workdir string) error {
+	root := sandboxRoot(executionID)
+	_, err := os.Stat(filepath.Join(root, sandboxClean))
+	wasClean := err == nil
+	refsBefore, refsErr := os.ReadFile(filepath.Join(root, sandboxRefs))
+	if err := removeSandbox(root); err != nil {
+		return err
+	}
+	if sb.Worktree == nil {
+		return nil
+	}
+	if refsErr == nil {
+		refs, err := sb.Worktree.RefState(ctx, workdir)
+		if err != nil {
+			return fmt.Errorf("agent: sandbox: check refs: %w", err)
+		}
+		if refs != string(refsBefore) {
+			return ErrWorkspaceModified
+		}
+	}
+	if !wasClean {
+		return nil
+	}
+	empty, err := sb.Worktree.DiffEmpty(ctx, workdir)
+	if err != nil {
+		return fmt.Errorf("agent: sandbox: check worktree: %w", err)
+	}
+	if !empty {
+		return ErrWorkspaceModified
+	}
+	return nil
+}
+
+func removeSandbox(root string) error {
+	if _, err := os.Lstat(root); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	_ = setTreeWritable(root, true)
+	if err := os.RemoveAll(root); err != nil {
+		return fmt.Errorf("agent: sandbox: remove: %w", err)
+	}
+	return nil
+}
+
+// copyTree copies regular files, directories and symlinks from src to dst,
+// keeping modes. Other file types (sockets, fifos) are skipped, and so is
+// .git: a worktree's or submodule's .git points at the live repository.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Name() == ".git" && path != src {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, perm|0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// setTreeWritable adds or removes write permission on everything under
+// root. Directories are handled after their contents, so a read-only
+// directory never blocks the walk.
+func setTreeWritable(root string, writable bool) error {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if d.IsDir() {
+			if writable {
+				return os.Chmod(path, 0o700)
+			}
+			dirs = append(dirs, path)
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := info.Mode().Perm() &^ 0o222
+		if writable {
+			mode |= 0o200
+		}
+		return os.Chmod(path, mode)
+	})
+	if err != nil {
+		return err
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(dirs[i], 0o555); err != nil {
+			return err
+		}
+	}
+	return nil
+}
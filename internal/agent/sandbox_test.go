@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// shellAdapter runs a shell script as the agent; its stdout is the result.
+type shellAdapter struct{ script string }
+
+func (a shellAdapter) BuildInvocation(_ context.Context, req Request) (Invocation, error) {
+	return Invocation{
+		ExecutionID: req.ExecutionID,
+		Bin:         "/bin/sh",
+		Args:        []string{"-c", a.script},
+		Dir:         req.WorkingDir,
+	}, nil
+}
+
+func (shellAdapter) ParseResult(_ context.Context, _ Request, raw RuntimeResult) (Result, error) {
+	return Result{Raw: string(raw.Stdout), IsError: raw.ExitErr != nil}, raw.ExitErr
+}
+
+// fileDiff reports a diff once a file no longer has its original content.
+type fileDiff struct{ path, want string }
+
+func (f fileDiff) DiffEmpty(context.Context, string) (bool, error) {
+	b, err := os.ReadFile(f.path)
+	return string(b) == f.want, err
+}
+
+func (fileDiff) RefState(context.Context, string) (string, error) {
+	return "", errors.New("not a git repository")
+}
+
+// gitWorktree checks a real repository with the git CLI.
+type gitWorktree struct{}
+
+func (gitWorktree) DiffEmpty(ctx context.Context, dir string) (bool, error) {
+	err := exec.CommandContext(ctx, "git", "-C", dir, "diff", "--quiet", "HEAD").Run()
+	return err == nil, nil
+}
+
+func (gitWorktree) RefState(ctx context.Context, dir string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "for-each-ref").Output()
+	return string(out), err
+}
+
+func newSandboxWorktree(t *testing.T) (string, fileDiff) {
+	t.Helper()
+	dir := t.TempDir()
+	tracked := filepath.Join(dir, "src", "main.go")
+	if err := os.MkdirAll(filepath.Dir(tracked), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tracked, []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, fileDiff{path: tracked, want: "package main\n"}
+}
+
+func TestRunAgentReadOnlySandbox(t *testing.T) {
+	workdir, diff := newSandboxWorktree(t)
+	t.Setenv("SANDBOX_SECRET", "s3cret")
+	t.Setenv("SANDBOX_ALLOWED", "ok")
+	script := `pwd; cat src/main.go; echo "secret=$SANDBOX_SECRET allowed=$SANDBOX_ALLOWED"
+if (echo x > src/main.go) 2>/dev/null; then echo wrote; else echo readonly; fi`
+	req := Request{
+		ExecutionID: AllocateExecutionID(),
+		WorkingDir:  workdir,
+		Sandbox:     Sandbox{ReadOnly: true, Env: []string{"SANDBOX_ALLOW*"}, Worktree: diff},
+	}
+
+	res, err := RunAgent(context.Background(), shellAdapter{script}, NewExecRuntime(), req)
+	if err != nil {
+		t.Fatalf("RunAgent: %v (%+v)", err, res)
+	}
+	lines := strings.Split(strings.TrimSpace(res.Raw), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("output = %q", res.Raw)
+	}
+	if lines[0] != filepath.Join(sandboxRoot(req.ExecutionID), sandboxTree) || lines[1] != "package main" {
+		t.Fatalf("agent ran in %q seeing %q, want the sandbox copy", lines[0], lines[1])
+	}
+	if lines[2] != "secret= allowed=ok" {
+		t.Fatalf("environment = %q, want the secret scrubbed", lines[2])
+	}
+	if os.Geteuid() != 0 && lines[3] != "readonly" {
+		t.Fatalf("write to the sandbox copy = %q, want it refused", lines[3])
+	}
+	if _, err := os.Stat(sandboxRoot(req.ExecutionID)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("sandbox left behind: %v", err)
+	}
+	if b, _ := os.ReadFile(diff.path); string(b) != diff.want {
+		t.Fatalf("original worktree changed: %q", b)
+	}
+}
+
+func TestRunAgentReadOnlyWorktreeModified(t *testing.T) {
+	workdir, diff := newSandboxWorktree(t)
+	req := Request{
+		ExecutionID: AllocateExecutionID(),
+		WorkingDir:  workdir,
+		Sandbox:     Sandbox{ReadOnly: true, Worktree: diff},
+	}
+	script := "echo '// edited' >> " + shellQuote(diff.path)
+
+	res, err := RunAgent(context.Background(), shellAdapter{script}, NewExecRuntime(), req)
+	if !errors.Is(err, ErrWorkspaceModified) || !res.IsError {
+		t.Fatalf("RunAgent = %+v, %v; want ErrWorkspaceModified", res, err)
+	}
+
+	// A writer role is not checked.
+	req.ExecutionID = AllocateExecutionID()
+	req.Sandbox = Sandbox{}
+	if _, err := RunAgent(context.Background(), shellAdapter{script}, NewExecRuntime(), req); err != nil {
+		t.Fatalf("writer RunAgent: %v", err)
+	}
+}
+
+func TestRunAgentReadOnlyRefsChanged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	workdir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", workdir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	req := Request{
+		ExecutionID: AllocateExecutionID(),
+		WorkingDir:  workdir,
+		Sandbox:     Sandbox{ReadOnly: true, Worktree: gitWorktree{}},
+	}
+
+	// The copy has no .git, so git in it cannot reach the repository.
+	res, err := RunAgent(context.Background(), shellAdapter{"test -e .git && echo git || echo none; git branch in-copy 2>/dev/null; true"}, NewExecRuntime(), req)
+	if err != nil || strings.TrimSpace(res.Raw) != "none" {
+		t.Fatalf("RunAgent in the copy = %q, %v", res.Raw, err)
+	}
+
+	// Git run against the original path still changes its refs.
+	req.ExecutionID = AllocateExecutionID()
+	res, err = RunAgent(context.Background(), shellAdapter{"git -C " + shellQuote(workdir) + " branch sneaky"}, NewExecRuntime(), req)
+	if !errors.Is(err, ErrWorkspaceModified) || !res.IsError {
+		t.Fatalf("RunAgent = %+v, %v; want ErrWorkspaceModified", res, err)
+	}
+}
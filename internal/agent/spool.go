@@ -0,0 +1,209 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// A spool is the on-disk record of one execution, kept by runtimes whose
+// results must survive a daemon restart (HerdrRuntime, DurableExecRuntime).
+// It lives in <spoolDir>/<ExecutionID>/ and holds the wrapper script, the
+// captured stdin/stdout/stderr, the limits to enforce (see
+// writeSpoolLimits) and, once the wrapper finishes, the exit status.
+const (
+	spoolScript = "run.sh"
+	spoolStdin  = "stdin"
+	spoolStdout = "stdout"
+	spoolStderr = "stderr"
+	spoolExit   = "exit"
+	spoolPane   = "pane"
+	spoolProc   = "proc"
+
+	// exitStopped is written to the exit file by Stop so Wait can tell a
+	// deliberate stop from a lost process.
+	exitStopped = "stopped"
+)
+
+// ErrExecutionStopped is the RuntimeResult.ExitErr of an execution that was
+// terminated through Runtime.Stop.
+var ErrExecutionStopped = errors.New("agent: execution stopped")
+
+// ExitCodeError reports a non-zero exit status recorded by a wrapper script
+// (the runtime may no longer own the child, so there is no *exec.ExitError).
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// validSpoolID rejects ids that would escape the spool directory.
+func validSpoolID(id string) bool {
+	return id != "" && id != "." && id != ".." && !strings.ContainsAny(id, `/\`)
+}
+
+// reserveSpool creates the execution directory. os.Mkdir is the atomic
+// reservation: a second Spawn with the same ID gets ErrDuplicateExecution,
+// even from another process sharing the spool.
+func reserveSpool(spoolDir, id string) (string, error) {
+	if err := os.MkdirAll(spoolDir, 0o700); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(spoolDir, id)
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return "", ErrDuplicateExecution
+		}
+		return "", err
+	}
+	return dir, nil
+}
+
+// writeSpoolScript writes stdin and the wrapper script for inv. The wrapper
+// feeds the spooled stdin to the command, captures stderr and stdout (also
+// echoing stdout to the terminal when teeStdout is set, for a human
+// watching a pane, and to inv.Transcript when one is set) and renames the exit file into place only after all
+// output is flushed, so its presence means the result is complete.
+//
+// A wrapper interrupted by SIGINT, SIGTERM or SIGHUP (Ctrl-C or a closed
+// terminal in a pane) still records 128+signal, so Wait does not poll a
+// spool that will never finish. The shell runs the trap only after the
+// command exits, so a SIGTERM to the group lets the agent finish writing
+// its output first. Every rename uses mv -n: an exit file
+// already written by Stop is never replaced.
+func writeSpoolScript(dir string, inv Invocation, teeStdout bool) error {
+	if err := os.WriteFile(filepath.Join(dir, spoolStdin), inv.Stdin, 0o600); err != nil {
+		return err
+	}
+
+	q := func(name string) string { return shellQuote(filepath.Join(dir, name)) }
+	cmd := make([]string, 0, len(inv.Args)+len(inv.Env)+3)
+	if inv.Env != nil {
+		// The environment is replaced for the command only; the wrapper
+		// itself keeps the pane's or daemon's.
+		cmd = append(cmd, "env", "-i")
+		for _, kv := range inv.Env {
+			cmd = append(cmd, shellQuote(kv))
+		}
+	}
+	cmd = append(cmd, shellQuote(inv.Bin))
+	for _, a := range inv.Args {
+		cmd = append(cmd, shellQuote(a))
+	}
+	tmp, exit := q(spoolExit+".tmp"), q(spoolExit)
+
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# bdtui execution %s\n", inv.ExecutionID)
+	fmt.Fprintf(&b, "finish() { echo \"$1\" > %s; mv -n %s %s; exit \"$1\"; }\n", tmp, tmp, exit)
+	b.WriteString("trap 'finish 129' HUP\ntrap 'finish 130' INT\ntrap 'finish 143' TERM\n")
+	if teeStdout {
+		// In a pane no Go parent knows the wrapper's pid; record it (with
+		// no start time) so Stop can signal its process group.
+		fmt.Fprintf(&b, "echo \"$$ 0\" > %s && mv %s %s\n", q(spoolProc+".tmp"), q(spoolProc+".tmp"), q(spoolProc))
+	}
+	if inv.Dir != "" {
+		fmt.Fprintf(&b, "cd %s || finish 126\n", shellQuote(inv.Dir))
+	}
+	if teeStdout || inv.Transcript != "" {
+		// The exit status is captured inside the braces: a pipeline's
+		// status is tee's, and dash has no pipefail. tee ignores SIGTERM
+		// so it still copies what the command writes while stopping.
+		targets := q(spoolStdout)
+		if inv.Transcript != "" {
+			if err := os.MkdirAll(filepath.Dir(inv.Transcript), 0o700); err != nil {
+				return err
+			}
+			targets += " " + shellQuote(inv.Transcript)
+		}
+		if !teeStdout {
+			targets += " > /dev/null"
+		}
+		fmt.Fprintf(&b, "{ %s < %s 2> %s; echo $? > %s; } | { trap '' TERM; exec tee %s; }\n",
+			strings.Join(cmd, " "), q(spoolStdin), q(spoolStderr), tmp, targets)
+		fmt.Fprintf(&b, "mv -n %s %s\n", tmp, exit)
+	} else {
+		fmt.Fprintf(&b, "%s < %s > %s 2> %s\n", strings.Join(cmd, " "), q(spoolStdin), q(spoolStdout), q(spoolStderr))
+		b.WriteString("finish $?\n")
+	}
+	return os.WriteFile(filepath.Join(dir, spoolScript), []byte(b.String()), 0o700)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// spoolExists reports whether dir exists; other stat errors are returned.
+func spoolExists(dir string) (bool, error) {
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// spoolExitStatus returns the recorded exit status and whether one exists.
+func spoolExitStatus(dir string) (string, bool) {
+	b, err := os.ReadFile(filepath.Join(dir, spoolExit))
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(b)), true
+}
+
+// readSpoolResult assembles the RuntimeResult of a finished execution.
+func readSpoolResult(dir, status string) (RuntimeResult, error) {
+	stdout, err := os.ReadFile(filepath.Join(dir, spoolStdout))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return RuntimeResult{}, err
+	}
+	stderr, err := os.ReadFile(filepath.Join(dir, spoolStderr))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return RuntimeResult{}, err
+	}
+	res := RuntimeResult{Stdout: stdout, Stderr: stderr}
+	switch code, err := strconv.Atoi(status); {
+	case status == exitStopped:
+		res.ExitErr = ErrExecutionStopped
+	case status == exitTimeout:
+		res.ExitErr = ErrExecutionTimeout
+	case status == exitIdle:
+		res.ExitErr = ErrExecutionIdle
+	case err != nil:
+		return RuntimeResult{}, fmt.Errorf("agent: malformed exit status %q in %s", status, dir)
+	case code != 0:
+		res.ExitErr = &ExitCodeError{Code: code}
+	}
+	return res, nil
+}
+
+// stopMarker reports whether an exit status was written by Stop or a
+// limit rather than by the wrapper.
+func stopMarker(status string) bool {
+	return status == exitStopped || status == exitTimeout || status == exitIdle
+}
+
+// markSpoolExit records marker (a Stop or a crossed limit) as the exit
+// status unless one already exists. Callers write it before killing the
+// wrapper, so a wrapper that finishes or traps the signal in the meantime
+// cannot replace the marker (its mv -n leaves the existing file alone). The
+// marker is written in full to a temporary file and hard-linked into place,
+// which fails rather than clobbers when the wrapper won the race.
+func markSpoolExit(dir, marker string) error {
+	tmp := filepath.Join(dir, spoolExit+"."+marker)
+	if err := os.WriteFile(tmp, []byte(marker+"\n"), 0o600); err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if err := os.Link(tmp, filepath.Join(dir, spoolExit)); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return nil
+}
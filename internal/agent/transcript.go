@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TranscriptKind classifies one entry of an agent transcript.
+type TranscriptKind string
+
+const (
+	TranscriptText       TranscriptKind = "text"        // assistant prose
+	TranscriptToolCall   TranscriptKind = "tool_call"   // tool name and input
+	TranscriptToolResult TranscriptKind = "tool_result" // what the tool returned
+	TranscriptResult     TranscriptKind = "result"      // the final result event
+	TranscriptSystem     TranscriptKind = "system"      // init and other notices
+	TranscriptRaw        TranscriptKind = "raw"         // a line that is not stream-json
+)
+
+// TranscriptEntry is a displayable piece of a transcript line.
+type TranscriptEntry struct {
+	Kind    TranscriptKind
+	Text    string
+	IsError bool
+}
+
+// ParseTranscriptLine turns one line of Maki's stream-json output (as
+// written to Invocation.Transcript) into display entries: an assistant
+// message yields its text and tool_use blocks, a user message its
+// tool_result blocks. Lines that are not JSON come back as TranscriptRaw,
+// so runners with plain-text output still render; JSON events with nothing
+// to show yield no entries.
+func ParseTranscriptLine(line []byte) []TranscriptEntry {
+	if len(strings.TrimSpace(string(line))) == 0 {
+		return nil
+	}
+	var ev transcriptEvent
+	if err := json.Unmarshal(line, &ev); err != nil {
+		return []TranscriptEntry{{Kind: TranscriptRaw, Text: string(line)}}
+	}
+	switch ev.Type {
+	case "assistant", "user":
+		return contentEntries(ev.Message.Content)
+	case "result":
+		text := ev.Result
+		if text == "" {
+			text = ev.Subtype
+		}
+		return []TranscriptEntry{{Kind: TranscriptResult, Text: text, IsError: ev.IsError || strings.EqualFold(ev.Subtype, "error")}}
+	case "system":
+		if ev.Subtype == "" {
+			return nil
+		}
+		return []TranscriptEntry{{Kind: TranscriptSystem, Text: ev.Subtype}}
+	}
+	return nil
+}
+
+// transcriptEvent is the subset of a stream-json line the transcript view
+// renders.
+type transcriptEvent struct {
+	Type    string `json:"type"`
+	Subtype string `json:"subtype"`
+	IsError bool   `json:"is_error"`
+	Result  string `json:"result"`
+	Message struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// contentBlock is one element of a message's content array.
+type contentBlock struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text"`
+	Name    string          `json:"name"`
+	Input   json.RawMessage `json:"input"`
+	Content json.RawMessage `json:"content"`
+	IsError bool            `json:"is_error"`
+}
+
+// contentEntries renders message content, which is either a plain string or
+// an array of blocks.
+func contentEntries(raw json.RawMessage) []TranscriptEntry {
+	if s, ok := contentString(raw); ok {
+		if s == "" {
+			return nil
+		}
+		return []TranscriptEntry{{Kind: TranscriptText, Text: s}}
+	}
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return nil
+	}
+	var out []TranscriptEntry
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			if b.Text != "" {
+				out = append(out, TranscriptEntry{Kind: TranscriptText, Text: b.Text})
+			}
+		case "tool_use":
+			text := b.Name
+			if len(b.Input) > 0 && string(b.Input) != "null" {
+				text += " " + string(b.Input)
+			}
+			out = append(out, TranscriptEntry{Kind: TranscriptToolCall, Text: text})
+		case "tool_result":
+			text, _ := contentString(b.Content)
+			if text == "" {
+				// Array content: join its text blocks.
+				var parts []contentBlock
+				_ = json.Unmarshal(b.Content, &parts)
+				var texts []string
+				for _, p := range parts {
+					if p.Text != "" {
+						texts = append(texts, p.Text)
+					}
+				}
+				text = strings.Join(texts, "\n")
+			}
+			out = append(out, TranscriptEntry{Kind: TranscriptToolResult, Text: text, IsError: b.IsError})
+		}
+	}
+	return out
+}
+
+// contentString decodes raw as a JSON string.
+func contentString(raw json.RawMessage) (string, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTranscriptLine(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+		want []TranscriptEntry
+	}{
+		{"string content", `{"type":"assistant","message":{"content":"thinking"}}`,
+			[]TranscriptEntry{{Kind: TranscriptText, Text: "thinking"}}},
+		{"blocks", `{"type":"assistant","message":{"content":[{"type":"text","text":"let me look"},{"type":"tool_use","name":"read","input":{"path":"a.go"}}]}}`,
+			[]TranscriptEntry{{Kind: TranscriptText, Text: "let me look"}, {Kind: TranscriptToolCall, Text: `read {"path":"a.go"}`}}},
+		{"tool result", `{"type":"user","message":{"content":[{"type":"tool_result","content":[{"type":"text","text":"no such file"}],"is_error":true}]}}`,
+			[]TranscriptEntry{{Kind: TranscriptToolResult, Text: "no such file", IsError: true}}},
+		{"result", `{"type":"result","subtype":"success","result":"done"}`,
+			[]TranscriptEntry{{Kind: TranscriptResult, Text: "done"}}},
+		{"error result", `{"type":"result","subtype":"error","is_error":true}`,
+			[]TranscriptEntry{{Kind: TranscriptResult, Text: "error", IsError: true}}},
+		{"system", `{"type":"system","subtype":"init","session_id":"s"}`,
+			[]TranscriptEntry{{Kind: TranscriptSystem, Text: "init"}}},
+		{"plain text", `compiling...`,
+			[]TranscriptEntry{{Kind: TranscriptRaw, Text: "compiling..."}}},
+		{"unknown event", `{"type":"progress"}`, nil},
+		{"blank", "  ", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ParseTranscriptLine([]byte(tc.line)); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ParseTranscriptLine = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,14 @@
 // plus the deterministic prompt envelope and the completion checks that gate a
 // step attempt on machine-verifiable evidence.
 //
-// The boundary has three layers so a future runtime (HerdrRuntime) can own the
-// process lifecycle without changing the controller or the adapter:
+// The boundary has three layers so the runtime (HerdrRuntime or ExecRuntime)
+// owns the process lifecycle without changing the controller or the adapter:
 //
 //	Controller -> RunAgent(adapter, runtime, req)
 //	             |                       |
-//	          MakiAdapter             Runtime (HerdrRuntime / ExecRuntime)
+//	     Adapter (Registry.ForRole)   Runtime (HerdrRuntime / ExecRuntime)
+//	     MakiAdapter or a RunnerSpec
+//	     (StdinJSONL / PromptFile)
 //	             |                       |
 //	      build invocation        spawn / wait / stop / inspect / reattach
 //	      parse wire format       (controller-allocated ExecutionID)
@@ -20,9 +22,11 @@
 package agent
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"bdtui/internal/workflow"
 )
@@ -68,6 +72,16 @@ type Request struct {
 	// where the agent must write its structured result and declared artifacts.
 	OutputPaths OutputPaths
 
+	// Timeout and IdleTimeout are the role's limits
+	// (workflow.RoleContract.Limits); adapters pass them through to the
+	// Invocation. Zero means no limit.
+	Timeout     time.Duration
+	IdleTimeout time.Duration
+
+	// Sandbox confines a read-only role (see SandboxFor); the zero value
+	// runs the agent in WorkingDir with the daemon's environment.
+	Sandbox Sandbox
+
 	// Contract is the resolved, immutable completion contract for this
 	// attempt. It is constructed only via ResolveContract; the controller
 	// cannot assemble the parts independently and therefore cannot bypass
@@ -80,6 +94,9 @@ type Request struct {
 type OutputPaths struct {
 	Result    string
 	Artifacts map[string]string
+	// Transcript receives the agent's raw stdout while it runs; adapters
+	// pass it through as Invocation.Transcript. Optional.
+	Transcript string
 }
 
 // ResultContract is the single, resolved contract for a step attempt. The
@@ -192,11 +209,59 @@ func sameStringSet(a, b []string) bool {
 	return true
 }
 
-// TaskSnapshot is the immutable snapshot of the source Kanban task for a run.
+// TaskSnapshot is the immutable snapshot of the source Kanban task for a
+// run, captured from bd at run start. Beyond the task itself it records
+// where the task sits in the plan: its notes, the parent epic, the parent's
+// other children and the tasks blocking it. A role sees the extra context
+// it asks for (see ForRole); the rest is left out of its envelope.
 type TaskSnapshot struct {
-	ID          string
-	Title       string
-	Description string
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	Parent      *TaskRef  `json:"parent,omitempty"`
+	Siblings    []TaskRef `json:"siblings,omitempty"`
+	Blockers    []TaskRef `json:"blockers,omitempty"`
+}
+
+// TaskRef is a related task in a TaskSnapshot. Description is only
+// captured for the parent.
+type TaskRef struct {
+	ID          string `json:"id"`
+	Title       string `json:"title,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ParseTaskSnapshot decodes a TaskSnapshot stored with a run.
+func ParseTaskSnapshot(data string) (TaskSnapshot, error) {
+	var t TaskSnapshot
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		return TaskSnapshot{}, fmt.Errorf("agent: task snapshot: %w", err)
+	}
+	if t.ID == "" {
+		return TaskSnapshot{}, errors.New("agent: task snapshot: id is required")
+	}
+	return t, nil
+}
+
+// ForRole returns the snapshot trimmed to the task context the role asks
+// for in its contract.
+func (t TaskSnapshot) ForRole(role workflow.RoleContract) TaskSnapshot {
+	out := TaskSnapshot{ID: t.ID, Title: t.Title, Description: t.Description}
+	if role.WantsContext(workflow.ContextNotes) {
+		out.Notes = t.Notes
+	}
+	if role.WantsContext(workflow.ContextParent) {
+		out.Parent = t.Parent
+	}
+	if role.WantsContext(workflow.ContextSiblings) {
+		out.Siblings = t.Siblings
+	}
+	if role.WantsContext(workflow.ContextBlockers) {
+		out.Blockers = t.Blockers
+	}
+	return out
 }
 
 // ProjectInstruction is a snapshotted project instruction file.
@@ -205,6 +270,16 @@ type ProjectInstruction struct {
 	Content string
 }
 
+// ProjectInstructions converts the instruction files a role receives from
+// its run's snapshot (workflow.SnapshotInstructions) for EnvelopeInput.
+func ProjectInstructions(ins []workflow.Instruction) []ProjectInstruction {
+	out := make([]ProjectInstruction, 0, len(ins))
+	for _, in := range ins {
+		out = append(out, ProjectInstruction{Name: in.Name, Content: in.Content})
+	}
+	return out
+}
+
 // EnvelopeInput is the fully-resolved input the controller passes to
 // BuildEnvelope.
 type EnvelopeInput struct {
@@ -226,6 +301,16 @@ type Result struct {
 	ResultJSON  []byte
 	Artifacts   map[string][]byte
 	Raw         string
+	// Usage is what the agent reported spending, from its result event.
+	Usage Usage
+}
+
+// Usage is the token and cost accounting of one agent invocation. Zero
+// fields mean the runner did not report them.
+type Usage struct {
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
 }
 
 // Completion is the validated completion of a step attempt.
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"bdtui/internal/daemon"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// artifactViewMaxBytes caps how much of an artifact the viewer fetches.
+// The daemon still verifies the hash over the whole file; the viewer just
+// avoids pulling multi-megabyte logs into the TUI.
+const artifactViewMaxBytes = 1 << 20
+
+// selectedTimelineRow returns the timeline row under the cursor (the top
+// visible row), or nil when the timeline is empty.
+func (m model) selectedTimelineRow() *RunTimelineRow {
+	if m.Runs == nil || m.Runs.Timeline == nil {
+		return nil
+	}
+	tl := m.Runs.Timeline
+	if tl.Scroll < 0 || tl.Scroll >= len(tl.Rows) {
+		return nil
+	}
+	return &tl.Rows[tl.Scroll]
+}
+
+// openSelectedArtifact opens the artifact viewer for the timeline row
+// under the cursor and starts an async ReadArtifact load.
+func (m model) openSelectedArtifact() (tea.Model, tea.Cmd) {
+	row := m.selectedTimelineRow()
+	if row == nil || row.Kind != "artifact" {
+		m.setToast("warning", "select an artifact row to view it")
+		return m, nil
+	}
+	if m.Daemon == nil {
+		m.setToast("warning", "daemon not running")
+		return m, nil
+	}
+	m.Runs.Artifact = &ArtifactViewState{
+		ID:         row.ID,
+		Name:       row.Summary,
+		LoadingMsg: "loading artifact...",
+	}
+	return m, loadArtifactCmd(m.Daemon, row.ID)
+}
+
+// loadArtifactCmd streams the artifact through ReadArtifact and delivers
+// it as artifactLoadedMsg.
+func loadArtifactCmd(client *daemon.Client, id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), runsLoadTimeout)
+		defer cancel()
+		content, err := client.ReadArtifactBytes(ctx, id, 0, artifactViewMaxBytes)
+		if err != nil {
+			return artifactLoadedMsg{id: id, err: err}
+		}
+		return artifactLoadedMsg{id: id, content: content}
+	}
+}
+
+// artifactLoadedMsg carries the result of loadArtifactCmd back to the
+// model.
+type artifactLoadedMsg struct {
+	id      string
+	content *daemon.ArtifactContent
+	err     error
+}
+
+// handleArtifactLoaded applies an artifactLoadedMsg. Results for an
+// artifact the viewer no longer shows are dropped.
+func (m model) handleArtifactLoaded(msg artifactLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.Runs == nil || m.Runs.Artifact == nil || m.Runs.Artifact.ID != msg.id {
+		return m, nil
+	}
+	av := m.Runs.Artifact
+	av.Loaded = true
+	av.LoadingMsg = ""
+	if msg.err != nil {
+		av.LastError = fmt.Sprintf("read failed: %v", msg.err)
+		m.setToast("warning", av.LastError)
+		return m, nil
+	}
+	c := msg.content
+	av.LastError = ""
+	if c.Name != "" {
+		av.Name = c.Name
+	}
+	av.Hash = c.Hash
+	av.Size = c.Size
+	av.Verified = c.Verified
+	av.Content = c.Data
+	av.Truncated = int64(len(c.Data)) < c.Size
+	if av.Truncated {
+		// The range cut may have split a multi-byte rune; drop the
+		// partial tail so text artifacts are not mistaken for binary.
+		for i := 0; i < utf8.UTFMax-1 && len(av.Content) > 0 && !utf8.Valid(av.Content); i++ {
+			av.Content = av.Content[:len(av.Content)-1]
+		}
+	}
+	av.Lines = nil
+	av.LinesWidth = 0
+	av.Scroll = 0
+	return m, nil
+}
+
+// scrollArtifactView moves the viewer by delta lines, clamped to the
+// rendered content.
+func (m model) scrollArtifactView(delta int) {
+	if m.Runs == nil || m.Runs.Artifact == nil {
+		return
+	}
+	av := m.Runs.Artifact
+	av.Scroll += delta
+	if av.Scroll > len(av.Lines)-1 {
+		av.Scroll = len(av.Lines) - 1
+	}
+	if av.Scroll < 0 {
+		av.Scroll = 0
+	}
+}
+
+// artifactLines renders artifact content for the viewer. Markdown files
+// go through the same glamour pipeline as issue descriptions; other text
+// keeps its indentation (logs, JSON, diffs) and is clipped to width, and
+// binary content is summarised rather than dumped.
+func artifactLines(name string, content []byte, width int) []string {
+	if !utf8.Valid(content) {
+		return []string{fmt.Sprintf("(binary artifact, %d bytes)", len(content))}
+	}
+	text := string(content)
+	if isMarkdownArtifact(name) {
+		return renderDescriptionLines(text, width)
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if strings.TrimSpace(text) == "" {
+		return []string{"(empty)"}
+	}
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = truncate(strings.ReplaceAll(l, "\t", "    "), width)
+	}
+	return lines
+}
+
+func isMarkdownArtifact(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
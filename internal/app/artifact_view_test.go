@@ -0,0 +1,81 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestArtifactLinesPlainTextKeepsIndentation(t *testing.T) {
+	got := artifactLines("result.json", []byte("{\n  \"ok\": true\n}\n"), 40)
+	if len(got) != 3 || got[1] != `  "ok": true` {
+		t.Fatalf("unexpected plain lines: %#v", got)
+	}
+}
+
+func TestArtifactLinesBinary(t *testing.T) {
+	got := artifactLines("blob.bin", []byte{0xff, 0xfe, 0x00}, 40)
+	if len(got) != 1 || !strings.Contains(got[0], "binary artifact, 3 bytes") {
+		t.Fatalf("unexpected binary summary: %#v", got)
+	}
+}
+
+func TestArtifactLinesMarkdownUsesGlamour(t *testing.T) {
+	got := strings.Join(artifactLines("report.md", []byte("# Review\n\n- **blocker** found"), 60), "\n")
+	// The notty style keeps heading/emphasis markers but still lays the
+	// document out: list items get a bullet.
+	if !strings.Contains(got, "• **blocker** found") || !strings.Contains(got, "Review") {
+		t.Fatalf("markdown was not rendered: %q", got)
+	}
+}
+
+// TestRenderArtifactView asserts the viewer shows the verification state
+// and the truncation note, and that esc returns to the timeline rather
+// than the run list.
+func TestRenderArtifactView(t *testing.T) {
+	m := model{Width: 80, Mode: ModeRuns, Runs: &RunsTabState{
+		Loaded:   true,
+		Rows:     []RunRow{{RunID: "run-aaaa"}},
+		Timeline: &RunTimelineState{RunID: "run-aaaa", Loaded: true},
+		Artifact: &ArtifactViewState{
+			ID:        "art-1",
+			Name:      "log.txt",
+			Size:      100,
+			Verified:  true,
+			Truncated: true,
+			Loaded:    true,
+			Content:   []byte("line one\nline two"),
+		},
+	}}
+	out := m.renderRunsModal()
+	for _, want := range []string{"Artifact log.txt", "sha256 ok", "line two", "showing first 17 of 100 bytes"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in artifact view, got: %q", want, out)
+		}
+	}
+
+	got, _ := m.handleRunsKey(tea.KeyMsg{Type: tea.KeyEsc})
+	gm := got.(model)
+	if gm.Runs.Artifact != nil || gm.Runs.Timeline == nil {
+		t.Fatalf("esc should return to the timeline, got %+v", gm.Runs)
+	}
+}
+
+func TestOpenSelectedArtifactRequiresArtifactRow(t *testing.T) {
+	m := model{Runs: &RunsTabState{
+		Loaded: true,
+		Timeline: &RunTimelineState{
+			Loaded: true,
+			Rows:   []RunTimelineRow{{Kind: "execution", ID: "exec-1"}},
+		},
+	}}
+	got, _ := m.openSelectedArtifact()
+	gm := got.(model)
+	if gm.Runs.Artifact != nil {
+		t.Fatal("viewer should not open on a non-artifact row")
+	}
+	if gm.ToastKind != "warning" {
+		t.Fatalf("expected warning toast, got %q", gm.ToastKind)
+	}
+}
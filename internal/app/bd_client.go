@@ -18,15 +18,19 @@ import (
 
 type BdClient struct {
 	RepoDir string
+
+	jsonl *jsonlIssueReader
 }
 
 const (
 	sortModeKVKey      = "bdtui.sort_mode"
+	boardLayoutKVKey   = "bdtui.board_layout"
+	viewsKVKey         = "bdtui.views"
 	issueLoadPageLimit = 200
 )
 
 func NewBdClient(repoDir string) *BdClient {
-	return &BdClient{RepoDir: repoDir}
+	return &BdClient{RepoDir: repoDir, jsonl: newJSONLIssueReader(filepath.Join(repoDir, ".beads"))}
 }
 
 type rawDependency struct {
@@ -54,6 +58,7 @@ type rawIssue struct {
 }
 
 type CreateParams struct {
+	ID          string // explicit id, e.g. to restore a deleted issue
 	Title       string
 	Description string
 	Priority    int
@@ -125,8 +130,43 @@ func stripJSONPrefix(s string) string {
 	return s
 }
 
-func (c *BdClient) ListIssues() ([]Issue, string, error) {
-	return c.ListIssuesPage(issueLoadPageLimit, "")
+// IssueWindow selects the issues a board load brings in: every issue that
+// is not closed, plus the closed issues closed on or after ClosedSince. A
+// zero ClosedSince selects every closed issue too.
+type IssueWindow struct {
+	ClosedSince time.Time
+}
+
+// LoadIssues reads the board from .beads/issues.jsonl, which always yields
+// every issue, and falls back to `bd list` for just the window when the
+// file cannot be used. complete reports whether the result is the whole
+// board. Mutations always go through bd.
+func (c *BdClient) LoadIssues(w IssueWindow) (issues []Issue, hash string, complete bool, err error) {
+	if c.jsonl != nil {
+		issues, hash, err = c.jsonl.Read()
+		if err == nil {
+			return issues, hash, true, nil
+		}
+		logger.Info("native issue read skipped: %v", err)
+	}
+
+	var raw []rawIssue
+	if w.ClosedSince.IsZero() {
+		raw, err = c.listRaw("list", "--json", "--all", "--limit", "0")
+	} else {
+		raw, err = c.listRaw("list", "--json", "--limit", "0")
+		if err == nil {
+			var closed []rawIssue
+			closed, err = c.listRaw("list", "--json", "--status", string(StatusClosed),
+				"--closed-after", w.ClosedSince.Format("2006-01-02"), "--limit", "0")
+			raw = append(raw, closed...)
+		}
+	}
+	if err != nil {
+		return nil, "", false, err
+	}
+	issues = normalizeIssues(raw)
+	return issues, canonicalIssuesHash(issues), w.ClosedSince.IsZero(), nil
 }
 
 // ListIssuesPage returns one bounded page. Local bd does not support offsets;
@@ -140,20 +180,74 @@ func (c *BdClient) ListIssuesPage(limit int, statusFilter string) ([]Issue, stri
 	if statusFilter != "" {
 		args = append(args, "--status", statusFilter)
 	}
-	out, err := c.run(args...)
+	raw, err := c.listRaw(args...)
 	if err != nil {
 		return nil, "", err
 	}
+
+	issues := normalizeIssues(raw)
+	hash := canonicalIssuesHash(issues)
+	return issues, hash, nil
+}
+
+// shownIssue is an issue as `bd show --json` prints it. Unlike `bd list`
+// it comes with its related issues expanded, closed ones included:
+// dependencies are what it depends on (parent, blockers) and dependents
+// what depends on it (children, the issues it blocks).
+type shownIssue struct {
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Description  string         `json:"description"`
+	Notes        string         `json:"notes"`
+	Status       string         `json:"status"`
+	Parent       string         `json:"parent"`
+	Dependencies []shownRelated `json:"dependencies"`
+	Dependents   []shownRelated `json:"dependents"`
+}
+
+type shownRelated struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Status         string `json:"status"`
+	DependencyType string `json:"dependency_type"`
+}
+
+// showIssue runs `bd show <id> --json`, which prints the issue alone or in
+// a one-element array.
+func (c *BdClient) showIssue(id string) (shownIssue, error) {
+	out, err := c.run("show", id, "--json")
+	if err != nil {
+		return shownIssue{}, err
+	}
+	out = stripJSONPrefix(out)
+
+	var issues []shownIssue
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		var one shownIssue
+		if err := json.Unmarshal([]byte(out), &one); err != nil {
+			return shownIssue{}, fmt.Errorf("parse bd show %s: %w", id, err)
+		}
+		issues = []shownIssue{one}
+	}
+	if len(issues) == 0 || issues[0].ID == "" {
+		return shownIssue{}, fmt.Errorf("bd show %s: no issue", id)
+	}
+	return issues[0], nil
+}
+
+// listRaw runs a `bd list --json` variant and decodes its issues.
+func (c *BdClient) listRaw(args ...string) ([]rawIssue, error) {
+	out, err := c.run(args...)
+	if err != nil {
+		return nil, err
+	}
 	out = stripJSONPrefix(out)
 
 	var raw []rawIssue
 	if err := json.Unmarshal([]byte(out), &raw); err != nil {
-		return nil, "", fmt.Errorf("parse bd list json: %w", err)
+		return nil, fmt.Errorf("parse bd list json: %w", err)
 	}
-
-	issues := normalizeIssues(raw)
-	hash := canonicalIssuesHash(issues)
-	return issues, hash, nil
+	return raw, nil
 }
 
 func normalizeIssues(raw []rawIssue) []Issue {
@@ -273,6 +367,9 @@ func canonicalIssuesHash(issues []Issue) string {
 
 func (c *BdClient) CreateIssue(p CreateParams) (string, error) {
 	args := []string{"create", p.Title, "--silent"}
+	if p.ID != "" {
+		args = append(args, "--id", p.ID)
+	}
 	if p.Description != "" {
 		args = append(args, "-d", p.Description)
 	}
@@ -401,6 +498,65 @@ func (c *BdClient) SetSortMode(mode SortMode) error {
 	return err
 }
 
+// GetBoardLayout reads the board layout saved as JSON. An unset key is the
+// default layout.
+func (c *BdClient) GetBoardLayout() (BoardLayout, error) {
+	out, err := c.run("kv", "get", boardLayoutKVKey)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "(not set)") {
+			return BoardLayout{}, nil
+		}
+		return BoardLayout{}, err
+	}
+	var layout BoardLayout
+	if strings.TrimSpace(out) == "" {
+		return layout, nil
+	}
+	if err := json.Unmarshal([]byte(out), &layout); err != nil {
+		return BoardLayout{}, fmt.Errorf("parse %s: %w", boardLayoutKVKey, err)
+	}
+	return layout, nil
+}
+
+func (c *BdClient) SetBoardLayout(layout BoardLayout) error {
+	raw, err := json.Marshal(layout)
+	if err != nil {
+		return err
+	}
+	_, err = c.run("kv", "set", boardLayoutKVKey, string(raw))
+	return err
+}
+
+func (c *BdClient) GetViews() ([]SavedView, error) {
+	out, err := c.run("kv", "get", viewsKVKey)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "(not set)") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var views []SavedView
+	if strings.TrimSpace(out) == "" {
+		return views, nil
+	}
+	if err := json.Unmarshal([]byte(out), &views); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", viewsKVKey, err)
+	}
+	return views, nil
+}
+
+func (c *BdClient) SetViews(views []SavedView) error {
+	if views == nil {
+		views = []SavedView{}
+	}
+	raw, err := json.Marshal(views)
+	if err != nil {
+		return err
+	}
+	_, err = c.run("kv", "set", viewsKVKey, string(raw))
+	return err
+}
+
 func asString(v any) string {
 	switch t := v.(type) {
 	case nil:
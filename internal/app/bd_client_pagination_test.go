@@ -7,7 +7,7 @@ import (
 	"testing"
 )
 
-func TestListIssuesUsesBoundedLimitAndParsesJSON(t *testing.T) {
+func TestListIssuesPageUsesBoundedLimitAndParsesJSON(t *testing.T) {
 	dir := t.TempDir()
 	argsPath := filepath.Join(dir, "args")
 	bdPath := filepath.Join(dir, "bd")
@@ -21,9 +21,9 @@ printf '%s\n' '[{"id":"bd-1","title":"first","status":"open","priority":1,"issue
 	t.Setenv("ARGS_FILE", argsPath)
 	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
 
-	issues, hash, err := NewBdClient(dir).ListIssues()
+	issues, hash, err := NewBdClient(dir).ListIssuesPage(0, "")
 	if err != nil {
-		t.Fatalf("ListIssues: %v", err)
+		t.Fatalf("ListIssuesPage: %v", err)
 	}
 	if hash == "" || len(issues) != 2 || issues[0].ID != "bd-1" || issues[1].ID != "bd-2" {
 		t.Fatalf("unexpected parsed issues: %#v, hash=%q", issues, hash)
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	beadsadapter "bdtui/internal/adapters/beads"
+)
+
+// errJSONLUnavailable means issues.jsonl cannot stand in for `bd list`: it
+// is missing, older than the database, or in a format this reader does not
+// know. ListIssues then falls back to bd.
+var errJSONLUnavailable = errors.New("issues.jsonl unavailable")
+
+// jsonlDatabaseFiles are bd database files that, when newer than
+// issues.jsonl, mean the export has not caught up with a write yet.
+var jsonlDatabaseFiles = []string{"beads.db", "beads.db-wal"}
+
+// jsonlIssueReader reads the board straight from .beads/issues.jsonl. It
+// caches the last parse: an unchanged file is not read again, and after a
+// change only lines that differ from the previous version are decoded.
+type jsonlIssueReader struct {
+	path string
+
+	mu      sync.Mutex
+	size    int64
+	modTime time.Time
+	hash    string
+	issues  []Issue
+	records map[string]jsonlRecord // decoded records by line
+}
+
+// jsonlRecord is one line of issues.jsonl. Lines of another _type than
+// "issue" are kept so they are not decoded again, but never listed.
+type jsonlRecord struct {
+	Type string `json:"_type"`
+	rawIssue
+}
+
+func newJSONLIssueReader(beadsDir string) *jsonlIssueReader {
+	return &jsonlIssueReader{path: beadsadapter.IssuesFile(beadsDir)}
+}
+
+// Read returns the issues in the file and a hash of its contents, or an
+// error wrapping errJSONLUnavailable when bd has to be asked instead.
+func (r *jsonlIssueReader) Read() ([]Issue, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", errJSONLUnavailable, err)
+	}
+	dir := filepath.Dir(r.path)
+	for _, name := range jsonlDatabaseFiles {
+		if db, err := os.Stat(filepath.Join(dir, name)); err == nil && db.ModTime().After(info.ModTime()) {
+			return nil, "", fmt.Errorf("%w: %s is newer than the export", errJSONLUnavailable, name)
+		}
+	}
+	if r.records != nil && info.Size() == r.size && info.ModTime().Equal(r.modTime) {
+		return append([]Issue(nil), r.issues...), r.hash, nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", errJSONLUnavailable, err)
+	}
+	records := make(map[string]jsonlRecord, len(r.records))
+	var raw []rawIssue
+	for n, line := range bytes.Split(data, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		rec, ok := r.records[string(line)]
+		if !ok {
+			if err := json.Unmarshal(line, &rec); err != nil {
+				return nil, "", fmt.Errorf("%w: line %d: %v", errJSONLUnavailable, n+1, err)
+			}
+			if rec.Type == "" {
+				rec.Type = "issue"
+			}
+			if rec.Type == "issue" && asString(rec.ID) == "" {
+				return nil, "", fmt.Errorf("%w: line %d: issue without id", errJSONLUnavailable, n+1)
+			}
+		}
+		records[string(line)] = rec
+		if rec.Type == "issue" {
+			raw = append(raw, rec.rawIssue)
+		}
+	}
+
+	r.size, r.modTime = info.Size(), info.ModTime()
+	r.issues = normalizeIssues(raw)
+	r.hash = canonicalIssuesHash(r.issues)
+	r.records = records
+	return append([]Issue(nil), r.issues...), r.hash, nil
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeBdOnPath installs a bd that prints out and appends each argument list
+// it ran with to the returned file.
+func fakeBdOnPath(t *testing.T, out string) string {
+	t.Helper()
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "called")
+	script := "#!/bin/sh\necho \"$*\" >> " + marker + "\nprintf '%s\\n' '" + out + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return marker
+}
+
+func writeIssuesJSONL(t *testing.T, beadsDir, content string, mod time.Time) {
+	t.Helper()
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write issues.jsonl: %v", err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+}
+
+func TestLoadIssuesReadsFixtureJSONLWithoutBd(t *testing.T) {
+	marker := fakeBdOnPath(t, `[]`)
+
+	window := IssueWindow{ClosedSince: time.Now()}
+	issues, hash, complete, err := NewBdClient(filepath.Join("..", "..", "tests", "fixtures", "testdb")).LoadIssues(window)
+	if err != nil {
+		t.Fatalf("LoadIssues: %v", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("bd was run although issues.jsonl is readable")
+	}
+	if !complete || hash == "" || len(issues) != 40 {
+		t.Fatalf("got %d issues, hash %q, complete %v", len(issues), hash, complete)
+	}
+	byID := map[string]Issue{}
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	if got := byID["test-009"]; got.Parent != "test-002" || got.Assignee != "diana" {
+		t.Fatalf("test-009 = %+v", got)
+	}
+	if got := byID["test-099"]; !got.IsDeferred() {
+		t.Fatalf("test-099 should be deferred: %+v", got)
+	}
+}
+
+func TestJSONLIssueReaderReparsesChangedLines(t *testing.T) {
+	beadsDir := t.TempDir()
+	r := newJSONLIssueReader(beadsDir)
+	base := time.Now().Add(-time.Hour)
+
+	writeIssuesJSONL(t, beadsDir, `{"_type":"issue","id":"a","title":"first","status":"open"}
+{"_type":"memory","key":"ignored"}
+{"id":"b","title":"second","status":"open","dependencies":[{"issue_id":"b","depends_on_id":"a","type":"blocks"}]}
+`, base)
+	issues, hash, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(issues) != 2 || len(r.records) != 3 {
+		t.Fatalf("issues = %+v, records = %d", issues, len(r.records))
+	}
+
+	again, sameHash, err := r.Read()
+	if err != nil || sameHash != hash || len(again) != 2 {
+		t.Fatalf("unchanged re-read = %d issues, %q, %v", len(again), sameHash, err)
+	}
+
+	writeIssuesJSONL(t, beadsDir, `{"_type":"issue","id":"a","title":"first","status":"closed"}
+{"id":"b","title":"second","status":"open","dependencies":[{"issue_id":"b","depends_on_id":"a","type":"blocks"}]}
+`, base.Add(time.Minute))
+	issues, newHash, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read after change: %v", err)
+	}
+	if newHash == hash || len(r.records) != 2 {
+		t.Fatalf("hash %q after change, %d records", newHash, len(r.records))
+	}
+	for _, issue := range issues {
+		if issue.ID == "b" && (issue.Display != StatusOpen || len(issue.BlockedBy) != 0) {
+			t.Fatalf("b should be unblocked once a closes: %+v", issue)
+		}
+	}
+}
+
+func TestLoadIssuesHashDoesNotDependOnSource(t *testing.T) {
+	const issue = `{"id":"bd-1","title":"same","status":"open","priority":1,"issue_type":"task"}`
+	fakeBdOnPath(t, "["+issue+"]")
+	repo := t.TempDir()
+	beadsDir := filepath.Join(repo, ".beads")
+	if err := os.Mkdir(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeIssuesJSONL(t, beadsDir, issue+"\n", time.Now().Add(-time.Hour))
+	client := NewBdClient(repo)
+
+	_, fromJSONL, _, err := client.LoadIssues(IssueWindow{})
+	if err != nil {
+		t.Fatalf("LoadIssues from jsonl: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.db"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, fromBd, _, err := client.LoadIssues(IssueWindow{})
+	if err != nil {
+		t.Fatalf("LoadIssues from bd: %v", err)
+	}
+	if fromJSONL != fromBd {
+		t.Fatalf("hash changed with the source: jsonl %q, bd %q", fromJSONL, fromBd)
+	}
+}
+
+func TestLoadIssuesFallsBackToBd(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	cases := []struct {
+		name  string
+		setup func(t *testing.T, beadsDir string)
+	}{
+		{"missing", func(t *testing.T, beadsDir string) {}},
+		{"malformed", func(t *testing.T, beadsDir string) {
+			writeIssuesJSONL(t, beadsDir, "not json\n", base)
+		}},
+		{"without id", func(t *testing.T, beadsDir string) {
+			writeIssuesJSONL(t, beadsDir, `{"title":"nameless"}`+"\n", base)
+		}},
+		{"database newer", func(t *testing.T, beadsDir string) {
+			writeIssuesJSONL(t, beadsDir, `{"id":"a","title":"stale"}`+"\n", base)
+			if err := os.WriteFile(filepath.Join(beadsDir, "beads.db"), nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			marker := fakeBdOnPath(t, `[{"id":"bd-1","title":"from bd","status":"open"}]`)
+			repo := t.TempDir()
+			beadsDir := filepath.Join(repo, ".beads")
+			if err := os.Mkdir(beadsDir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			tc.setup(t, beadsDir)
+
+			client := NewBdClient(repo)
+			if _, _, err := client.jsonl.Read(); !errors.Is(err, errJSONLUnavailable) {
+				t.Fatalf("Read = %v, want errJSONLUnavailable", err)
+			}
+			issues, _, complete, err := client.LoadIssues(IssueWindow{})
+			if err != nil {
+				t.Fatalf("LoadIssues: %v", err)
+			}
+			calls, err := os.ReadFile(marker)
+			if err != nil {
+				t.Fatal("bd was not run for the fallback")
+			}
+			if got := strings.TrimSpace(string(calls)); got != "list --json --all --limit 0" {
+				t.Fatalf("bd ran with %q", got)
+			}
+			if !complete || len(issues) != 1 || issues[0].ID != "bd-1" {
+				t.Fatalf("fallback = %+v, complete %v", issues, complete)
+			}
+		})
+	}
+}
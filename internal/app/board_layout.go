@@ -0,0 +1,488 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// swimlaneMinInner is the smallest inner height of a swimlane cell: the
+// header, the divider and three cards.
+const swimlaneMinInner = 6
+
+// SwimlaneMode groups the board into horizontal lanes.
+type SwimlaneMode string
+
+const (
+	SwimlaneNone     SwimlaneMode = ""
+	SwimlaneAssignee SwimlaneMode = "assignee"
+	SwimlaneEpic     SwimlaneMode = "epic"
+	SwimlaneLabel    SwimlaneMode = "label"
+)
+
+func (s SwimlaneMode) Label() string {
+	if s == SwimlaneNone {
+		return "off"
+	}
+	return string(s)
+}
+
+// Next cycles off -> assignee -> epic -> label -> off.
+func (s SwimlaneMode) Next() SwimlaneMode {
+	switch s {
+	case SwimlaneNone:
+		return SwimlaneAssignee
+	case SwimlaneAssignee:
+		return SwimlaneEpic
+	case SwimlaneEpic:
+		return SwimlaneLabel
+	default:
+		return SwimlaneNone
+	}
+}
+
+// BoardLayout is the user-defined board of a project, stored in bd kv. No
+// columns means the four status columns.
+type BoardLayout struct {
+	Columns   []ColumnDef  `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Swimlanes SwimlaneMode `json:"swimlanes,omitempty" yaml:"swimlanes,omitempty"`
+}
+
+// ColumnDef is one column of a custom layout. Filter selects its issues
+// and a card shows in the first column it matches. Status is what moving a
+// card into the column sets; it defaults to the status the filter names.
+// Weight sizes the column against the others (default 1) and WIP, when
+// set, is the card count above which the column is flagged.
+type ColumnDef struct {
+	Name   string `json:"name" yaml:"name"`
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	Status Status `json:"status,omitempty" yaml:"status,omitempty"`
+	Weight int    `json:"weight,omitempty" yaml:"weight,omitempty"`
+	WIP    int    `json:"wip,omitempty" yaml:"wip,omitempty"`
+}
+
+// columnDef is a checked ColumnDef. key indexes the board maps when there
+// are no swimlanes; for the default columns it is the status itself.
+type columnDef struct {
+	ColumnDef
+	key   Status
+	query issueQuery
+}
+
+var defaultColumnDefs = func() []columnDef {
+	defs := make([]columnDef, 0, len(statusOrder))
+	for _, status := range statusOrder {
+		query, _ := parseQuery("status:" + string(status))
+		defs = append(defs, columnDef{
+			ColumnDef: ColumnDef{Name: status.Label(), Filter: "status:" + string(status), Status: status, Weight: 1},
+			key:       status,
+			query:     query,
+		})
+	}
+	return defs
+}()
+
+// compileLayout checks the columns of layout. An empty list gives the
+// default columns.
+func compileLayout(layout BoardLayout) ([]columnDef, error) {
+	switch layout.Swimlanes {
+	case SwimlaneNone, SwimlaneAssignee, SwimlaneEpic, SwimlaneLabel:
+	default:
+		return nil, fmt.Errorf("unknown swimlanes %q (assignee, epic, label)", layout.Swimlanes)
+	}
+	if len(layout.Columns) == 0 {
+		return defaultColumnDefs, nil
+	}
+	defs := make([]columnDef, 0, len(layout.Columns))
+	seen := map[string]bool{}
+	for _, col := range layout.Columns {
+		col.Name = strings.TrimSpace(col.Name)
+		if col.Name == "" {
+			return nil, fmt.Errorf("column without a name")
+		}
+		if seen[col.Name] {
+			return nil, fmt.Errorf("duplicate column %q", col.Name)
+		}
+		seen[col.Name] = true
+		query, err := parseQuery(col.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", col.Name, err)
+		}
+		if col.Status == "" {
+			col.Status, _ = query.status()
+		} else if _, ok := statusFromString(string(col.Status)); !ok {
+			return nil, fmt.Errorf("column %q: invalid status %q", col.Name, col.Status)
+		}
+		if col.Weight < 0 || col.WIP < 0 {
+			return nil, fmt.Errorf("column %q: weight and wip must not be negative", col.Name)
+		}
+		if col.Weight == 0 {
+			col.Weight = 1
+		}
+		defs = append(defs, columnDef{ColumnDef: col, key: Status(col.Name), query: query})
+	}
+	return defs, nil
+}
+
+// setLayout checks layout and makes it the board layout.
+func (m *model) setLayout(layout BoardLayout) error {
+	defs, err := compileLayout(layout)
+	if err != nil {
+		return err
+	}
+	m.Layout = layout
+	m.BoardDefs = defs
+	return nil
+}
+
+func (m model) columnDefs() []columnDef {
+	if len(m.BoardDefs) == 0 {
+		return defaultColumnDefs
+	}
+	return m.BoardDefs
+}
+
+// columnDefFor is the index of the first column issue matches, or -1.
+func columnDefFor(defs []columnDef, issue Issue) int {
+	for i, def := range defs {
+		if def.query.matches(issue) {
+			return i
+		}
+	}
+	return -1
+}
+
+// boardColumn is one cell of the board: a column, in a swimlane when the
+// board has them. key indexes Columns, SelectedIdx and ScrollOffset.
+type boardColumn struct {
+	key  Status
+	def  int
+	lane string
+}
+
+func cellKey(def columnDef, lane string, mode SwimlaneMode) Status {
+	if mode == SwimlaneNone {
+		return def.key
+	}
+	return Status(lane + "\x1f" + string(def.key))
+}
+
+// issueLanes are the swimlanes issue shows in: its assignee, its epic
+// (the parent, or itself for a top-level epic) or each of its labels. ""
+// is the lane of issues without one.
+func issueLanes(issue Issue, mode SwimlaneMode) []string {
+	switch mode {
+	case SwimlaneAssignee:
+		return []string{strings.TrimSpace(issue.Assignee)}
+	case SwimlaneEpic:
+		if parent := strings.TrimSpace(issue.Parent); parent != "" {
+			return []string{parent}
+		}
+		if _, isEpic := dashboardEpicAccentStyle(issue.IssueType); isEpic {
+			return []string{issue.ID}
+		}
+		return []string{""}
+	case SwimlaneLabel:
+		if len(issue.Labels) == 0 {
+			return []string{""}
+		}
+		return issue.Labels
+	}
+	return []string{""}
+}
+
+// sortedLanes orders lanes by name with the lane of issues without one
+// last.
+func sortedLanes(seen map[string]bool) []string {
+	lanes := make([]string, 0, len(seen))
+	for lane := range seen {
+		if lane != "" {
+			lanes = append(lanes, lane)
+		}
+	}
+	sort.Strings(lanes)
+	if seen[""] || len(lanes) == 0 {
+		lanes = append(lanes, "")
+	}
+	return lanes
+}
+
+// columnKeys lists the cells of the board, lane by lane.
+func (m model) columnKeys() []Status {
+	board := m.boardCells()
+	keys := make([]Status, len(board))
+	for i, col := range board {
+		keys[i] = col.key
+	}
+	return keys
+}
+
+// boardCells is the board as last laid out. Before the first layout pass,
+// e.g. when the first load failed, it is one empty lane of the columns.
+func (m model) boardCells() []boardColumn {
+	if len(m.Board) > 0 {
+		return m.Board
+	}
+	defs := m.columnDefs()
+	board := make([]boardColumn, len(defs))
+	for i, def := range defs {
+		board[i] = boardColumn{key: cellKey(def, "", m.Layout.Swimlanes), def: i}
+	}
+	return board
+}
+
+// boardColumn finds the cell with key.
+func (m model) boardColumn(key Status) (boardColumn, bool) {
+	for _, col := range m.boardCells() {
+		if col.key == key {
+			return col, true
+		}
+	}
+	return boardColumn{}, false
+}
+
+// columnStatus is the status the cell with key stands for, if any.
+func (m model) columnStatus(key Status) Status {
+	col, ok := m.boardColumn(key)
+	if !ok {
+		return ""
+	}
+	return m.columnDefs()[col.def].Status
+}
+
+// columnHolds reports whether issue belongs in the cell with key, search
+// and filters aside.
+func (m model) columnHolds(key Status, issue Issue) bool {
+	col, ok := m.boardColumn(key)
+	if !ok || columnDefFor(m.columnDefs(), issue) != col.def {
+		return false
+	}
+	for _, lane := range issueLanes(issue, m.Layout.Swimlanes) {
+		if lane == col.lane {
+			return true
+		}
+	}
+	return false
+}
+
+// columnCount is the number of cards in column def across the swimlanes.
+func (m model) columnCount(def int) int {
+	ids := map[string]bool{}
+	for _, col := range m.boardCells() {
+		if col.def != def {
+			continue
+		}
+		for _, issue := range m.Columns[col.key] {
+			ids[issue.ID] = true
+		}
+	}
+	return len(ids)
+}
+
+// overWIP reports whether the column of the cell with key has more cards
+// than its WIP limit.
+func (m model) overWIP(key Status) (count, limit int, over bool) {
+	col, ok := m.boardColumn(key)
+	if !ok {
+		return 0, 0, false
+	}
+	limit = m.columnDefs()[col.def].WIP
+	if limit == 0 {
+		return 0, 0, false
+	}
+	count = m.columnCount(col.def)
+	return count, limit, count > limit
+}
+
+// columnWidths splits the board width between the columns of a lane by
+// their weights.
+func (m model) columnWidths() []int {
+	defs := m.columnDefs()
+	available := max(20, m.Width-4) - (len(defs) - 1)
+	total := 0
+	for _, def := range defs {
+		total += max(1, def.Weight)
+	}
+	widths := make([]int, len(defs))
+	for i, def := range defs {
+		widths[i] = max(20, available*max(1, def.Weight)/total)
+	}
+	return widths
+}
+
+// boardLanes lists the swimlanes of the board in order.
+func (m model) boardLanes() []string {
+	if m.Layout.Swimlanes == SwimlaneNone || len(m.Board) == 0 {
+		return []string{""}
+	}
+	lanes := make([]string, 0, len(m.Board)/len(m.columnDefs()))
+	for i := 0; i < len(m.Board); i += len(m.columnDefs()) {
+		lanes = append(lanes, m.Board[i].lane)
+	}
+	return lanes
+}
+
+// laneLayout returns the index of the first swimlane shown, how many fit
+// and the inner height of their cells. The lane of the selection is kept
+// in view. Without swimlanes it is the whole board.
+func (m model) laneLayout() (first, shown, innerHeight int) {
+	lanes := m.boardLanes()
+	if len(lanes) <= 1 && m.Layout.Swimlanes == SwimlaneNone {
+		return 0, 1, m.boardInnerHeight()
+	}
+	total := m.boardInnerHeight() + 2
+	shown = min(len(lanes), max(1, total/(swimlaneMinInner+3)))
+	innerHeight = max(swimlaneMinInner, total/shown-3)
+	selected := m.SelectedCol / len(m.columnDefs())
+	first = max(0, min(selected, len(lanes)-shown))
+	return first, shown, innerHeight
+}
+
+// columnInnerHeight is the inner height of one board cell.
+func (m model) columnInnerHeight() int {
+	_, _, innerHeight := m.laneLayout()
+	return innerHeight
+}
+
+// laneTitle names a swimlane.
+func (m model) laneTitle(lane string) string {
+	switch m.Layout.Swimlanes {
+	case SwimlaneAssignee:
+		return defaultString(lane, "(unassigned)")
+	case SwimlaneEpic:
+		if lane == "" {
+			return "(no epic)"
+		}
+		if epic := m.ByID[lane]; epic != nil {
+			return lane + " " + epic.Title
+		}
+		return lane
+	case SwimlaneLabel:
+		return defaultString(lane, "(no label)")
+	}
+	return lane
+}
+
+func (m model) renderLaneTitle(lane string, width int) string {
+	count := 0
+	for _, col := range m.Board {
+		if col.lane == lane {
+			count += len(m.Columns[col.key])
+		}
+	}
+	title := truncate(fmt.Sprintf("▌ %s (%d)", m.laneTitle(lane), count), max(1, width))
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("111")).Bold(true).Render(title)
+}
+
+// cycleSwimlanes switches to the next swimlane grouping and saves it.
+func (m model) cycleSwimlanes() (tea.Model, tea.Cmd) {
+	layout := m.Layout
+	layout.Swimlanes = layout.Swimlanes.Next()
+	if err := m.setLayout(layout); err != nil {
+		m.setToast("error", err.Error())
+		return m, nil
+	}
+	m.relayoutBoard()
+	m.setToast("info", "swimlanes: "+layout.Swimlanes.Label())
+	return m, persistBoardLayoutCmd(m.Client, layout)
+}
+
+// relayoutBoard lays the board out again and keeps the selected issue
+// selected.
+func (m *model) relayoutBoard() {
+	selectedID := m.currentIssueID()
+	m.computeColumns()
+	m.normalizeSelectionBounds()
+	if selectedID != "" {
+		m.selectIssueByID(selectedID)
+	}
+}
+
+func persistBoardLayoutCmd(client *BdClient, layout BoardLayout) tea.Cmd {
+	if client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return boardLayoutPersistMsg{err: client.SetBoardLayout(layout)}
+	}
+}
+
+// boardLayoutEditorContent is the layout as YAML for the editor. The
+// default columns are written out so they can be edited.
+func boardLayoutEditorContent(layout BoardLayout) ([]byte, error) {
+	if len(layout.Columns) == 0 {
+		for _, def := range defaultColumnDefs {
+			layout.Columns = append(layout.Columns, ColumnDef{Name: def.Name, Filter: def.Filter, Weight: def.Weight})
+		}
+	}
+	body, err := yaml.Marshal(layout)
+	if err != nil {
+		return nil, err
+	}
+	header := "# Board layout. Each column takes the issues its filter matches\n" +
+		"# (the search query syntax: status:, label:, p<=1, -term negates ...);\n" +
+		"# a card shows in the first matching column. Optional: status (set on move),\n" +
+		"# weight (width share), wip (limit). swimlanes: assignee, epic, label.\n"
+	return append([]byte(header), body...), nil
+}
+
+// openBoardLayoutInEditorCmd opens the board layout in $EDITOR and sends
+// the edited layout back as a boardLayoutEditorMsg.
+func (m model) openBoardLayoutInEditorCmd() (tea.Cmd, error) {
+	content, err := boardLayoutEditorContent(m.Layout)
+	if err != nil {
+		return nil, fmt.Errorf("marshal board layout: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(editorTempDir(), "bdtui-layout-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("create temp editor file: %w", err)
+	}
+	path := tmpFile.Name()
+	if _, err := tmpFile.Write(content); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("write temp editor file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("close temp editor file: %w", err)
+	}
+
+	return tea.ExecProcess(buildEditorCommand(resolveEditor(), path), func(execErr error) tea.Msg {
+		defer os.Remove(path)
+		if execErr != nil {
+			return boardLayoutEditorMsg{err: fmt.Errorf("editor failed: %w", execErr)}
+		}
+		updated, err := os.ReadFile(path)
+		if err != nil {
+			return boardLayoutEditorMsg{err: fmt.Errorf("read editor file: %w", err)}
+		}
+		var layout BoardLayout
+		if err := yaml.Unmarshal(updated, &layout); err != nil {
+			return boardLayoutEditorMsg{err: fmt.Errorf("parse board layout: %w", err)}
+		}
+		if _, err := compileLayout(layout); err != nil {
+			return boardLayoutEditorMsg{err: err}
+		}
+		return boardLayoutEditorMsg{layout: layout}
+	}), nil
+}
+
+// applyEditedLayout lays the board out with an edited layout and saves it.
+func (m model) applyEditedLayout(msg boardLayoutEditorMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.setToast("error", msg.err.Error())
+		return m, nil
+	}
+	if err := m.setLayout(msg.layout); err != nil {
+		m.setToast("error", err.Error())
+		return m, nil
+	}
+	m.relayoutBoard()
+	return m, persistBoardLayoutCmd(m.Client, msg.layout)
+}
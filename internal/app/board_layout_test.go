@@ -0,0 +1,164 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCustomColumnsWeightsAndWIP(t *testing.T) {
+	m := opQueueModel()
+	m.Width, m.Height = 120, 30
+	err := m.setLayout(BoardLayout{Columns: []ColumnDef{
+		{Name: "P0 bugs", Filter: "type:bug p0 -status:closed", Status: StatusInProgress, Weight: 2, WIP: 1},
+		{Name: "Frontend", Filter: "label:frontend"},
+		{Name: "Done", Filter: "status:closed"},
+	}})
+	if err != nil {
+		t.Fatalf("setLayout: %v", err)
+	}
+	m.applyLoadedIssues([]Issue{
+		{ID: "a", Title: "crash", Status: StatusOpen, Display: StatusOpen, IssueType: "bug", Priority: 0, Labels: []string{"frontend"}},
+		{ID: "b", Title: "leak", Status: StatusOpen, Display: StatusOpen, IssueType: "bug", Priority: 0},
+		{ID: "c", Title: "button", Status: StatusOpen, Display: StatusOpen, IssueType: "task", Priority: 2, Labels: []string{"frontend"}},
+		{ID: "d", Title: "misc", Status: StatusOpen, Display: StatusOpen, IssueType: "task", Priority: 2},
+	}, "h", true)
+
+	if got := len(m.Columns["P0 bugs"]); got != 2 {
+		t.Fatalf("P0 bugs column has %d cards, want 2 (first match wins)", got)
+	}
+	if got := len(m.Columns["Frontend"]); got != 1 || m.Columns["Frontend"][0].ID != "c" {
+		t.Fatalf("Frontend column = %+v", m.Columns["Frontend"])
+	}
+	if m.columnStatus("Done") != StatusClosed || m.columnStatus("Frontend") != "" {
+		t.Fatal("a column's status should default to the status of its filter")
+	}
+	if widths := m.columnWidths(); widths[0] <= widths[1] || widths[1] != widths[2] {
+		t.Fatalf("weighted widths = %v", widths)
+	}
+	if count, limit, over := m.overWIP("P0 bugs"); !over || count != 2 || limit != 1 {
+		t.Fatalf("overWIP = %d/%d %v", count, limit, over)
+	}
+	if out := m.renderColumn("P0 bugs", 40, 10, true); !strings.Contains(out, "WIP 2/1") {
+		t.Fatalf("column header should show the WIP limit, got %q", out)
+	}
+
+	if _, _, err := m.moveParams("P0 bugs", "Frontend"); err == nil {
+		t.Fatal("a column without a status should refuse drops")
+	}
+	label, params, err := m.moveParams("Frontend", "P0 bugs")
+	if err != nil || params.Status == nil || *params.Status != StatusInProgress {
+		t.Fatalf("move into P0 bugs = %q %+v %v", label, params, err)
+	}
+}
+
+func TestSwimlanesByAssignee(t *testing.T) {
+	m := opQueueModel(
+		Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen, Assignee: "zoe"},
+		Issue{ID: "b", Title: "b", Status: StatusInProgress, Display: StatusInProgress, Assignee: "amy"},
+		Issue{ID: "c", Title: "c", Status: StatusOpen, Display: StatusOpen},
+	)
+	m.Width, m.Height = 120, 40
+	m.selectIssueByID("a")
+
+	next, _ := m.handleLeaderCombo("g", "w")
+	m = next.(model)
+	if m.Layout.Swimlanes != SwimlaneAssignee {
+		t.Fatalf("swimlanes = %q", m.Layout.Swimlanes)
+	}
+	if lanes := m.boardLanes(); strings.Join(lanes, ",") != "amy,zoe," {
+		t.Fatalf("lanes = %q, want amy, zoe, unassigned", lanes)
+	}
+	if m.currentIssueID() != "a" || m.SelectedCol != len(statusOrder) {
+		t.Fatalf("selection should stay on a in zoe's lane, got %q at %d", m.currentIssueID(), m.SelectedCol)
+	}
+	out := m.renderBoard()
+	for _, want := range []string{"amy (1)", "zoe (1)", "(unassigned) (1)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("board misses lane %q:\n%s", want, out)
+		}
+	}
+
+	amyOpen := m.Board[0].key
+	label, params, err := m.moveParams(m.currentStatus(), amyOpen)
+	if err != nil || params.Assignee == nil || *params.Assignee != "amy" || params.Status != nil {
+		t.Fatalf("move to amy's lane = %q %+v %v", label, params, err)
+	}
+
+	m.moveColumn(-1)
+	if m.currentStatus() != m.Board[len(statusOrder)-1].key {
+		t.Fatal("h should walk back into the previous lane")
+	}
+}
+
+func TestCompileLayoutRejectsBadColumns(t *testing.T) {
+	for _, layout := range []BoardLayout{
+		{Columns: []ColumnDef{{Name: "x", Filter: "colour:red"}}},
+		{Columns: []ColumnDef{{Name: "x", Filter: "priority:9"}}},
+		{Columns: []ColumnDef{{Name: "x"}, {Name: "x"}}},
+		{Columns: []ColumnDef{{Name: "x", WIP: -1}}},
+		{Swimlanes: "team"},
+	} {
+		if _, err := compileLayout(layout); err == nil {
+			t.Fatalf("layout %+v should be rejected", layout)
+		}
+	}
+}
+
+func TestBoardLayoutKVRoundTrip(t *testing.T) {
+	marker := fakeBdOnPath(t, `{"columns":[{"name":"Doing","filter":"status:in_progress","wip":3}],"swimlanes":"epic"}`)
+	client := NewBdClient(t.TempDir())
+
+	layout, err := client.GetBoardLayout()
+	if err != nil {
+		t.Fatalf("GetBoardLayout: %v", err)
+	}
+	if layout.Swimlanes != SwimlaneEpic || len(layout.Columns) != 1 || layout.Columns[0].WIP != 3 {
+		t.Fatalf("layout = %+v", layout)
+	}
+	if err := client.SetBoardLayout(layout); err != nil {
+		t.Fatalf("SetBoardLayout: %v", err)
+	}
+	calls, _ := os.ReadFile(marker)
+	if !strings.Contains(string(calls), "kv get bdtui.board_layout") || !strings.Contains(string(calls), `kv set bdtui.board_layout {"columns":[{"name":"Doing"`) {
+		t.Fatalf("bd calls = %q", calls)
+	}
+
+	editor, err := boardLayoutEditorContent(BoardLayout{})
+	if err != nil || !strings.Contains(string(editor), "filter: status:in_progress") {
+		t.Fatalf("editor content should spell out the default columns: %q %v", editor, err)
+	}
+}
+
+func TestCustomLayoutRendersBeforeFirstLoad(t *testing.T) {
+	m := opQueueModel()
+	m.Width, m.Height = 160, 30
+	m.Board = nil
+	err := m.setLayout(BoardLayout{Columns: []ColumnDef{
+		{Name: "Triage", Filter: "status:open -has:label"},
+		{Name: "Ready", Filter: "status:open"},
+		{Name: "Doing", Filter: "status:in_progress"},
+		{Name: "Waiting", Filter: "status:blocked"},
+		{Name: "Done", Filter: "status:closed"},
+	}})
+	if err != nil {
+		t.Fatalf("setLayout: %v", err)
+	}
+
+	next, _ := m.Update(loadedMsg{err: errors.New("bd: database locked"), source: "init", complete: true})
+	m = next.(model)
+	if keys := m.columnKeys(); len(keys) != 5 || keys[0] != "Triage" {
+		t.Fatalf("columns before the first load = %q", keys)
+	}
+	out := m.View()
+	for _, want := range []string{"Triage", "Waiting", "Done"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("board misses column %q:\n%s", want, out)
+		}
+	}
+	m.moveColumn(4)
+	if m.currentStatus() != "Done" {
+		t.Fatalf("l should walk the layout columns, at %q", m.currentStatus())
+	}
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bulkFailureShown caps how many per-issue errors a bulk summary lists.
+const bulkFailureShown = 3
+
+// opBatch collects the per-issue results of one bulk operation.
+type opBatch struct {
+	label  string
+	total  int
+	done   int
+	failed []string
+}
+
+// record counts the result of the op on id and reports whether it was the
+// last one of the batch.
+func (b *opBatch) record(id string, err error) bool {
+	b.done++
+	if err != nil {
+		b.failed = append(b.failed, fmt.Sprintf("%s: %v", id, err))
+	}
+	return b.done == b.total
+}
+
+// summary is the toast for a finished batch, e.g. "priority -> P1: 4
+// issues" or "priority -> P1: 3/4 done, bd-7: ... (rolled back)".
+func (b *opBatch) summary() (kind, msg string) {
+	if len(b.failed) == 0 {
+		return "success", fmt.Sprintf("%s: %d issues", b.label, b.total)
+	}
+	shown := b.failed
+	if len(shown) > bulkFailureShown {
+		shown = append(shown[:bulkFailureShown:bulkFailureShown], fmt.Sprintf("+%d more", len(b.failed)-bulkFailureShown))
+	}
+	kind = "warning"
+	if len(b.failed) == b.total {
+		kind = "error"
+	}
+	return kind, fmt.Sprintf("%s: %d/%d done, %s (rolled back)", b.label, b.total-len(b.failed), b.total, strings.Join(shown, "; "))
+}
+
+// visualRange is the set of issues between the V anchor and the cursor.
+func (m model) visualRange() map[string]bool {
+	if m.VisualAnchor == "" {
+		return nil
+	}
+	col := m.Columns[m.VisualStatus]
+	anchor := -1
+	for i, issue := range col {
+		if issue.ID == m.VisualAnchor {
+			anchor = i
+			break
+		}
+	}
+	if anchor < 0 {
+		return nil
+	}
+	from, to := anchor, m.SelectedIdx[m.VisualStatus]
+	if from > to {
+		from, to = to, from
+	}
+	to = min(to, len(col)-1)
+	ids := make(map[string]bool, to-from+1)
+	for i := from; i <= to; i++ {
+		ids[col[i].ID] = true
+	}
+	return ids
+}
+
+// markedSet is Marked together with the visual range.
+func (m model) markedSet() map[string]bool {
+	set := make(map[string]bool, len(m.Marked))
+	for id, ok := range m.Marked {
+		if ok {
+			set[id] = true
+		}
+	}
+	for id := range m.visualRange() {
+		set[id] = true
+	}
+	return set
+}
+
+// toggleMark marks or unmarks the selected issue.
+func (m *model) toggleMark() {
+	issue := m.currentIssue()
+	if issue == nil {
+		m.setToast("warning", "no issue selected")
+		return
+	}
+	if m.Marked == nil {
+		m.Marked = map[string]bool{}
+	}
+	if m.Marked[issue.ID] {
+		delete(m.Marked, issue.ID)
+	} else {
+		m.Marked[issue.ID] = true
+	}
+}
+
+// toggleVisual starts a range at the selected issue, or ends the range and
+// keeps its issues marked.
+func (m *model) toggleVisual() {
+	if m.VisualAnchor != "" {
+		m.commitVisual()
+		return
+	}
+	issue := m.currentIssue()
+	if issue == nil {
+		m.setToast("warning", "no issue selected")
+		return
+	}
+	m.VisualAnchor = issue.ID
+	m.VisualStatus = m.currentStatus()
+}
+
+func (m *model) commitVisual() {
+	set := m.markedSet()
+	m.Marked = set
+	m.VisualAnchor = ""
+}
+
+// clearMarks drops the visual range, then the marks. It reports whether
+// there was anything to clear.
+func (m *model) clearMarks() bool {
+	if m.VisualAnchor != "" {
+		m.VisualAnchor = ""
+		return true
+	}
+	if len(m.Marked) == 0 {
+		return false
+	}
+	m.Marked = nil
+	return true
+}
+
+// markedIssues returns the marked issues shown on the board, cell by
+// cell. Marked issues hidden by a search or filter are left alone.
+func (m model) markedIssues() []Issue {
+	set := m.markedSet()
+	if len(set) == 0 {
+		return nil
+	}
+	var issues []Issue
+	for _, status := range m.columnKeys() {
+		for _, issue := range m.Columns[status] {
+			if set[issue.ID] {
+				issues = append(issues, issue)
+				delete(set, issue.ID) // a card in several label lanes counts once.
+			}
+		}
+	}
+	return issues
+}
+
+// bulkUpdate queues the update params builds for each issue as one bulk
+// operation and ends the visual range.
+func (m *model) bulkUpdate(label string, issues []Issue, params func(Issue) UpdateParams) tea.Cmd {
+	batch := &opBatch{label: label}
+	var cmds []tea.Cmd
+	for _, issue := range issues {
+		p := params(issue)
+		p.ID = issue.ID
+		itemLabel := fmt.Sprintf("%s: %s", issue.ID, label)
+		op := updateBoardOp(itemLabel, p)
+		entry := &undoEntry{label: itemLabel, do: op, undo: updateBoardOp(itemLabel, inverseUpdateParams(issue, p))}
+		cmds = append(cmds, m.queueOp(op, opNew, entry, batch))
+	}
+	m.commitVisual()
+	return tea.Batch(cmds...)
+}
+
+// bulkClose closes each issue with the reason appended to its description,
+// like closing a single issue does.
+func (m *model) bulkClose(issues []Issue, reason string) tea.Cmd {
+	batch := &opBatch{label: "closed"}
+	timestamp := time.Now().Format("2006-01-02 15:04")
+	var cmds []tea.Cmd
+	for _, issue := range issues {
+		newDesc := issue.Description
+		if reason != "" {
+			newDesc = fmt.Sprintf("%s\n\n---\n**Closed**: %s - %s", strings.TrimSpace(newDesc), timestamp, reason)
+		}
+		itemLabel := fmt.Sprintf("%s closed", issue.ID)
+		op := closeBoardOp(itemLabel, issue.ID, newDesc)
+		entry := &undoEntry{label: itemLabel, do: op, undo: reopenBoardOp(itemLabel, issue)}
+		cmds = append(cmds, m.queueOp(op, opNew, entry, batch))
+	}
+	m.commitVisual()
+	return tea.Batch(cmds...)
+}
+
+// submitBulkPrompt applies a prompt answered for several marked issues.
+func (m *model) submitBulkPrompt(ids []string, action PromptAction, value string) tea.Cmd {
+	var issues []Issue
+	for _, id := range ids {
+		if issue := m.ByID[id]; issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	switch action {
+	case PromptAssignee:
+		return m.bulkUpdate("assignee -> "+value, issues, func(Issue) UpdateParams {
+			return UpdateParams{Assignee: &value}
+		})
+	case PromptLabels:
+		labels := parseLabels(value)
+		return m.bulkUpdate("labels -> "+strings.Join(labels, ","), issues, func(Issue) UpdateParams {
+			return UpdateParams{Labels: &labels}
+		})
+	case PromptCloseReason:
+		return m.bulkClose(issues, value)
+	default:
+		return opCmd("", func() error { return fmt.Errorf("bulk %s is not supported", action) })
+	}
+}
+
+// openTargetsPrompt asks for the assignee (a) or the labels (L) of the
+// marked issues, or of the selected one when nothing is marked.
+func (m model) openTargetsPrompt(key string) (tea.Model, tea.Cmd) {
+	issue := m.currentIssue()
+	if issue == nil {
+		m.setToast("warning", "no issue selected")
+		return m, nil
+	}
+	action, title, what, initial := PromptAssignee, "Assignee", "assignee", issue.Assignee
+	if key == "L" {
+		action, title, what, initial = PromptLabels, "Labels", "labels (comma separated)", strings.Join(issue.Labels, ",")
+	}
+	description := fmt.Sprintf("Enter %s:", what)
+	marked := m.markedIssues()
+	if len(marked) > 0 {
+		description = fmt.Sprintf("Enter %s for %d issues:", what, len(marked))
+		initial = ""
+	}
+	m.Prompt = newPrompt(ModePrompt, title, description, issue.ID, action, initial)
+	for _, it := range marked {
+		m.Prompt.TargetIssues = append(m.Prompt.TargetIssues, it.ID)
+	}
+	m.Mode = ModePrompt
+	return m, nil
+}
+
+// bulkLaunchCmd launches the workflow on each task one after another and
+// sums up the results in a single opMsg.
+func (m model) bulkLaunchCmd(ids []string, workflowName string) tea.Cmd {
+	launches := make([]tea.Cmd, len(ids))
+	for i, id := range ids {
+		launches[i] = m.launchRunCmd(id, workflowName)
+	}
+	return func() tea.Msg {
+		var failed []string
+		for i, launch := range launches {
+			if msg, ok := launch().(opMsg); ok && msg.err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", ids[i], msg.err))
+			}
+		}
+		if len(failed) > 0 {
+			return opMsg{err: fmt.Errorf("started %d/%d runs of %s; %s", len(ids)-len(failed), len(ids), workflowName, strings.Join(failed, "; "))}
+		}
+		return opMsg{info: fmt.Sprintf("started %d runs of %s", len(ids), workflowName)}
+	}
+}
+
+// markedLabel is the footer note for the multi-selection.
+func (m model) markedLabel() string {
+	n := len(m.markedSet())
+	switch {
+	case m.VisualAnchor != "":
+		return fmt.Sprintf("-- VISUAL -- %d marked", n)
+	case n > 0:
+		return fmt.Sprintf("%d marked (Esc clear)", n)
+	}
+	return ""
+}
+
+func dashboardMarkedRowStyle(issueType string) lipgloss.Style {
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("17")).
+		Background(lipgloss.Color("153")).
+		Bold(true)
+	if _, isEpic := dashboardEpicAccentStyle(issueType); isEpic {
+		style = style.Bold(true)
+	}
+	return style
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestVisualRangeMarksAndBulkPriority(t *testing.T) {
+	m := opQueueModel(
+		Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen, Priority: 1},
+		Issue{ID: "b", Title: "b", Status: StatusOpen, Display: StatusOpen, Priority: 2},
+		Issue{ID: "c", Title: "c", Status: StatusOpen, Display: StatusOpen, Priority: 3},
+	)
+
+	for _, key := range []string{"V", "j"} {
+		next, _ := m.handleBoardKey(runeKey(key))
+		m = next.(model)
+	}
+	if got := len(m.markedIssues()); got != 2 {
+		t.Fatalf("visual range marked %d issues, want 2", got)
+	}
+	if !strings.Contains(m.markedLabel(), "VISUAL") {
+		t.Fatal("footer should show the visual range")
+	}
+
+	next, _ := m.handleBoardKey(runeKey("p"))
+	m = next.(model)
+	if m.VisualAnchor != "" || len(m.Marked) != 2 {
+		t.Fatalf("bulk op should end the range and keep the marks: %+v", m.Marked)
+	}
+	for _, id := range []string{"a", "b"} {
+		if got := m.ByID[id]; got.Priority != 2 || !got.Pending {
+			t.Fatalf("%s = P%d pending %v, want P2 pending", id, got.Priority, got.Pending)
+		}
+	}
+	if m.ByID["c"].Pending {
+		t.Fatal("unmarked issue should not change")
+	}
+
+	first, second := m.PendingOps[0].seq, m.PendingOps[1].seq
+	next, _ = m.handleOpDone(opDoneMsg{seq: first})
+	m = next.(model)
+	next, _ = m.handleOpDone(opDoneMsg{seq: second, err: errors.New("locked")})
+	m = next.(model)
+	if m.ToastKind != "warning" || !strings.Contains(m.Toast, "1/2 done") || !strings.Contains(m.Toast, "b: locked") {
+		t.Fatalf("summary toast = %s %q", m.ToastKind, m.Toast)
+	}
+	if m.ByID["b"].Priority != 2 {
+		// b was already P2 before the bulk change.
+		t.Fatalf("b = P%d after rollback", m.ByID["b"].Priority)
+	}
+
+	next, _ = m.handleBoardKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(model)
+	if len(m.Marked) != 0 {
+		t.Fatal("Esc should clear the marks")
+	}
+}
+
+func TestBulkUndoRevertsTheWholeBatch(t *testing.T) {
+	m := opQueueModel(
+		Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen},
+		Issue{ID: "b", Title: "b", Status: StatusOpen, Display: StatusOpen},
+	)
+	next, _ := m.handleBoardKey(runeKey(" "))
+	m = next.(model)
+	m.moveSelection(1)
+	next, _ = m.handleBoardKey(runeKey(" "))
+	m = next.(model)
+
+	next, _ = m.handleBoardKey(runeKey("a"))
+	m = next.(model)
+	if m.Mode != ModePrompt || len(m.Prompt.TargetIssues) != 2 {
+		t.Fatalf("assignee prompt targets = %+v", m.Prompt)
+	}
+	m.Prompt.Input.SetValue("zoe")
+	next, _ = m.handlePromptKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	for len(m.PendingOps) > 0 && !m.PendingOps[len(m.PendingOps)-1].done {
+		op := m.PendingOps[0]
+		for _, p := range m.PendingOps {
+			if !p.done {
+				op = p
+				break
+			}
+		}
+		next, _ = m.handleOpDone(opDoneMsg{seq: op.seq})
+		m = next.(model)
+	}
+	if m.ByID["a"].Assignee != "zoe" || m.ByID["b"].Assignee != "zoe" || len(m.UndoStack) != 2 {
+		t.Fatalf("bulk assignee not applied: undo %d", len(m.UndoStack))
+	}
+
+	m.undoCmd()
+	if len(m.UndoStack) != 0 || m.ByID["a"].Assignee != "" || m.ByID["b"].Assignee != "" {
+		t.Fatal("one undo should revert the whole bulk change")
+	}
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// closedWindowDays is how far back the closed column reaches at start and
+// how much further each extension goes.
+const closedWindowDays = 30
+
+// initialClosedSince is the first closed window: the last closedWindowDays
+// days, from local midnight.
+func initialClosedSince(now time.Time) time.Time {
+	return startOfDay(now).AddDate(0, 0, -closedWindowDays)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.Local)
+}
+
+// searchOrFilterActive reports whether the board is narrowed by a search
+// or a filter. Those always run over the whole board, so the closed
+// window is lifted meanwhile.
+func (m model) searchOrFilterActive() bool {
+	return m.SearchQuery != "" || !m.Filter.IsEmpty()
+}
+
+// loadWindow is the window the next board load asks for.
+func (m model) loadWindow() IssueWindow {
+	if m.searchOrFilterActive() {
+		return IssueWindow{}
+	}
+	return IssueWindow{ClosedSince: m.ClosedSince}
+}
+
+// nextClosedWindow is the window one extension of the closed column loads.
+func (m model) nextClosedWindow() IssueWindow {
+	return IssueWindow{ClosedSince: m.ClosedSince.AddDate(0, 0, -closedWindowDays)}
+}
+
+// issueClosedTime is when the issue was closed, or last updated when bd
+// did not record a close time.
+func issueClosedTime(issue Issue) (time.Time, bool) {
+	if t, ok := parseDeferTime(issue.ClosedAt); ok {
+		return t, true
+	}
+	return parseDeferTime(issue.UpdatedAt)
+}
+
+// closedInWindow reports whether a closed issue belongs in the closed
+// column. Issues without a usable timestamp are always shown.
+func (m model) closedInWindow(issue Issue) bool {
+	if m.ClosedSince.IsZero() || m.searchOrFilterActive() {
+		return true
+	}
+	t, ok := issueClosedTime(issue)
+	return !ok || !t.Before(m.ClosedSince)
+}
+
+// atClosedColumnEnd reports whether the selection is on the last row of
+// the closed column, where moving down loads older closed issues.
+func (m model) atClosedColumnEnd() bool {
+	key := m.currentStatus()
+	if m.columnStatus(key) != StatusClosed {
+		return false
+	}
+	col := m.Columns[key]
+	return len(col) == 0 || m.SelectedIdx[key] >= len(col)-1
+}
+
+// extendClosedWindow reaches the closed column closedWindowDays further
+// back. With the whole board loaded it only re-filters, skipping empty
+// stretches and lifting the window once nothing older is left; otherwise
+// it uses the prefetched window or loads it from bd.
+func (m *model) extendClosedWindow() tea.Cmd {
+	if m.ClosedSince.IsZero() || m.searchOrFilterActive() {
+		return nil
+	}
+	next := m.nextClosedWindow()
+	if m.DatasetComplete {
+		var newest time.Time
+		for _, issue := range m.Issues {
+			if issue.Display != StatusClosed {
+				continue
+			}
+			if t, ok := issueClosedTime(issue); ok && t.Before(m.ClosedSince) && t.After(newest) {
+				newest = t
+			}
+		}
+		switch {
+		case newest.IsZero():
+			m.ClosedSince = time.Time{}
+		case newest.Before(next.ClosedSince):
+			m.ClosedSince = startOfDay(newest)
+		default:
+			m.ClosedSince = next.ClosedSince
+		}
+		selectedID := m.currentIssueID()
+		m.computeColumns()
+		m.normalizeSelectionBounds()
+		if selectedID != "" {
+			m.selectIssueByID(selectedID)
+		}
+		return nil
+	}
+
+	m.ClosedSince = next.ClosedSince
+	if p := m.ClosedPrefetch; p != nil && p.window == next {
+		m.reconcileOps(p.started)
+		m.applyLoadedIssues(p.Issues, p.hash, p.complete)
+		return m.prefetchClosedWindowCmd()
+	}
+	return m.loadCmd("window")
+}
+
+// prefetchClosedWindowCmd loads the next closed window in the background
+// so extending the closed column does not wait on bd. Nothing is
+// prefetched when the whole board is loaded already.
+func (m model) prefetchClosedWindowCmd() tea.Cmd {
+	if m.DatasetComplete || m.ClosedSince.IsZero() || m.searchOrFilterActive() || m.Client == nil {
+		return nil
+	}
+	return m.loadWindowCmd("prefetch", m.nextClosedWindow())
+}
+
+// handleClosedPrefetch keeps a prefetched window while it is still the
+// next one. applyLoadedIssues drops it again on any newer data.
+func (m model) handleClosedPrefetch(msg loadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil && !m.DatasetComplete && msg.window == m.nextClosedWindow() {
+		m.ClosedPrefetch = &msg
+	}
+	return m, nil
+}
+
+// fullDatasetCmd loads the whole board when a search or filter starts on
+// a windowed load, so they match closed issues outside the window too.
+func (m *model) fullDatasetCmd() tea.Cmd {
+	if m.DatasetComplete || m.LoadingFull || !m.searchOrFilterActive() || m.Client == nil {
+		return nil
+	}
+	m.LoadingFull = true
+	return m.loadCmd("search")
+}
+
+// revealClosedIssue widens the closed window so the loaded issue id is
+// shown, for jumps to a closed issue older than the window.
+func (m *model) revealClosedIssue(id string) bool {
+	issue, ok := m.ByID[id]
+	if !ok || m.ClosedSince.IsZero() {
+		return false
+	}
+	t, ok := issueClosedTime(*issue)
+	if !ok || !t.Before(m.ClosedSince) {
+		return false
+	}
+	m.ClosedSince = startOfDay(t)
+	m.computeColumns()
+	m.normalizeSelectionBounds()
+	return m.selectIssueByID(id)
+}
+
+// closedWindowLabel describes the closed window for the footer, or "" when
+// every closed issue is shown.
+func (m model) closedWindowLabel() string {
+	if m.ClosedSince.IsZero() || m.searchOrFilterActive() {
+		return ""
+	}
+	label := "closed since " + m.ClosedSince.Format("2006-01-02")
+	if m.DatasetComplete {
+		older := 0
+		for _, issue := range m.Issues {
+			if issue.Display == StatusClosed && !m.closedInWindow(issue) {
+				older++
+			}
+		}
+		if older == 0 {
+			return label
+		}
+		return label + fmt.Sprintf(" (%d older, ↓ at end loads more)", older)
+	}
+	return label + " (↓ at end loads more)"
+}
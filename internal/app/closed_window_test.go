@@ -0,0 +1,129 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func closedWindowModel(since time.Time, issues ...Issue) model {
+	m := model{
+		ClosedSince:     since,
+		DatasetComplete: true,
+		Filter:          Filter{Status: "any", Priority: "any", Type: "any", Run: "any"},
+		SelectedIdx:     map[Status]int{},
+		ScrollOffset:    map[Status]int{},
+		SelectedCol:     3,
+	}
+	m.applyLoadedIssues(issues, "h", true)
+	return m
+}
+
+func closedIssue(id string, closedAt time.Time) Issue {
+	return Issue{ID: id, Title: id, Status: StatusClosed, Display: StatusClosed, ClosedAt: closedAt.Format(time.RFC3339)}
+}
+
+func closedColumnIDs(m model) []string {
+	var ids []string
+	for _, issue := range m.Columns[StatusClosed] {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestClosedWindowHidesOlderIssuesUntilExtended(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	m := closedWindowModel(since,
+		closedIssue("recent", since.AddDate(0, 0, 2)),
+		closedIssue("older", since.AddDate(0, 0, -10)),
+		closedIssue("ancient", since.AddDate(-1, 0, 0)),
+	)
+	if got := closedColumnIDs(m); len(got) != 1 || got[0] != "recent" {
+		t.Fatalf("closed column = %v, want only recent", got)
+	}
+	if label := m.closedWindowLabel(); !strings.Contains(label, "2 older") {
+		t.Fatalf("label = %q", label)
+	}
+
+	m.SearchQuery = "ancient"
+	m.computeColumns()
+	if got := closedColumnIDs(m); len(got) != 1 || got[0] != "ancient" {
+		t.Fatalf("search should reach past the window, got %v", got)
+	}
+	m.SearchQuery = ""
+	m.computeColumns()
+
+	if !m.atClosedColumnEnd() {
+		t.Fatal("selection should be at the end of the closed column")
+	}
+	if cmd := m.extendClosedWindow(); cmd != nil {
+		t.Fatal("a complete board should extend without loading")
+	}
+	if got := closedColumnIDs(m); len(got) != 2 {
+		t.Fatalf("after one extension = %v, want recent and older", got)
+	}
+	m.extendClosedWindow()
+	if got := closedColumnIDs(m); len(got) != 3 {
+		t.Fatalf("extension should skip the empty stretch, got %v", got)
+	}
+	m.extendClosedWindow()
+	if !m.ClosedSince.IsZero() || m.closedWindowLabel() != "" {
+		t.Fatalf("window should lift once nothing older is left, since = %v", m.ClosedSince)
+	}
+}
+
+func TestRevealClosedIssueWidensWindow(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	m := closedWindowModel(since,
+		closedIssue("recent", since.AddDate(0, 0, 2)),
+		closedIssue("parent", since.AddDate(0, -6, 0)),
+	)
+	if !m.revealClosedIssue("parent") {
+		t.Fatal("reveal should select the parent")
+	}
+	if got := m.currentIssueID(); got != "parent" {
+		t.Fatalf("selected %q, want parent", got)
+	}
+}
+
+func TestWindowedLoadUsesBdAndPrefetch(t *testing.T) {
+	marker := fakeBdOnPath(t, `[]`)
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	m := closedWindowModel(since)
+	m.Client = NewBdClient(t.TempDir())
+	m.DatasetComplete = false
+
+	msg := m.prefetchClosedWindowCmd()()
+	prefetch, ok := msg.(loadedMsg)
+	if !ok || prefetch.complete || prefetch.window != m.nextClosedWindow() {
+		t.Fatalf("prefetch = %+v", msg)
+	}
+	calls, err := os.ReadFile(marker)
+	if err != nil {
+		t.Fatalf("bd was not run: %v", err)
+	}
+	if !strings.Contains(string(calls), "--status closed --closed-after 2026-01-30 --limit 0") {
+		t.Fatalf("bd calls = %q", calls)
+	}
+
+	prefetch.Issues = []Issue{closedIssue("older", since.AddDate(0, 0, -5))}
+	next, _ := m.Update(prefetch)
+	m = next.(model)
+	if m.ClosedPrefetch == nil {
+		t.Fatal("prefetched window was not kept")
+	}
+	_ = os.Remove(marker)
+	m.extendClosedWindow()
+	if got := closedColumnIDs(m); len(got) != 1 || got[0] != "older" {
+		t.Fatalf("extension should use the prefetch, got %v", got)
+	}
+	if !m.ClosedSince.Equal(since.AddDate(0, 0, -closedWindowDays)) {
+		t.Fatalf("ClosedSince = %v", m.ClosedSince)
+	}
+
+	m.SearchQuery = "x"
+	if cmd := m.fullDatasetCmd(); cmd == nil || !m.LoadingFull {
+		t.Fatal("search on a windowed board should load the full board")
+	}
+}
@@ -89,15 +89,7 @@ func (m model) openFormInEditorCmd() (tea.Cmd, error) {
 		return nil, fmt.Errorf("close temp editor file: %w", err)
 	}
 
-	editor := strings.TrimSpace(os.Getenv("VISUAL"))
-	if editor == "" {
-		editor = strings.TrimSpace(os.Getenv("EDITOR"))
-	}
-	if editor == "" {
-		editor = "vi"
-	}
-
-	cmd := buildEditorCommand(editor, path)
+	cmd := buildEditorCommand(resolveEditor(), path)
 
 	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
 		defer os.Remove(path)
@@ -134,6 +126,18 @@ func (m model) openFormInEditorCmd() (tea.Cmd, error) {
 	}), nil
 }
 
+// resolveEditor is $VISUAL, then $EDITOR, then vi.
+func resolveEditor() string {
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	return editor
+}
+
 func buildEditorCommand(editor string, path string) *exec.Cmd {
 	if strings.Contains(editor, " ") {
 		quoted := "'" + strings.ReplaceAll(path, "'", "'\"'\"'") + "'"
@@ -407,6 +407,9 @@ func newFilterForm(base Filter) *FilterForm {
 	if base.Type == "" {
 		base.Type = "any"
 	}
+	if base.Run == "" {
+		base.Run = "any"
+	}
 
 	f := &FilterForm{
 		Cursor:   0,
@@ -415,6 +418,7 @@ func newFilterForm(base Filter) *FilterForm {
 		Status:   base.Status,
 		Priority: base.Priority,
 		Type:     base.Type,
+		Run:      base.Run,
 		Input:    in,
 	}
 	f.loadInput()
@@ -422,7 +426,7 @@ func newFilterForm(base Filter) *FilterForm {
 }
 
 func (f *FilterForm) fields() []string {
-	return []string{"status", "priority", "type"}
+	return []string{"status", "priority", "type", "run"}
 }
 
 func (f *FilterForm) currentField() string {
@@ -525,9 +529,31 @@ func (f *FilterForm) cycleEnum(delta int) {
 			idx = 0
 		}
 		f.Type = opts[idx]
+	case "run":
+		opts := runFilterOptions()
+		idx := 0
+		for i, v := range opts {
+			if v == f.Run {
+				idx = i
+				break
+			}
+		}
+		idx += delta
+		if idx < 0 {
+			idx = len(opts) - 1
+		}
+		if idx >= len(opts) {
+			idx = 0
+		}
+		f.Run = opts[idx]
 	}
 }
 
+// runFilterOptions are the values of the "run" filter field.
+func runFilterOptions() []string {
+	return []string{"any", "active", "attention"}
+}
+
 func (f *FilterForm) toFilter() Filter {
 	f.saveInput()
 	return Filter{
@@ -536,6 +562,7 @@ func (f *FilterForm) toFilter() Filter {
 		Status:   strings.TrimSpace(f.Status),
 		Priority: strings.TrimSpace(f.Priority),
 		Type:     strings.TrimSpace(f.Type),
+		Run:      strings.TrimSpace(f.Run),
 	}
 }
 
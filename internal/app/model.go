@@ -28,6 +28,36 @@ type Model struct {
 	TotalIssues int
 	LoadedLimit int
 
+	// ClosedSince bounds the closed column to issues closed on or after it
+	// while no search or filter is active; zero shows every closed issue.
+	// Without issues.jsonl only that window is loaded from bd.
+	ClosedSince     time.Time
+	DatasetComplete bool       // the loaded issues are the whole board
+	LoadingFull     bool       // a load of the whole board is in flight
+	ClosedPrefetch  *loadedMsg // the next closed window, loaded ahead
+
+	// BaseIssues is the board as bd last reported it; Issues is BaseIssues
+	// with the queued PendingOps applied on top.
+	BaseIssues []Issue
+	PendingOps []pendingOp
+	OpSeq      int
+	OpRunning  bool
+
+	// UndoStack holds the operations u reverts, latest last; RedoStack
+	// the ones Ctrl+R applies again.
+	UndoStack     []undoEntry
+	RedoStack     []undoEntry
+	HistoryScroll int
+
+	// Marked is the multi-selection bulk actions apply to. While V is
+	// active the range from VisualAnchor to the cursor in VisualStatus
+	// counts as marked too.
+	Marked       map[string]bool
+	VisualAnchor string
+	VisualStatus Status
+
+	Drag *DragState
+
 	Columns      map[Status][]Issue
 	ColumnDepths map[Status]map[string]int
 	SelectedCol  int
@@ -35,6 +65,17 @@ type Model struct {
 	ScrollOffset map[Status]int
 	Collapsed    map[string]bool
 
+	// Layout is the saved board layout; BoardDefs are its checked columns
+	// and Board the cells computeColumns laid out from them.
+	Layout    BoardLayout
+	BoardDefs []columnDef
+	Board     []boardColumn
+
+	// Views are the saved search queries; ViewsIndex is the cursor of the
+	// views picker.
+	Views      []SavedView
+	ViewsIndex int
+
 	ShowDetails    bool
 	DetailsScroll  int
 	DetailsIssueID string
@@ -63,6 +104,9 @@ type Model struct {
 	WorkflowPicker  *WorkflowPickerState
 	Runs            *RunsTabState
 	Daemon          *daemon.Client // cached gRPC client for the Runs tab; nil when not yet opened.
+	// RunBadges maps task ids to their run state for the board cards,
+	// refreshed by pollRunStateCmd while a daemon is running.
+	RunBadges map[string]RunBadge
 
 	DepList *DepListState
 
@@ -149,24 +193,34 @@ func newModel(cfg Config) (model, error) {
 			Status:   "any",
 			Priority: "any",
 			Type:     "any",
+			Run:      "any",
 		},
-		Loading:   true,
-		Now:       time.Now(),
-		Keymap:    defaultKeymap(),
-		Styles:    newStyles(),
-		Plugins:   newPluginRegistry(cfg),
-		UIFocused: true,
+		ClosedSince: initialClosedSince(time.Now()),
+		Loading:     true,
+		Now:         time.Now(),
+		Keymap:      defaultKeymap(),
+		Styles:      newStyles(),
+		Plugins:     newPluginRegistry(cfg),
+		UIFocused:   true,
 	}
 
 	if mode, err := m.Client.GetSortMode(); err == nil {
 		m.SortMode = mode
 	}
+	if layout, err := m.Client.GetBoardLayout(); err == nil {
+		if err := m.setLayout(layout); err != nil {
+			m.setToast("warning", "board layout ignored: "+err.Error())
+		}
+	}
+	if views, err := m.Client.GetViews(); err == nil {
+		m.Views = views
+	}
 
 	return m, nil
 }
 
 func (m model) Init() tea.Cmd {
-	cmds := []tea.Cmd{m.loadCmd("init")}
+	cmds := []tea.Cmd{m.loadCmd("init"), m.loadRunStateCmd()}
 	if !m.Cfg.NoWatch {
 		cmds = append(cmds, tickCmd())
 		cmds = append(cmds, watchBeadsChangesCmd(m.BeadsDir))
@@ -206,16 +260,17 @@ func (m *model) clearTransientUI() {
 	m.ResumeDescriptionScroll = 0
 }
 
-func (m *model) applyLoadedIssues(issues []Issue, hash string) {
+func (m *model) applyLoadedIssues(issues []Issue, hash string, complete bool) {
 	selectedID := m.currentIssueID()
 
-	m.Issues = issues
-	m.TotalIssues = 0 // bd list has no total in local CLI mode.
-	m.LoadedLimit = issueLoadPageLimit
-	m.ByID = make(map[string]*Issue, len(issues))
-	for i := range m.Issues {
-		m.ByID[m.Issues[i].ID] = &m.Issues[i]
+	m.BaseIssues = issues
+	m.applyPendingOps()
+	m.TotalIssues = 0 // a windowed bd load does not know the total.
+	if complete {
+		m.TotalIssues = len(issues)
 	}
+	m.DatasetComplete = complete
+	m.ClosedPrefetch = nil
 	m.LastHash = hash
 
 	m.computeColumns()
@@ -230,39 +285,70 @@ func (m *model) applyLoadedIssues(issues []Issue, hash string) {
 }
 
 func (m *model) computeColumns() {
-	next := map[Status][]Issue{
-		StatusOpen:       {},
-		StatusInProgress: {},
-		StatusBlocked:    {},
-		StatusClosed:     {},
-	}
-	depths := map[Status]map[string]int{
-		StatusOpen:       {},
-		StatusInProgress: {},
-		StatusBlocked:    {},
-		StatusClosed:     {},
-	}
+	defs := m.columnDefs()
+	mode := m.Layout.Swimlanes
 
+	type placed struct {
+		issue Issue
+		def   int
+	}
+	search := m.searchFilter()
+	shown := make([]placed, 0, len(m.Issues))
+	seenLanes := map[string]bool{}
 	for _, issue := range m.Issues {
 		if issue.Display == StatusTombstone {
 			continue
 		}
+		if issue.Display == StatusClosed && !m.closedInWindow(issue) {
+			continue
+		}
 		if !m.matchesFilter(issue) {
 			continue
 		}
-		if !m.matchesSearch(issue) {
+		if !search.matches(issue) {
+			continue
+		}
+		def := columnDefFor(defs, issue)
+		if def < 0 {
 			continue
 		}
-		next[issue.Display] = append(next[issue.Display], issue)
+		shown = append(shown, placed{issue: issue, def: def})
+		for _, lane := range issueLanes(issue, mode) {
+			seenLanes[lane] = true
+		}
+	}
+
+	lanes := []string{""}
+	if mode != SwimlaneNone {
+		lanes = sortedLanes(seenLanes)
+	}
+	board := make([]boardColumn, 0, len(lanes)*len(defs))
+	next := make(map[Status][]Issue, len(lanes)*len(defs))
+	depths := make(map[Status]map[string]int, len(lanes)*len(defs))
+	for _, lane := range lanes {
+		for i, def := range defs {
+			key := cellKey(def, lane, mode)
+			board = append(board, boardColumn{key: key, def: i, lane: lane})
+			next[key] = []Issue{}
+			depths[key] = map[string]int{}
+		}
 	}
 
-	for _, status := range statusOrder {
-		sortIssuesByMode(next[status], m.SortMode)
-		ordered, depthMap := orderColumnAsTreeWithCollapsed(next[status], m.Collapsed)
-		next[status] = ordered
-		depths[status] = depthMap
+	for _, p := range shown {
+		for _, lane := range issueLanes(p.issue, mode) {
+			key := cellKey(defs[p.def], lane, mode)
+			next[key] = append(next[key], p.issue)
+		}
 	}
 
+	for _, col := range board {
+		sortIssuesByMode(next[col.key], m.SortMode)
+		ordered, depthMap := orderColumnAsTreeWithCollapsed(next[col.key], m.Collapsed)
+		next[col.key] = ordered
+		depths[col.key] = depthMap
+	}
+
+	m.Board = board
 	m.Columns = next
 	m.ColumnDepths = depths
 }
@@ -363,7 +449,9 @@ func orderColumnAsTreeWithCollapsed(input []Issue, collapsed map[string]bool) ([
 }
 
 func (m *model) normalizeSelectionBounds() {
-	for _, status := range statusOrder {
+	keys := m.columnKeys()
+	m.SelectedCol = min(max(0, m.SelectedCol), len(keys)-1)
+	for _, status := range keys {
 		col := m.Columns[status]
 		idx := m.SelectedIdx[status]
 		if idx >= len(col) {
@@ -384,25 +472,14 @@ func (m *model) normalizeSelectionBounds() {
 	}
 }
 
-func (m model) matchesSearch(issue Issue) bool {
-	q := strings.TrimSpace(strings.ToLower(m.SearchQuery))
-	if q == "" {
-		return true
-	}
-
-	inLabels := false
-	for _, label := range issue.Labels {
-		if strings.Contains(strings.ToLower(label), q) {
-			inLabels = true
-			break
-		}
+// searchFilter parses the search query. While it does not parse, its
+// words are matched as plain text.
+func (m model) searchFilter() issueQuery {
+	q, err := parseQuery(m.SearchQuery)
+	if err != nil {
+		return textQuery(m.SearchQuery)
 	}
-
-	return strings.Contains(strings.ToLower(issue.ID), q) ||
-		strings.Contains(strings.ToLower(issue.Title), q) ||
-		strings.Contains(strings.ToLower(issue.Description), q) ||
-		strings.Contains(strings.ToLower(issue.Assignee), q) ||
-		inLabels
+	return q
 }
 
 func (m model) matchesFilter(issue Issue) bool {
@@ -438,11 +515,26 @@ func (m model) matchesFilter(issue Issue) bool {
 		return false
 	}
 
+	if m.Filter.Run != "" && m.Filter.Run != "any" {
+		badge, ok := m.RunBadges[issue.ID]
+		switch {
+		case !ok:
+			return false
+		case m.Filter.Run == "active" && !badge.Active():
+			return false
+		case m.Filter.Run == "attention" && !badge.NeedsAttention():
+			return false
+		}
+	}
+
 	return true
 }
 
+// currentStatus is the key of the selected board cell, the status itself
+// for the default columns.
 func (m model) currentStatus() Status {
-	return statusOrder[m.SelectedCol]
+	keys := m.columnKeys()
+	return keys[min(max(0, m.SelectedCol), len(keys)-1)]
 }
 
 func (m model) currentColumn() []Issue {
@@ -475,7 +567,7 @@ func (m model) currentIssueID() string {
 }
 
 func (m *model) selectIssueByID(id string) bool {
-	for colIdx, status := range statusOrder {
+	for colIdx, status := range m.columnKeys() {
 		col := m.Columns[status]
 		for idx, issue := range col {
 			if strings.EqualFold(issue.ID, id) {
@@ -512,9 +604,9 @@ func (m *model) moveSelection(delta int) {
 func (m *model) moveColumn(delta int) {
 	next := m.SelectedCol + delta
 	if next < 0 {
-		next = len(statusOrder) - 1
+		next = len(m.columnKeys()) - 1
 	}
-	if next >= len(statusOrder) {
+	if next >= len(m.columnKeys()) {
 		next = 0
 	}
 	m.SelectedCol = next
@@ -714,7 +806,7 @@ func (m *model) clampDetailsScroll() {
 }
 
 func (m *model) ensureSelectionVisible(status Status) {
-	itemsPerPage := m.boardInnerHeight() - 3
+	itemsPerPage := m.columnInnerHeight() - 3
 	if itemsPerPage < 1 {
 		itemsPerPage = 1
 	}
@@ -759,15 +851,19 @@ func (m model) selectedVisibleRowIndex(status Status) int {
 }
 
 func (m model) loadCmd(source string) tea.Cmd {
+	return m.loadWindowCmd(source, m.loadWindow())
+}
+
+func (m model) loadWindowCmd(source string, window IssueWindow) tea.Cmd {
 	return func() tea.Msg {
 		logger.Info("DBG loadCmd: start source=%s", source)
 		t0 := time.Now()
-		issues, hash, err := m.Client.ListIssues()
+		issues, hash, complete, err := m.Client.LoadIssues(window)
 		logger.Info("DBG loadCmd: done source=%s dur=%s n=%d err=%v", source, time.Since(t0), len(issues), err)
 		if err != nil {
 			logger.Error("load issues failed (source=%s): %v", source, err)
 		}
-		return loadedMsg{Issues: issues, hash: hash, err: err, source: source}
+		return loadedMsg{Issues: issues, hash: hash, err: err, source: source, window: window, complete: complete, started: t0}
 	}
 }
 
@@ -0,0 +1,249 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"bdtui/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// boardOp is a mutation of issue id. apply previews it on the issue; run
+// sends it to bd.
+type boardOp struct {
+	label string
+	id    string
+	apply func(*Issue) // nil for ops the board cannot preview
+	run   func(*BdClient) error
+}
+
+// pendingOp is one boardOp in the op queue. Ops run one at a time in
+// queue order. A finished op stays queued until a load started after it
+// replaces the board, so a reload that raced it does not flash the old
+// state back.
+type pendingOp struct {
+	boardOp
+	seq    int
+	origin opOrigin
+	entry  *undoEntry // history entry the op records or replays; nil when not undoable
+	batch  *opBatch   // bulk operation the op belongs to, if any
+	done   bool
+	doneAt time.Time
+}
+
+// opDoneMsg reports that bd finished the queued op seq.
+type opDoneMsg struct {
+	seq int
+	err error
+}
+
+// enqueueOp queues a mutation of issue id, previews it on the board and
+// starts it when no other op is running.
+func (m *model) enqueueOp(label, id string, apply func(*Issue), run func(*BdClient) error) tea.Cmd {
+	return m.queueOp(boardOp{label: label, id: id, apply: apply, run: run}, opNew, nil, nil)
+}
+
+// enqueueUndoableOp queues op and records undo as its inverse once bd has
+// applied it.
+func (m *model) enqueueUndoableOp(op, undo boardOp) tea.Cmd {
+	return m.queueOp(op, opNew, &undoEntry{label: op.label, do: op, undo: undo}, nil)
+}
+
+// queueOp adds op to the queue. A new op of a bulk operation also files
+// its history entry under batch, so u undoes the bulk operation at once.
+func (m *model) queueOp(op boardOp, origin opOrigin, entry *undoEntry, batch *opBatch) tea.Cmd {
+	if m.BaseIssues == nil {
+		m.BaseIssues = m.Issues
+	}
+	if batch != nil {
+		batch.total++
+		if entry != nil && origin == opNew {
+			entry.batch = batch
+		}
+	}
+	m.OpSeq++
+	m.PendingOps = append(m.PendingOps, pendingOp{boardOp: op, seq: m.OpSeq, origin: origin, entry: entry, batch: batch})
+	m.refreshIssues()
+	return m.runNextOpCmd()
+}
+
+// runNextOpCmd starts the oldest op that has not run yet.
+func (m *model) runNextOpCmd() tea.Cmd {
+	if m.OpRunning {
+		return nil
+	}
+	for _, op := range m.PendingOps {
+		if op.done {
+			continue
+		}
+		m.OpRunning = true
+		client := m.Client
+		return func() tea.Msg {
+			err := op.run(client)
+			if err != nil {
+				logger.Error("operation failed (%s): %v", op.label, err)
+			}
+			return opDoneMsg{seq: op.seq, err: err}
+		}
+	}
+	return nil
+}
+
+// handleOpDone rolls a failed op back or marks it done, then starts the
+// next one. Once the queue has drained the board is reloaded.
+func (m model) handleOpDone(msg opDoneMsg) (tea.Model, tea.Cmd) {
+	m.OpRunning = false
+	for i, op := range m.PendingOps {
+		if op.seq != msg.seq {
+			continue
+		}
+		if msg.err != nil {
+			m.PendingOps = append(m.PendingOps[:i:i], m.PendingOps[i+1:]...)
+			if op.batch == nil {
+				m.setToast("error", fmt.Sprintf("%v (rolled back)", msg.err))
+			}
+		} else {
+			m.PendingOps[i].done = true
+			m.PendingOps[i].doneAt = time.Now()
+			if op.batch == nil && op.label != "" {
+				m.setToast("success", op.label)
+			}
+		}
+		if op.batch != nil && op.batch.record(op.id, msg.err) {
+			m.setToast(op.batch.summary())
+		}
+		m.recordHistory(op, msg.err == nil)
+		m.refreshIssues()
+		break
+	}
+	if cmd := m.runNextOpCmd(); cmd != nil {
+		return m, cmd
+	}
+	return m, m.loadCmd("mutation")
+}
+
+// reconcileOps drops finished ops that a load started at started already
+// reflects.
+func (m *model) reconcileOps(started time.Time) {
+	kept := m.PendingOps[:0]
+	for _, op := range m.PendingOps {
+		if op.done && op.doneAt.Before(started) {
+			continue
+		}
+		kept = append(kept, op)
+	}
+	m.PendingOps = kept
+}
+
+// applyPendingOps rebuilds Issues from BaseIssues with every queued op
+// applied on top, marking the issues with an op still on its way.
+func (m *model) applyPendingOps() {
+	issues := m.BaseIssues
+	if len(m.PendingOps) > 0 {
+		issues = append([]Issue(nil), m.BaseIssues...)
+		index := make(map[string]int, len(issues))
+		for i := range issues {
+			index[issues[i].ID] = i
+		}
+		for _, op := range m.PendingOps {
+			i, ok := index[op.id]
+			if !ok {
+				continue
+			}
+			if op.apply != nil {
+				op.apply(&issues[i])
+			}
+			if !op.done {
+				issues[i].Pending = true
+			}
+		}
+	}
+	m.Issues = issues
+	m.ByID = make(map[string]*Issue, len(issues))
+	for i := range m.Issues {
+		m.ByID[m.Issues[i].ID] = &m.Issues[i]
+	}
+}
+
+// refreshIssues re-applies the op queue and redraws the columns, keeping
+// the selection.
+func (m *model) refreshIssues() {
+	selectedID := m.currentIssueID()
+	m.applyPendingOps()
+	if m.SelectedIdx == nil || m.ScrollOffset == nil {
+		return // the board has not been laid out yet
+	}
+	m.computeColumns()
+	m.normalizeSelectionBounds()
+	if selectedID != "" {
+		m.selectIssueByID(selectedID)
+	}
+}
+
+// setIssueStatus previews a status change the way bd would report it:
+// open issues with open blockers show as blocked, and closing stamps
+// ClosedAt so the issue stays inside the closed window.
+func setIssueStatus(issue *Issue, status Status) {
+	issue.Status = status
+	issue.Display = status
+	if status == StatusOpen && len(issue.BlockedBy) > 0 {
+		issue.Display = StatusBlocked
+	}
+	switch {
+	case status != StatusClosed:
+		issue.ClosedAt = ""
+	case issue.ClosedAt == "":
+		issue.ClosedAt = time.Now().Format(time.RFC3339)
+	}
+}
+
+// applyUpdateParams previews an UpdateParams on the issue.
+func applyUpdateParams(p UpdateParams) func(*Issue) {
+	return func(issue *Issue) {
+		if p.Title != nil {
+			issue.Title = *p.Title
+		}
+		if p.Description != nil {
+			issue.Description = *p.Description
+		}
+		if p.Notes != nil {
+			issue.Notes = *p.Notes
+		}
+		if p.Status != nil {
+			setIssueStatus(issue, *p.Status)
+		}
+		if p.Priority != nil {
+			issue.Priority = *p.Priority
+		}
+		if p.IssueType != nil {
+			issue.IssueType = *p.IssueType
+		}
+		if p.Assignee != nil {
+			issue.Assignee = *p.Assignee
+		}
+		if p.Labels != nil {
+			issue.Labels = append([]string(nil), (*p.Labels)...)
+		}
+		if p.Parent != nil {
+			issue.Parent = *p.Parent
+		}
+	}
+}
+
+func updateBoardOp(label string, p UpdateParams) boardOp {
+	return boardOp{label: label, id: p.ID, apply: applyUpdateParams(p), run: func(c *BdClient) error {
+		return c.UpdateIssue(p)
+	}}
+}
+
+// updateOp queues an UpdateIssue and previews it. It is undone by an
+// update back to the values the issue had when it was queued.
+func (m *model) updateOp(label string, p UpdateParams) tea.Cmd {
+	op := updateBoardOp(label, p)
+	issue := m.ByID[p.ID]
+	if issue == nil {
+		return m.queueOp(op, opNew, nil, nil)
+	}
+	return m.enqueueUndoableOp(op, updateBoardOp(label, inverseUpdateParams(*issue, p)))
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func opQueueModel(issues ...Issue) model {
+	m := model{
+		Mode:         ModeBoard,
+		Filter:       Filter{Status: "any", Priority: "any", Type: "any", Run: "any"},
+		SelectedIdx:  map[Status]int{},
+		ScrollOffset: map[Status]int{},
+	}
+	m.applyLoadedIssues(issues, "h", true)
+	return m
+}
+
+func TestOpQueueAppliesOptimisticallyAndRunsSerially(t *testing.T) {
+	m := opQueueModel(Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen, Priority: 2})
+
+	next, first := m.handleBoardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = next.(model)
+	next, second := m.handleBoardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = next.(model)
+
+	if first == nil || second != nil {
+		t.Fatal("only the first op should start while it is running")
+	}
+	if got := m.ByID["a"]; got.Priority != 4 || !got.Pending {
+		t.Fatalf("issue after two presses = %+v, want P4 pending", got)
+	}
+	if m.BaseIssues[0].Priority != 2 {
+		t.Fatal("the optimistic change leaked into the loaded issues")
+	}
+
+	next, cmd := m.handleOpDone(opDoneMsg{seq: 1})
+	m = next.(model)
+	if cmd == nil || !m.OpRunning {
+		t.Fatal("the second op should start once the first is done")
+	}
+	next, _ = m.handleOpDone(opDoneMsg{seq: 2, err: errors.New("bd rejected")})
+	m = next.(model)
+	if got := m.ByID["a"]; got.Priority != 3 || got.Pending {
+		t.Fatalf("after rollback = %+v, want P3 not pending", got)
+	}
+	if m.ToastKind != "error" {
+		t.Fatalf("expected an error toast, got %s %q", m.ToastKind, m.Toast)
+	}
+
+	// A reload that started before the op finished keeps the preview; a
+	// later one replaces it.
+	stale := Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen, Priority: 2}
+	m.reconcileOps(m.PendingOps[0].doneAt.Add(-time.Second))
+	m.applyLoadedIssues([]Issue{stale}, "h2", true)
+	if m.ByID["a"].Priority != 3 {
+		t.Fatal("a racing reload undid a finished op")
+	}
+	m.reconcileOps(time.Now())
+	m.applyLoadedIssues([]Issue{stale}, "h3", true)
+	if len(m.PendingOps) != 0 || m.ByID["a"].Priority != 2 {
+		t.Fatalf("reload after the op should reconcile, ops = %d", len(m.PendingOps))
+	}
+}
+
+func TestOpQueuePreviewsCloseAndDelete(t *testing.T) {
+	m := opQueueModel(
+		Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen},
+		Issue{ID: "b", Title: "b", Status: StatusOpen, Display: StatusOpen},
+	)
+	m.submitPrompt("a", PromptCloseReason, "done")
+	if got := m.ByID["a"]; got.Display != StatusClosed || got.ClosedAt == "" {
+		t.Fatalf("close preview = %+v", got)
+	}
+	if len(m.Columns[StatusClosed]) != 1 {
+		t.Fatalf("closed column = %+v", m.Columns[StatusClosed])
+	}
+
+	m.ConfirmDelete = &ConfirmDelete{IssueID: "b"}
+	next, _ := m.handleDeleteConfirmKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	if len(m.Columns[StatusOpen]) != 0 {
+		t.Fatalf("deleted issue still on the board: %+v", m.Columns[StatusOpen])
+	}
+	if len(m.PendingOps) != 2 {
+		t.Fatalf("queued %d ops, want 2", len(m.PendingOps))
+	}
+}
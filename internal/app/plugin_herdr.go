@@ -1,15 +1,14 @@
 package app
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os/exec"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
+
+	"bdtui/internal/adapters/herdr"
 )
 
 type MuxTarget struct {
@@ -44,28 +43,7 @@ func (t MuxTarget) Label() string {
 	return strings.Join(parts, " | ")
 }
 
-type herdrRunner interface {
-	Run(args ...string) (string, error)
-}
-
-type shellHerdrRunner struct{}
-
-func (shellHerdrRunner) Run(args ...string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
-	defer cancel()
-
-	cmdArgs := append([]string{"herdr"}, args...)
-	cmd := exec.CommandContext(ctx, cmdArgs[0], cmdArgs[1:]...)
-	out, err := cmd.CombinedOutput()
-	text := strings.TrimSpace(string(out))
-	if err != nil {
-		if text == "" {
-			return "", fmt.Errorf("herdr %s failed: %w", strings.Join(args, " "), err)
-		}
-		return "", fmt.Errorf("herdr %s failed: %s", strings.Join(args, " "), text)
-	}
-	return text, nil
-}
+type herdrRunner = herdr.Runner
 
 type muxBackend interface {
 	Enabled() bool
@@ -85,7 +63,7 @@ type HerdrPlugin struct {
 
 func newHerdrPlugin(enabled bool, runner herdrRunner) *HerdrPlugin {
 	if runner == nil {
-		runner = shellHerdrRunner{}
+		runner = herdr.ShellRunner{}
 	}
 	return &HerdrPlugin{enabled: enabled, runner: runner}
 }
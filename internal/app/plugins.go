@@ -46,7 +46,7 @@ func newPluginRegistry(cfg Config) PluginRegistry {
 
 	return PluginRegistry{
 		Toggles:     clonePluginToggles(toggles),
-		HerdrPlugin: newHerdrPlugin(toggles.enabled("herdr"), shellHerdrRunner{}),
+		HerdrPlugin: newHerdrPlugin(toggles.enabled("herdr"), nil),
 	}
 }
 
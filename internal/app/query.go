@@ -0,0 +1,480 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// issueQuery is a parsed query. Terms are separated by spaces and all of
+// them must match; a leading - negates a term. A term is free text
+// (a word or a "quoted phrase" looked up in the id, title, description,
+// assignee and labels) or key:value:
+//
+//	status:open label:api assignee:me type:bug id:bd-3 parent:bd-12
+//	p1 p<=1 priority:>2
+//	updated:<7d created:>2026-01-01 closed:<=2026-03-31
+//	has:blocker has:parent has:children has:label ...
+//
+// Ages (h, d, w) compare how long ago: updated:<7d is the last week.
+// Dates compare the calendar: created:>2026-01-01 is after that day.
+type issueQuery struct {
+	terms []queryTerm
+}
+
+type queryTerm struct {
+	key    string // "" for free text
+	value  string
+	negate bool
+
+	op       string        // priority comparison
+	priority int           // priority value
+	age      time.Duration // relative time terms
+	from, to time.Time     // absolute time terms, zero when open
+}
+
+// queryKeys are the keys a query term can have, as completed in the
+// search input.
+var queryKeys = []string{"assignee", "closed", "created", "has", "id", "label", "parent", "priority", "status", "type", "updated"}
+
+// queryHasValues are the fields has: tests for.
+var queryHasValues = []string{"assignee", "blocker", "blocks", "children", "description", "label", "notes", "parent"}
+
+// queryNow is the clock relative time terms are measured against.
+var queryNow = time.Now
+
+// queryUser is who assignee:me stands for: $BD_ACTOR, then the git user
+// name, then $USER.
+var queryUser = sync.OnceValue(func() string {
+	if actor := strings.TrimSpace(os.Getenv("BD_ACTOR")); actor != "" {
+		return actor
+	}
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	return strings.TrimSpace(os.Getenv("USER"))
+})
+
+func parseQuery(expr string) (issueQuery, error) {
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return issueQuery{}, err
+	}
+	var q issueQuery
+	for _, tok := range tokens {
+		term, err := parseQueryTerm(tok)
+		if err != nil {
+			return issueQuery{}, err
+		}
+		q.terms = append(q.terms, term)
+	}
+	return q, nil
+}
+
+// textQuery matches each word of expr as free text. The search falls
+// back to it while the query does not parse.
+func textQuery(expr string) issueQuery {
+	var q issueQuery
+	for _, word := range strings.Fields(expr) {
+		q.terms = append(q.terms, queryTerm{value: strings.ToLower(word)})
+	}
+	return q
+}
+
+// queryToken is one space separated part of a query with the quotes
+// removed. quote is where the first quoted part started, or -1.
+type queryToken struct {
+	text  string
+	quote int
+}
+
+func tokenizeQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	var cur strings.Builder
+	quote, inQuote, started := -1, false, false
+	flush := func() {
+		if started {
+			tokens = append(tokens, queryToken{text: cur.String(), quote: quote})
+		}
+		cur.Reset()
+		quote, started = -1, false
+	}
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			if !inQuote && quote < 0 {
+				quote = cur.Len()
+			}
+			inQuote = !inQuote
+			started = true
+		case !inQuote && (r == ' ' || r == '\t'):
+			flush()
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+func parseQueryTerm(tok queryToken) (queryTerm, error) {
+	text, quote := tok.text, tok.quote
+	term := queryTerm{}
+	if len(text) > 1 && text[0] == '-' && quote != 0 {
+		term.negate = true
+		text = text[1:]
+		if quote > 0 {
+			quote--
+		}
+	}
+
+	colon := strings.IndexByte(text, ':')
+	if quote >= 0 && colon > quote {
+		colon = -1
+	}
+	if colon < 0 {
+		if quote < 0 {
+			if op, p, ok := shortPriority(text); ok {
+				term.key, term.op, term.priority = "priority", op, p
+				return term, nil
+			}
+		}
+		term.value = strings.ToLower(text)
+		return term, nil
+	}
+
+	term.key = strings.ToLower(text[:colon])
+	term.value = text[colon+1:]
+	if term.key == "p" {
+		term.key = "priority"
+	}
+	if term.value == "" {
+		return queryTerm{}, fmt.Errorf("empty value for %s", term.key)
+	}
+	switch term.key {
+	case "status":
+		if _, ok := statusFromString(term.value); !ok {
+			return queryTerm{}, fmt.Errorf("invalid status %q", term.value)
+		}
+	case "priority":
+		op, rest := splitQueryOp(term.value)
+		p, err := strconv.Atoi(rest)
+		if err != nil || p < 0 || p > 4 {
+			return queryTerm{}, fmt.Errorf("invalid priority %q", term.value)
+		}
+		term.op, term.priority = op, p
+	case "assignee":
+		if term.value == "me" {
+			term.value = queryUser()
+		}
+	case "label", "type", "id", "parent":
+	case "has":
+		term.value = strings.ToLower(term.value)
+		if !containsString(queryHasValues, term.value) && term.value != "blockers" && term.value != "labels" {
+			return queryTerm{}, fmt.Errorf("unknown has:%s (%s)", term.value, strings.Join(queryHasValues, ", "))
+		}
+	case "updated", "created", "closed":
+		if err := term.parseTime(); err != nil {
+			return queryTerm{}, err
+		}
+	default:
+		return queryTerm{}, fmt.Errorf("unknown key %q (%s)", term.key, strings.Join(queryKeys, ", "))
+	}
+	return term, nil
+}
+
+// shortPriority reads pN and its comparisons p<N, p<=N, p>N, p>=N.
+func shortPriority(text string) (string, int, bool) {
+	if len(text) < 2 || (text[0] != 'p' && text[0] != 'P') {
+		return "", 0, false
+	}
+	op, rest := splitQueryOp(text[1:])
+	if len(rest) != 1 || rest[0] < '0' || rest[0] > '4' {
+		return "", 0, false
+	}
+	return op, int(rest[0] - '0'), true
+}
+
+// splitQueryOp splits a leading comparison off value.
+func splitQueryOp(value string) (string, string) {
+	for _, op := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	return "", value
+}
+
+// parseTime reads an age (7d, <2w, >12h) or a date (2026-01-31, with a
+// comparison) into the term.
+func (t *queryTerm) parseTime() error {
+	op, rest := splitQueryOp(t.value)
+	if n := len(rest); n > 1 {
+		if count, err := strconv.Atoi(rest[:n-1]); err == nil && count >= 0 {
+			unit := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}[rest[n-1]]
+			if unit != 0 {
+				t.op = op
+				t.age = time.Duration(count) * unit
+				return nil
+			}
+		}
+	}
+	day, err := time.ParseInLocation("2006-01-02", rest, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q (use 7d, <2w or >=2026-01-31)", t.key, t.value)
+	}
+	next := day.AddDate(0, 0, 1)
+	switch op {
+	case ">":
+		t.from = next
+	case ">=":
+		t.from = day
+	case "<":
+		t.to = day
+	case "<=":
+		t.to = next
+	default:
+		t.from, t.to = day, next
+	}
+	return nil
+}
+
+func (q issueQuery) matches(issue Issue) bool {
+	for _, term := range q.terms {
+		if term.matches(issue) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t queryTerm) matches(issue Issue) bool {
+	switch t.key {
+	case "":
+		return matchesText(issue, t.value)
+	case "status":
+		return string(issue.Display) == t.value
+	case "priority":
+		return compareInts(issue.Priority, t.op, t.priority)
+	case "label":
+		return containsFold(issue.Labels, t.value)
+	case "assignee":
+		return strings.EqualFold(issue.Assignee, t.value)
+	case "type":
+		return strings.EqualFold(issue.IssueType, t.value)
+	case "id":
+		return strings.EqualFold(issue.ID, t.value)
+	case "parent":
+		return strings.EqualFold(strings.TrimSpace(issue.Parent), t.value)
+	case "has":
+		return issueHas(issue, t.value)
+	case "updated", "created", "closed":
+		raw := map[string]string{"updated": issue.UpdatedAt, "created": issue.CreatedAt, "closed": issue.ClosedAt}[t.key]
+		at, ok := parseDeferTime(raw)
+		return ok && t.inTimeRange(at)
+	}
+	return false
+}
+
+func (t queryTerm) inTimeRange(at time.Time) bool {
+	if t.from.IsZero() && t.to.IsZero() {
+		cutoff := queryNow().Add(-t.age)
+		if t.op == ">" || t.op == ">=" {
+			return !at.After(cutoff)
+		}
+		return !at.Before(cutoff)
+	}
+	return (t.from.IsZero() || !at.Before(t.from)) && (t.to.IsZero() || at.Before(t.to))
+}
+
+func compareInts(a int, op string, b int) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return a == b
+}
+
+// matchesText looks text up in the id, title, description, assignee and
+// labels of issue. text is lower case.
+func matchesText(issue Issue, text string) bool {
+	if strings.Contains(strings.ToLower(issue.ID), text) ||
+		strings.Contains(strings.ToLower(issue.Title), text) ||
+		strings.Contains(strings.ToLower(issue.Description), text) ||
+		strings.Contains(strings.ToLower(issue.Assignee), text) {
+		return true
+	}
+	for _, label := range issue.Labels {
+		if strings.Contains(strings.ToLower(label), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func issueHas(issue Issue, field string) bool {
+	switch field {
+	case "assignee":
+		return strings.TrimSpace(issue.Assignee) != ""
+	case "blocker", "blockers":
+		return len(issue.BlockedBy) > 0
+	case "blocks":
+		return len(issue.Blocks) > 0
+	case "children":
+		return len(issue.Children) > 0
+	case "description":
+		return strings.TrimSpace(issue.Description) != ""
+	case "label", "labels":
+		return len(issue.Labels) > 0
+	case "notes":
+		return strings.TrimSpace(issue.Notes) != ""
+	case "parent":
+		return strings.TrimSpace(issue.Parent) != ""
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// status is the status a query pins its issues to, if it has exactly one
+// positive status term.
+func (q issueQuery) status() (Status, bool) {
+	var found Status
+	for _, term := range q.terms {
+		if term.key != "status" || term.negate {
+			continue
+		}
+		if found != "" {
+			return "", false
+		}
+		found = Status(term.value)
+	}
+	return found, found != ""
+}
+
+// queryCompletions completes the term that ends at the byte offset cursor
+// in input: a key name, or a value of the key from the loaded issues. It
+// returns the byte offset the term starts at and the completed terms, best
+// first.
+func queryCompletions(input string, cursor int, issues []Issue) (int, []string) {
+	cursor = min(max(0, cursor), len(input))
+	start := strings.LastIndexAny(input[:cursor], " \t") + 1
+	term := input[start:cursor]
+	prefix := ""
+	if strings.HasPrefix(term, "-") {
+		prefix, term = "-", term[1:]
+	}
+	if term == "" {
+		return start, nil
+	}
+
+	var options []string
+	key, value, hasColon := strings.Cut(term, ":")
+	if !hasColon {
+		for _, k := range queryKeys {
+			if strings.HasPrefix(k, strings.ToLower(term)) {
+				options = append(options, prefix+k+":")
+			}
+		}
+		return start, options
+	}
+
+	key = strings.ToLower(key)
+	if key == "p" {
+		key = "priority"
+	}
+	value = strings.ToLower(strings.Trim(value, `"`))
+	for _, candidate := range queryValues(key, issues) {
+		if !strings.HasPrefix(strings.ToLower(candidate), value) || strings.EqualFold(candidate, value) {
+			continue
+		}
+		if strings.ContainsAny(candidate, " \t") {
+			candidate = `"` + candidate + `"`
+		}
+		options = append(options, prefix+key+":"+candidate)
+	}
+	return start, options
+}
+
+// queryValues lists the values worth completing for key.
+func queryValues(key string, issues []Issue) []string {
+	switch key {
+	case "status":
+		values := make([]string, len(statusOrder))
+		for i, status := range statusOrder {
+			values[i] = string(status)
+		}
+		return values
+	case "priority":
+		return []string{"0", "1", "2", "3", "4", "<=1", ">=2"}
+	case "has":
+		return queryHasValues
+	case "updated", "created", "closed":
+		return []string{"<1d", "<7d", "<30d", ">30d"}
+	}
+
+	seen := map[string]bool{}
+	add := func(v string) {
+		if v = strings.TrimSpace(v); v != "" {
+			seen[v] = true
+		}
+	}
+	for _, issue := range issues {
+		switch key {
+		case "label":
+			for _, label := range issue.Labels {
+				add(label)
+			}
+		case "assignee":
+			add(issue.Assignee)
+		case "type":
+			add(issue.IssueType)
+		case "parent":
+			add(issue.Parent)
+		case "id":
+			add(issue.ID)
+		}
+	}
+	values := make([]string, 0, len(seen)+1)
+	for v := range seen {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	if key == "assignee" {
+		values = append([]string{"me"}, values...)
+	}
+	return values
+}
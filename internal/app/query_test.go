@@ -0,0 +1,156 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQueryMatchesExampleQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	oldNow, oldUser := queryNow, queryUser
+	queryNow = func() time.Time { return now }
+	queryUser = func() string { return "amy" }
+	t.Cleanup(func() { queryNow, queryUser = oldNow, oldUser })
+
+	q, err := parseQuery(`assignee:me label:api -label:wontfix p<=1 updated:<7d has:blocker parent:bd-12 "login page"`)
+	if err != nil {
+		t.Fatalf("parseQuery: %v", err)
+	}
+	match := Issue{
+		ID: "bd-20", Title: "Fix the login page", Assignee: "amy", Labels: []string{"api"},
+		Priority: 1, UpdatedAt: "2026-03-08T09:00:00Z", BlockedBy: []string{"bd-3"}, Parent: "bd-12",
+	}
+	if !q.matches(match) {
+		t.Fatalf("query should match %+v", match)
+	}
+	for name, change := range map[string]func(*Issue){
+		"someone else's":   func(i *Issue) { i.Assignee = "zoe" },
+		"wontfix":          func(i *Issue) { i.Labels = append(i.Labels, "wontfix") },
+		"p2":               func(i *Issue) { i.Priority = 2 },
+		"stale":            func(i *Issue) { i.UpdatedAt = "2026-02-01T09:00:00Z" },
+		"unblocked":        func(i *Issue) { i.BlockedBy = nil },
+		"other parent":     func(i *Issue) { i.Parent = "bd-13" },
+		"no phrase":        func(i *Issue) { i.Title = "Fix the login" },
+		"no updated stamp": func(i *Issue) { i.UpdatedAt = "" },
+	} {
+		issue := match
+		issue.Labels = append([]string(nil), match.Labels...)
+		change(&issue)
+		if q.matches(issue) {
+			t.Fatalf("query should not match the %s issue", name)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, expr := range []string{`colour:red`, `priority:9`, `updated:<soon`, `"unterminated`} {
+		if _, err := parseQuery(expr); err == nil {
+			t.Fatalf("%q should not parse", expr)
+		}
+	}
+}
+
+func TestQueryCompletions(t *testing.T) {
+	issues := []Issue{{ID: "a", Labels: []string{"api", "app store"}}}
+
+	start, options := queryCompletions("status:open -la", 15, issues)
+	if start != 12 || strings.Join(options, ",") != "-label:" {
+		t.Fatalf("key completion = %d %q", start, options)
+	}
+	_, options = queryCompletions("label:ap", 8, issues)
+	if strings.Join(options, ",") != `label:api,label:"app store"` {
+		t.Fatalf("value completion = %q", options)
+	}
+
+	m := opQueueModel(issues...)
+	m.Mode = ModeSearch
+	m.SearchInput.SetValue("has:blo")
+	m.SearchInput.CursorEnd()
+	next, _ := m.handleSearchKey(tea.KeyMsg{Type: tea.KeyTab})
+	m = next.(model)
+	if m.SearchInput.Value() != "has:blocker " || m.SearchQuery != "has:blocker" {
+		t.Fatalf("tab completed to %q, query %q", m.SearchInput.Value(), m.SearchQuery)
+	}
+}
+
+func TestSearchCompletionAfterNonASCIIText(t *testing.T) {
+	m := opQueueModel(Issue{ID: "a", Labels: []string{"api"}})
+	m.Mode = ModeSearch
+
+	m.SearchInput.SetValue("задача lab")
+	m.SearchInput.CursorEnd()
+	if hint := m.searchHint(); !strings.Contains(hint, "label:") {
+		t.Fatalf("hint = %q, want label: offered", hint)
+	}
+
+	m.SearchInput.SetValue("задача label:a ошибка")
+	m.SearchInput.SetCursor(len([]rune("задача label:a")))
+	next, _ := m.handleSearchKey(tea.KeyMsg{Type: tea.KeyTab})
+	m = next.(model)
+	if got := m.SearchInput.Value(); got != "задача label:api  ошибка" {
+		t.Fatalf("tab completed to %q", got)
+	}
+	if pos := m.SearchInput.Position(); pos != len([]rune("задача label:api ")) {
+		t.Fatalf("cursor at rune %d, want after the completed term", pos)
+	}
+}
+
+func TestSavedViews(t *testing.T) {
+	marker := fakeBdOnPath(t, `[{"name":"mine","query":"assignee:amy"}]`)
+	client := NewBdClient(t.TempDir())
+	views, err := client.GetViews()
+	if err != nil || len(views) != 1 || views[0].Query != "assignee:amy" {
+		t.Fatalf("GetViews = %+v %v", views, err)
+	}
+
+	m := opQueueModel(
+		Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen, Assignee: "amy", Labels: []string{"api"}},
+		Issue{ID: "b", Title: "b", Status: StatusOpen, Display: StatusOpen, Assignee: "zoe"},
+	)
+	m.Client = client
+	m.Views = views
+
+	next, _ := m.handleBoardKey(runeKey("1"))
+	m = next.(model)
+	if m.SearchQuery != "assignee:amy" || len(m.Columns[StatusOpen]) != 1 || m.activeView() != 0 {
+		t.Fatalf("view 1 gave query %q and %d cards", m.SearchQuery, len(m.Columns[StatusOpen]))
+	}
+
+	m.Mode = ModeSearch
+	m.SearchInput.SetValue("label:api")
+	next, _ = m.handleSearchKey(runeKey(" "))
+	m = next.(model)
+	next, _ = m.handleSearchKey(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = next.(model)
+	if m.Mode != ModePrompt || m.Prompt == nil || m.Prompt.Action != PromptSaveView {
+		t.Fatalf("ctrl+s should ask for the view name, mode %q", m.Mode)
+	}
+	m.Prompt.Input.SetValue("api")
+	next, cmd := m.handlePromptKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	if len(m.Views) != 2 || m.Views[1] != (SavedView{Name: "api", Query: "label:api"}) {
+		t.Fatalf("views = %+v", m.Views)
+	}
+	if msg, ok := cmd().(viewsPersistMsg); !ok || msg.err != nil {
+		t.Fatalf("persist = %+v", msg)
+	}
+	calls, _ := os.ReadFile(marker)
+	if !strings.Contains(string(calls), `kv set bdtui.views [{"name":"mine","query":"assignee:amy"},{"name":"api","query":"label:api"}]`) {
+		t.Fatalf("bd calls = %q", calls)
+	}
+
+	next, _ = m.handleBoardKey(runeKey("v"))
+	m = next.(model)
+	if m.Mode != ModeViews || m.ViewsIndex != 1 || !strings.Contains(m.renderViewsModal(), "(active)") {
+		t.Fatalf("views picker mode %q at %d", m.Mode, m.ViewsIndex)
+	}
+	next, _ = m.handleViewsKey(runeKey("d"))
+	m = next.(model)
+	if len(m.Views) != 1 || m.ViewsIndex != 0 {
+		t.Fatalf("after delete views = %+v at %d", m.Views, m.ViewsIndex)
+	}
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bdtui/internal/daemon"
+	"bdtui/internal/daemon/daemonpb"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// runStatePollInterval is how often the board refreshes the run badges.
+const runStatePollInterval = 3 * time.Second
+
+// runBadgeStepWidth caps the step id shown in a card badge.
+const runBadgeStepWidth = 12
+
+// runStateMsg carries the result of loadRunStateCmd. client is the
+// connection the poll opened when the model had none yet.
+type runStateMsg struct {
+	client *daemon.Client
+	badges map[string]RunBadge
+	err    error
+}
+
+// runStatePollMsg asks for the next run state load.
+type runStatePollMsg struct{}
+
+func runStatePollCmd() tea.Cmd {
+	return tea.Tick(runStatePollInterval, func(time.Time) tea.Msg {
+		return runStatePollMsg{}
+	})
+}
+
+// loadRunStateCmd fetches the runs of the current project and reduces them
+// to one badge per task. It only talks to a daemon that is already running:
+// a board without runs must not spawn bdtuid, and a project that never
+// launched a run has no project id yet, so both just yield no badges.
+func (m model) loadRunStateCmd() tea.Cmd {
+	client, opts, repoDir := m.Daemon, m.daemonOptions(), m.RepoDir
+	return func() tea.Msg {
+		projectID, err := readProjectID(repoDir)
+		if err != nil || projectID == "" {
+			return runStateMsg{err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), runsLoadTimeout)
+		defer cancel()
+		var opened *daemon.Client
+		if client == nil {
+			opened, err = daemon.DialRunning(ctx, opts)
+			if errors.Is(err, daemon.ErrDaemonNotRunning) {
+				return runStateMsg{}
+			}
+			if err != nil {
+				return runStateMsg{err: err}
+			}
+			client = opened
+		}
+		resp, err := client.ListRuns(ctx, &daemonpb.ListRunsRequest{ProjectId: &projectID})
+		if err != nil {
+			return runStateMsg{client: opened, err: err}
+		}
+		return runStateMsg{client: opened, badges: runBadgesFromProto(resp.Runs)}
+	}
+}
+
+// readProjectID returns the project id launchRunCmd persisted for the
+// workspace, or "" when none was created yet. Unlike projectIDForBeadsDir
+// it never writes one.
+func readProjectID(repoDir string) (string, error) {
+	if strings.TrimSpace(repoDir) == "" {
+		return "", nil
+	}
+	gitDir, err := resolveGitDir(repoDir)
+	if err != nil {
+		return "", nil
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, gitProjectIDFilename))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if id := strings.TrimSpace(string(data)); validProjectID(id) {
+		return id, nil
+	}
+	return "", nil
+}
+
+// runBadgesFromProto picks each task's badge from runs listed oldest first:
+// an unfinished run wins, otherwise the latest run does.
+func runBadgesFromProto(runs []*daemonpb.Run) map[string]RunBadge {
+	badges := make(map[string]RunBadge, len(runs))
+	for _, r := range runs {
+		if r.TaskId == "" {
+			continue
+		}
+		b := RunBadge{RunID: r.Id, Status: r.Status, StepID: derefString(r.CurrentStepId)}
+		if prev, ok := badges[r.TaskId]; ok && prev.Active() && !b.Active() {
+			continue
+		}
+		badges[r.TaskId] = b
+	}
+	return badges
+}
+
+// handleRunState stores fresh badges and schedules the next poll. Failed
+// polls keep the last badges quietly, like failed background reloads.
+func (m model) handleRunState(msg runStateMsg) (tea.Model, tea.Cmd) {
+	if msg.client != nil {
+		if m.Daemon == nil {
+			m.Daemon = msg.client
+		} else {
+			_ = msg.client.Close()
+		}
+	}
+	var next tea.Cmd
+	if !m.Cfg.NoWatch {
+		next = runStatePollCmd()
+	}
+	if msg.err != nil || runBadgesEqual(m.RunBadges, msg.badges) {
+		return m, next
+	}
+	selectedID := m.currentIssueID()
+	m.RunBadges = msg.badges
+	m.computeColumns()
+	m.normalizeSelectionBounds()
+	if selectedID != "" {
+		m.selectIssueByID(selectedID)
+	}
+	return m, next
+}
+
+func runBadgesEqual(a, b map[string]RunBadge) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, x := range a {
+		if y, ok := b[id]; !ok || x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// Active reports whether the run has not finished yet.
+func (b RunBadge) Active() bool {
+	switch b.Status {
+	case "queued", "running", "waiting_human", "needs_attention":
+		return true
+	}
+	return false
+}
+
+// NeedsAttention reports whether the run is blocked on the operator:
+// waiting for a human answer or flagged for attention.
+func (b RunBadge) NeedsAttention() bool {
+	return b.Status == "waiting_human" || b.Status == "needs_attention"
+}
+
+// Label is the compact card badge, e.g. "▶ implement" or "? review".
+// Completed and cancelled runs have none.
+func (b RunBadge) Label() string {
+	var icon string
+	switch b.Status {
+	case "queued":
+		return "◌ queued"
+	case "running":
+		icon = "▶"
+	case "waiting_human":
+		icon = "?"
+	case "needs_attention":
+		icon = "!"
+	case "failed":
+		icon = "✗"
+	default:
+		return ""
+	}
+	step := b.StepID
+	if step == "" {
+		step = strings.ReplaceAll(b.Status, "_", " ")
+	}
+	return icon + " " + truncate(step, runBadgeStepWidth)
+}
+
+func runBadgeStyle(status string) lipgloss.Style {
+	switch status {
+	case "running":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	case "waiting_human":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("117")).Bold(true)
+	case "needs_attention":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true)
+	case "failed":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Bold(true)
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	}
+}
+
+// pendingBadgeLabel marks a card whose queued op has not reached bd yet.
+const pendingBadgeLabel = "…"
+
+// boardBadgeLabel is the plain text of every badge on a card: the pending
+// marker, the run badge, then the defer badge.
+func (i Issue) boardBadgeLabel() string {
+	var parts []string
+	if i.Pending {
+		parts = append(parts, pendingBadgeLabel)
+	}
+	if i.Run != nil {
+		if l := i.Run.Label(); l != "" {
+			parts = append(parts, l)
+		}
+	}
+	if l := i.deferBadgeLabel(); l != "" {
+		parts = append(parts, l)
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderBoardBadges styles the badges of boardBadgeLabel.
+func renderBoardBadges(item Issue) string {
+	var parts []string
+	if item.Pending {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(pendingBadgeLabel))
+	}
+	if item.Run != nil {
+		if l := item.Run.Label(); l != "" {
+			parts = append(parts, runBadgeStyle(item.Run.Status).Render(l))
+		}
+	}
+	if l := item.deferBadgeLabel(); l != "" {
+		parts = append(parts, deferBadgeStyle().Render(l))
+	}
+	return strings.Join(parts, " ")
+}
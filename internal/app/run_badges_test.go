@@ -0,0 +1,86 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"bdtui/internal/daemon/daemonpb"
+)
+
+func TestRunBadgesFromProto(t *testing.T) {
+	step := "implement"
+	review := "review"
+	badges := runBadgesFromProto([]*daemonpb.Run{
+		{Id: "r1", TaskId: "a", Status: "failed"},
+		{Id: "r2", TaskId: "a", Status: "running", CurrentStepId: &step},
+		{Id: "r3", TaskId: "b", Status: "waiting_human", CurrentStepId: &review},
+		{Id: "r4", TaskId: "b", Status: "cancelled"},
+		{Id: "r5", TaskId: "c", Status: "completed"},
+		{Id: "r6", TaskId: "c", Status: "failed"},
+		{Id: "r7", Status: "running"},
+	})
+	want := map[string]RunBadge{
+		"a": {RunID: "r2", Status: "running", StepID: "implement"},
+		"b": {RunID: "r3", Status: "waiting_human", StepID: "review"},
+		"c": {RunID: "r6", Status: "failed"},
+	}
+	if !runBadgesEqual(badges, want) {
+		t.Fatalf("badges = %+v, want %+v", badges, want)
+	}
+
+	labels := map[string]string{
+		"a": "▶ implement",
+		"b": "? review",
+		"c": "✗ failed",
+	}
+	for id, label := range labels {
+		if got := badges[id].Label(); got != label {
+			t.Fatalf("badge %s label = %q, want %q", id, got, label)
+		}
+	}
+	if got := (RunBadge{Status: "completed"}).Label(); got != "" {
+		t.Fatalf("completed badge label = %q, want none", got)
+	}
+}
+
+func TestRunStateFiltersAndRendersBadges(t *testing.T) {
+	m := model{
+		Issues: []Issue{
+			{ID: "a", Title: "running task", Display: StatusInProgress, Status: StatusInProgress},
+			{ID: "b", Title: "waiting task", Display: StatusInProgress, Status: StatusInProgress},
+			{ID: "c", Title: "idle task", Display: StatusOpen, Status: StatusOpen},
+		},
+		Filter:       Filter{Status: "any", Priority: "any", Type: "any", Run: "any"},
+		SelectedIdx:  map[Status]int{},
+		ScrollOffset: map[Status]int{},
+		Cfg:          Config{NoWatch: true},
+	}
+	m.computeColumns()
+
+	step := "review"
+	next, _ := m.handleRunState(runStateMsg{badges: runBadgesFromProto([]*daemonpb.Run{
+		{Id: "r1", TaskId: "a", Status: "running"},
+		{Id: "r2", TaskId: "b", Status: "waiting_human", CurrentStepId: &step},
+	})})
+	m = next.(model)
+
+	m.Filter.Run = "active"
+	m.computeColumns()
+	if got := len(m.Columns[StatusInProgress]) + len(m.Columns[StatusOpen]); got != 2 {
+		t.Fatalf("active filter kept %d issues, want 2", got)
+	}
+	m.Filter.Run = "attention"
+	m.computeColumns()
+	if col := m.Columns[StatusInProgress]; len(col) != 1 || col[0].ID != "b" {
+		t.Fatalf("attention filter kept %+v, want only b", col)
+	}
+	if m.Filter.IsEmpty() {
+		t.Fatal("run filter should make the filter non-empty")
+	}
+
+	m.Width, m.Height = 120, 30
+	out := m.renderColumn(StatusInProgress, 40, 10, false)
+	if !strings.Contains(out, "? review") {
+		t.Fatalf("expected run badge on card, got %q", out)
+	}
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bdtui/internal/daemon"
+	"bdtui/internal/daemon/daemonpb"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openRunSessions opens the sessions view for the selected run and starts
+// an async ListSessions load.
+func (m model) openRunSessions() (tea.Model, tea.Cmd) {
+	run := m.currentRun()
+	if run == nil {
+		m.setToast("warning", "no run selected")
+		return m, nil
+	}
+	if m.Daemon == nil {
+		m.setToast("warning", "daemon not running")
+		return m, nil
+	}
+	m.Runs.Sessions = &RunSessionsState{
+		RunID:      run.RunID,
+		TaskID:     run.TaskID,
+		LoadingMsg: "loading sessions...",
+	}
+	return m, loadRunSessionsCmd(m.Daemon, run.RunID)
+}
+
+// loadRunSessionsCmd fetches the agent sessions of one run and delivers
+// them as runSessionsLoadedMsg.
+func loadRunSessionsCmd(client *daemon.Client, runID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), runsLoadTimeout)
+		defer cancel()
+		resp, err := client.ListSessions(ctx, &daemonpb.ListSessionsRequest{RunId: runID})
+		if err != nil {
+			return runSessionsLoadedMsg{runID: runID, err: err}
+		}
+		return runSessionsLoadedMsg{runID: runID, rows: runSessionRowsFromProto(resp)}
+	}
+}
+
+// runSessionRowsFromProto converts a ListSessions response into the rows
+// the sessions view renders.
+func runSessionRowsFromProto(resp *daemonpb.ListSessionsResponse) []RunSessionRow {
+	rows := make([]RunSessionRow, 0, len(resp.Sessions))
+	for _, s := range resp.Sessions {
+		updated, _ := time.Parse(time.RFC3339Nano, s.UpdatedAt)
+		rows = append(rows, RunSessionRow{
+			RoleID:      s.RoleId,
+			SessionID:   s.SessionId,
+			ForkPending: s.ForkPending,
+			ForkedFrom:  derefString(s.ForkedFrom),
+			UpdatedAt:   updated,
+		})
+	}
+	return rows
+}
+
+// runSessionsLoadedMsg carries a run's sessions back to the model, either
+// from a load or as the result of a clear/fork action.
+type runSessionsLoadedMsg struct {
+	runID  string
+	action string
+	rows   []RunSessionRow
+	err    error
+}
+
+// handleRunSessionsLoaded applies a runSessionsLoadedMsg. Results for a
+// run the operator already navigated away from are dropped.
+func (m model) handleRunSessionsLoaded(msg runSessionsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.Runs == nil || m.Runs.Sessions == nil || m.Runs.Sessions.RunID != msg.runID {
+		return m, nil
+	}
+	ss := m.Runs.Sessions
+	ss.LoadingMsg = ""
+	if msg.err != nil {
+		if msg.action != "" {
+			// A failed clear/fork leaves the loaded rows as they were.
+			m.setToast("warning", fmt.Sprintf("%s session failed: %v", msg.action, msg.err))
+			return m, nil
+		}
+		ss.Loaded = true
+		ss.LastError = fmt.Sprintf("sessions failed: %v", msg.err)
+		m.setToast("warning", ss.LastError)
+		ss.Rows = nil
+		ss.Index = 0
+		return m, nil
+	}
+	ss.Loaded = true
+	ss.LastError = ""
+	ss.Rows = msg.rows
+	if ss.Index >= len(ss.Rows) {
+		ss.Index = max(0, len(ss.Rows)-1)
+	}
+	if msg.action != "" {
+		m.setToast("success", fmt.Sprintf("%s session sent for %s", msg.action, shortRunID(msg.runID)))
+	}
+	return m, nil
+}
+
+// currentSession returns the session row under the cursor, or nil.
+func (m model) currentSession() *RunSessionRow {
+	if m.Runs == nil || m.Runs.Sessions == nil {
+		return nil
+	}
+	ss := m.Runs.Sessions
+	if ss.Index < 0 || ss.Index >= len(ss.Rows) {
+		return nil
+	}
+	return &ss.Rows[ss.Index]
+}
+
+// moveSessionSelection clamps the sessions view selection by delta rows.
+func (m model) moveSessionSelection(delta int) {
+	if m.Runs == nil || m.Runs.Sessions == nil || len(m.Runs.Sessions.Rows) == 0 {
+		return
+	}
+	ss := m.Runs.Sessions
+	ss.Index = min(max(ss.Index+delta, 0), len(ss.Rows)-1)
+}
+
+// clearSelectedSession forgets the selected role's session, so its next
+// attempt starts a fresh conversation.
+func (m model) clearSelectedSession() (tea.Model, tea.Cmd) {
+	return m.sessionAction("clear", func(ctx context.Context, client *daemon.Client, req *daemonpb.SessionRequest) (*daemonpb.ListSessionsResponse, error) {
+		return client.ClearSession(ctx, req)
+	})
+}
+
+// forkSelectedSession makes the selected role's next attempt continue the
+// conversation under a new session id, leaving the current one intact.
+func (m model) forkSelectedSession() (tea.Model, tea.Cmd) {
+	return m.sessionAction("fork", func(ctx context.Context, client *daemon.Client, req *daemonpb.SessionRequest) (*daemonpb.ListSessionsResponse, error) {
+		return client.ForkSession(ctx, req)
+	})
+}
+
+// sessionAction sends a clear/fork RPC for the selected session and
+// delivers the refreshed session list.
+func (m model) sessionAction(action string, call func(context.Context, *daemon.Client, *daemonpb.SessionRequest) (*daemonpb.ListSessionsResponse, error)) (tea.Model, tea.Cmd) {
+	se := m.currentSession()
+	if se == nil {
+		m.setToast("warning", "no session selected")
+		return m, nil
+	}
+	if m.Daemon == nil {
+		m.setToast("warning", "daemon not running")
+		return m, nil
+	}
+	client := m.Daemon
+	req := &daemonpb.SessionRequest{RunId: m.Runs.Sessions.RunID, RoleId: se.RoleID}
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), runsLoadTimeout)
+		defer cancel()
+		resp, err := call(ctx, client, req)
+		if err != nil {
+			return runSessionsLoadedMsg{runID: req.RunId, action: action, err: err}
+		}
+		return runSessionsLoadedMsg{runID: req.RunId, action: action, rows: runSessionRowsFromProto(resp)}
+	}
+}
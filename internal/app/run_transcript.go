@@ -0,0 +1,254 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"bdtui/internal/agent"
+	"bdtui/internal/daemon"
+	"bdtui/internal/daemon/daemonpb"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transcriptViewWindow is how many lines the transcript view shows at once.
+const transcriptViewWindow = 30
+
+// openRunTranscript opens the transcript view for an execution and starts
+// following its TailExecutionLog stream. The stream lives until the
+// execution finishes or the view is closed.
+func (m model) openRunTranscript(execID, runID string) (tea.Model, tea.Cmd) {
+	if execID == "" {
+		m.setToast("warning", "no transcript for this run")
+		return m, nil
+	}
+	if m.Daemon == nil {
+		m.setToast("warning", "daemon not running")
+		return m, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.Runs.Transcript = &RunTranscriptState{
+		ExecutionID: execID,
+		RunID:       runID,
+		Follow:      true,
+		LoadingMsg:  "loading transcript...",
+		cancel:      cancel,
+	}
+	return m, tailTranscriptCmd(ctx, m.Daemon, execID, 0)
+}
+
+// openSelectedRunTranscript opens the transcript of the selected run's
+// latest execution.
+func (m model) openSelectedRunTranscript() (tea.Model, tea.Cmd) {
+	run := m.currentRun()
+	if run == nil {
+		m.setToast("warning", "no run selected")
+		return m, nil
+	}
+	return m.openRunTranscript(run.TranscriptExecID, run.RunID)
+}
+
+// openTimelineTranscript opens the transcript of the execution under the
+// timeline cursor.
+func (m model) openTimelineTranscript() (tea.Model, tea.Cmd) {
+	row := m.selectedTimelineRow()
+	if row == nil || row.Kind != "execution" {
+		m.setToast("warning", "select an execution row to view its transcript")
+		return m, nil
+	}
+	return m.openRunTranscript(row.ID, m.Runs.Timeline.RunID)
+}
+
+// closeRunTranscript stops the tail stream and closes the view.
+func (m model) closeRunTranscript() {
+	if m.Runs == nil || m.Runs.Transcript == nil {
+		return
+	}
+	if m.Runs.Transcript.cancel != nil {
+		m.Runs.Transcript.cancel()
+	}
+	m.Runs.Transcript = nil
+}
+
+// reconnectRunTranscript restarts the tail stream after an error, resuming
+// at the bytes already received.
+func (m model) reconnectRunTranscript() (tea.Model, tea.Cmd) {
+	tr := m.Runs.Transcript
+	if m.Daemon == nil {
+		m.setToast("warning", "daemon not running")
+		return m, nil
+	}
+	if tr.Done {
+		return m, nil
+	}
+	if tr.cancel != nil {
+		tr.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	tr.cancel = cancel
+	tr.LastError = ""
+	tr.LoadingMsg = "reconnecting..."
+	return m, tailTranscriptCmd(ctx, m.Daemon, tr.ExecutionID, tr.Offset)
+}
+
+// tailTranscriptCmd opens a following TailExecutionLog stream and delivers
+// its first chunk.
+func tailTranscriptCmd(ctx context.Context, client *daemon.Client, execID string, offset int64) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := client.TailExecutionLog(ctx, &daemonpb.TailExecutionLogRequest{
+			ExecutionId: execID,
+			Offset:      offset,
+			Follow:      true,
+		})
+		if err != nil {
+			return transcriptChunkMsg{execID: execID, err: err}
+		}
+		return recvTranscriptCmd(stream, execID)()
+	}
+}
+
+// recvTranscriptCmd waits for the next chunk of an open stream.
+func recvTranscriptCmd(stream daemonpb.Orchestrator_TailExecutionLogClient, execID string) tea.Cmd {
+	return func() tea.Msg {
+		chunk, err := stream.Recv()
+		return transcriptChunkMsg{execID: execID, stream: stream, chunk: chunk, err: err}
+	}
+}
+
+// transcriptChunkMsg carries one TailExecutionLog chunk, and the stream to
+// read the next one from.
+type transcriptChunkMsg struct {
+	execID string
+	stream daemonpb.Orchestrator_TailExecutionLogClient
+	chunk  *daemonpb.ExecutionLogChunk
+	err    error
+}
+
+// handleTranscriptChunk appends a chunk to the transcript view and asks
+// for the next one. Chunks for a view that was closed or switched to
+// another execution are dropped; their stream was cancelled on close.
+func (m model) handleTranscriptChunk(msg transcriptChunkMsg) (tea.Model, tea.Cmd) {
+	if m.Runs == nil || m.Runs.Transcript == nil || m.Runs.Transcript.ExecutionID != msg.execID {
+		return m, nil
+	}
+	tr := m.Runs.Transcript
+	tr.LoadingMsg = ""
+	if msg.err != nil {
+		if status.Code(msg.err) == codes.Canceled {
+			return m, nil
+		}
+		if errors.Is(msg.err, io.EOF) {
+			// The daemon closed the stream without a final chunk
+			// (e.g. it shut down); R reconnects.
+			tr.LastError = "stream closed"
+			return m, nil
+		}
+		tr.LastError = fmt.Sprintf("transcript failed: %v", msg.err)
+		m.setToast("warning", tr.LastError)
+		return m, nil
+	}
+	c := msg.chunk
+	if c.Status != "" {
+		tr.Status = c.Status
+	}
+	// A chunk that overlaps bytes already shown (after a reconnect) is
+	// trimmed to the new part.
+	if data := c.Data; len(data) > 0 && c.Offset+int64(len(data)) > tr.Offset {
+		if skip := tr.Offset - c.Offset; skip > 0 {
+			data = data[skip:]
+		}
+		tr.appendData(data)
+		tr.Offset = c.Offset + int64(len(c.Data))
+	}
+	if c.Eof {
+		tr.flushPartial()
+		tr.Done = true
+		if tr.cancel != nil {
+			tr.cancel()
+		}
+		tr.follow()
+		return m, nil
+	}
+	tr.follow()
+	return m, recvTranscriptCmd(msg.stream, msg.execID)
+}
+
+// appendData adds transcript bytes, rendering each complete line. A
+// trailing partial line waits for the rest of its bytes.
+func (tr *RunTranscriptState) appendData(data []byte) {
+	buf := append(tr.partial, data...)
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		tr.appendLine(buf[:i])
+		buf = buf[i+1:]
+	}
+	tr.partial = append([]byte(nil), buf...)
+}
+
+// flushPartial renders a final line that never got its newline.
+func (tr *RunTranscriptState) flushPartial() {
+	if len(tr.partial) > 0 {
+		tr.appendLine(tr.partial)
+		tr.partial = nil
+	}
+}
+
+func (tr *RunTranscriptState) appendLine(line []byte) {
+	for _, e := range agent.ParseTranscriptLine(line) {
+		for i, text := range strings.Split(strings.TrimRight(e.Text, "\n"), "\n") {
+			tr.Lines = append(tr.Lines, TranscriptLine{Kind: e.Kind, Text: text, IsError: e.IsError, Cont: i > 0})
+		}
+	}
+}
+
+// follow scrolls to the newest lines while Follow is on.
+func (tr *RunTranscriptState) follow() {
+	if tr.Follow {
+		tr.Scroll = max(0, len(tr.Lines)-transcriptViewWindow)
+	}
+}
+
+// scrollRunTranscript moves the view by delta lines. Scrolling up stops
+// following; reaching the bottom resumes it.
+func (m model) scrollRunTranscript(delta int) {
+	if m.Runs == nil || m.Runs.Transcript == nil {
+		return
+	}
+	tr := m.Runs.Transcript
+	last := max(0, len(tr.Lines)-transcriptViewWindow)
+	tr.Scroll = min(max(tr.Scroll+delta, 0), last)
+	tr.Follow = tr.Scroll == last
+}
+
+// transcriptLinePrefix marks what a transcript line is: prose is indented,
+// tool calls and results get arrows, the final result "=", and errors "!".
+func transcriptLinePrefix(l TranscriptLine) string {
+	if l.Cont {
+		return "    "
+	}
+	if l.IsError {
+		return "  ! "
+	}
+	switch l.Kind {
+	case agent.TranscriptToolCall:
+		return "  → "
+	case agent.TranscriptToolResult:
+		return "  ← "
+	case agent.TranscriptResult:
+		return "  = "
+	case agent.TranscriptSystem:
+		return "  · "
+	case agent.TranscriptRaw:
+		return "  "
+	}
+	return "    "
+}
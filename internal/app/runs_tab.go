@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -57,21 +58,27 @@ func runRowFromProto(ctx context.Context, r *daemonpb.Run, client *daemon.Client
 		NeedsAttention:    derefString(r.NeedsAttentionReason),
 		WorkflowStageHint: stageHintFromSnapshot(r.WorkflowSnapshot),
 		HasPendingHuman:   r.Status == "waiting_human",
+		Tokens:            r.GetUsage().GetInputTokens() + r.GetUsage().GetOutputTokens(),
+		CostUSD:           r.GetUsage().GetCostUsd(),
+		MaxTokens:         r.MaxTokens,
+		MaxCostUSD:        r.MaxCostUsd,
 	}
 	// Fetch the executions for this run so the row can show the
 	// most-recent pane_id. We don't propagate the error -- if the
 	// call fails the row still renders, just without a pane reference.
-	if listResp, err := client.ListExecutions(rpcCtx(), &daemonpb.ListExecutionsRequest{
+	if listResp, err := client.ListExecutions(ctx, &daemonpb.ListExecutionsRequest{
 		RunId: &r.Id,
 	}); err == nil {
 		// Walk backwards so we pick the most-recent execution that
 		// actually has a pane_id (matching what the operator would
-		// expect to follow).
+		// expect to follow), and likewise the latest transcript.
 		for i := len(listResp.Executions) - 1; i >= 0; i-- {
 			e := listResp.Executions[i]
-			if p := derefString(e.PaneId); p != "" {
+			if p := derefString(e.PaneId); p != "" && row.PaneID == "" {
 				row.PaneID = p
-				break
+			}
+			if e.TranscriptRef != "" && row.TranscriptExecID == "" {
+				row.TranscriptExecID = e.Id
 			}
 		}
 	}
@@ -79,7 +86,7 @@ func runRowFromProto(ctx context.Context, r *daemonpb.Run, client *daemon.Client
 		// Fetch the pending human_input ids so the operator can answer.
 		// We don't propagate the error -- if the call fails the row
 		// still renders, the user just can't answer from this tab.
-		hiResp, err := client.ListHumanInputs(rpcCtx(), &daemonpb.ListHumanInputsRequest{
+		hiResp, err := client.ListHumanInputs(ctx, &daemonpb.ListHumanInputsRequest{
 			RunId: &r.Id,
 		})
 		if err == nil {
@@ -95,14 +102,6 @@ func runRowFromProto(ctx context.Context, r *daemonpb.Run, client *daemon.Client
 	return row, nil
 }
 
-// rpcCtx returns a short-lived context for individual gRPC calls.
-// Each call is bounded by runsLoadTimeout so a hung daemon does not
-// stall the TUI.
-func rpcCtx() context.Context {
-	ctx, _ := context.WithTimeout(context.Background(), runsLoadTimeout)
-	return ctx
-}
-
 // stageHintFromSnapshot extracts a short human-readable step hint from
 // the stored workflow snapshot. Returns "" if the snapshot is empty or
 // not parseable; the Runs tab still works without a hint.
@@ -160,7 +159,79 @@ func (m model) openRunsTab() (tea.Model, tea.Cmd) {
 	m.Runs = &RunsTabState{LoadingMsg: "loading runs..."}
 	m.Mode = ModeRuns
 	m.clearTransientUI()
-	return m, loadRunsCmd(client)
+	return m, tea.Batch(loadRunsCmd(client), daemonHandshakeCmd(client))
+}
+
+// daemonHandshakeCmd runs the version handshake against the connected
+// daemon so a TUI upgraded under a long-lived bdtuid notices it.
+func daemonHandshakeCmd(client *daemon.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), runsLoadTimeout)
+		defer cancel()
+		_, err := client.Handshake(ctx)
+		return daemonHandshakeMsg{err: err}
+	}
+}
+
+// daemonHandshakeMsg carries the result of daemonHandshakeCmd.
+type daemonHandshakeMsg struct {
+	err error
+}
+
+// handleDaemonHandshake flags a stale daemon on the Runs tab. Transport
+// errors are left to the regular run load, which reports them already.
+func (m model) handleDaemonHandshake(msg daemonHandshakeMsg) (tea.Model, tea.Cmd) {
+	if m.Runs == nil || !errors.Is(msg.err, daemon.ErrStaleDaemon) {
+		return m, nil
+	}
+	m.Runs.StaleDaemon = msg.err.Error()
+	m.setToast("warning", "daemon is older than bdtui; press D in Runs to restart it")
+	return m, nil
+}
+
+// restartDaemon replaces a stale daemon with the installed bdtuid. Runs
+// are durable in the daemon's SQLite store, so a restart loses no state.
+func (m model) restartDaemon() (tea.Model, tea.Cmd) {
+	if m.Runs != nil {
+		m.Runs.LoadingMsg = "restarting daemon..."
+	}
+	opts := m.daemonOptions()
+	old := m.Daemon
+	return m, func() tea.Msg {
+		if old != nil {
+			_ = old.Close()
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*runsLoadTimeout)
+		defer cancel()
+		client, err := daemon.RestartDaemon(ctx, opts)
+		return daemonRestartedMsg{client: client, err: err}
+	}
+}
+
+// daemonRestartedMsg carries the new client after restartDaemon.
+type daemonRestartedMsg struct {
+	client *daemon.Client
+	err    error
+}
+
+// handleDaemonRestarted swaps in the new client and reloads the tab.
+func (m model) handleDaemonRestarted(msg daemonRestartedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.Daemon = nil
+		if m.Runs != nil {
+			m.Runs.LoadingMsg = ""
+			m.Runs.LastError = "restart failed: " + msg.err.Error()
+		}
+		m.setToast("error", "daemon restart failed: "+msg.err.Error())
+		return m, nil
+	}
+	m.Daemon = msg.client
+	if m.Runs != nil {
+		m.Runs.StaleDaemon = ""
+		m.Runs.LoadingMsg = "loading runs..."
+	}
+	m.setToast("success", "daemon restarted")
+	return m, tea.Batch(loadRunsCmd(msg.client), daemonHandshakeCmd(msg.client))
 }
 
 // daemonOpenCtx returns a short-lived context for opening the daemon
@@ -390,4 +461,134 @@ func (m model) moveRunSelection(delta int) {
 	if m.Runs.Index >= len(m.Runs.Rows) {
 		m.Runs.Index = len(m.Runs.Rows) - 1
 	}
-}
\ No newline at end of file
+}
+// openRunTimeline opens the detail view for the selected run and starts
+// an async GetRunTimeline load. The list stays in m.Runs so esc returns
+// to the same selection.
+func (m model) openRunTimeline() (tea.Model, tea.Cmd) {
+	run := m.currentRun()
+	if run == nil {
+		m.setToast("warning", "no run selected")
+		return m, nil
+	}
+	if m.Daemon == nil {
+		m.setToast("warning", "daemon not running")
+		return m, nil
+	}
+	m.Runs.Timeline = &RunTimelineState{
+		RunID:      run.RunID,
+		TaskID:     run.TaskID,
+		Status:     run.Status,
+		LoadingMsg: "loading timeline...",
+	}
+	return m, loadRunTimelineCmd(m.Daemon, run.RunID)
+}
+
+// loadRunTimelineCmd fetches the joined history of one run and delivers
+// it as runTimelineLoadedMsg.
+func loadRunTimelineCmd(client *daemon.Client, runID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), runsLoadTimeout)
+		defer cancel()
+		resp, err := client.GetRunTimeline(ctx, &daemonpb.GetRunTimelineRequest{RunId: runID})
+		if err != nil {
+			return runTimelineLoadedMsg{runID: runID, err: err}
+		}
+		rows := make([]RunTimelineRow, 0, len(resp.Entries))
+		for _, e := range resp.Entries {
+			rows = append(rows, runTimelineRowFromProto(e))
+		}
+		msg := runTimelineLoadedMsg{runID: runID, rows: rows}
+		if resp.Run != nil {
+			msg.status = resp.Run.Status
+		}
+		return msg
+	}
+}
+
+// runTimelineRowFromProto converts a daemon TimelineEntry into the row
+// the detail view renders. Timestamps that fail to parse render as
+// zero rather than failing the whole load.
+func runTimelineRowFromProto(e *daemonpb.TimelineEntry) RunTimelineRow {
+	at, _ := time.Parse(time.RFC3339Nano, e.At)
+	return RunTimelineRow{
+		Kind:     e.Kind,
+		ID:       e.Id,
+		At:       at,
+		Duration: time.Duration(e.DurationMs) * time.Millisecond,
+		Open:     e.EndedAt == nil,
+		StepID:   e.StepId,
+		Attempt:  int(e.Attempt),
+		Status:   e.Status,
+		Summary:  e.Summary,
+		Detail:   e.Detail,
+		Error:    derefString(e.Error),
+	}
+}
+
+// runTimelineLoadedMsg carries the result of loadRunTimelineCmd back to
+// the model.
+type runTimelineLoadedMsg struct {
+	runID  string
+	status string
+	rows   []RunTimelineRow
+	err    error
+}
+
+// handleRunTimelineLoaded applies a runTimelineLoadedMsg. Results for a
+// run the operator already navigated away from are dropped.
+func (m model) handleRunTimelineLoaded(msg runTimelineLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.Runs == nil || m.Runs.Timeline == nil || m.Runs.Timeline.RunID != msg.runID {
+		return m, nil
+	}
+	tl := m.Runs.Timeline
+	tl.Loaded = true
+	tl.LoadingMsg = ""
+	if msg.err != nil {
+		tl.LastError = fmt.Sprintf("timeline failed: %v", msg.err)
+		m.setToast("warning", tl.LastError)
+		tl.Rows = nil
+		tl.Scroll = 0
+		return m, nil
+	}
+	tl.LastError = ""
+	tl.Rows = msg.rows
+	if msg.status != "" {
+		tl.Status = msg.status
+	}
+	if tl.Scroll >= len(tl.Rows) {
+		tl.Scroll = 0
+	}
+	return m, nil
+}
+
+// scrollRunTimeline moves the detail view by delta rows, clamped to the
+// loaded entries.
+func (m model) scrollRunTimeline(delta int) {
+	if m.Runs == nil || m.Runs.Timeline == nil {
+		return
+	}
+	tl := m.Runs.Timeline
+	tl.Scroll += delta
+	if tl.Scroll > len(tl.Rows)-1 {
+		tl.Scroll = len(tl.Rows) - 1
+	}
+	if tl.Scroll < 0 {
+		tl.Scroll = 0
+	}
+}
+
+// formatTimelineDuration renders a compact duration for the timeline
+// ("1m5s", "850ms"). Rows still in flight render as "open" and rows without a
+// measurable span as "-".
+func formatTimelineDuration(r RunTimelineRow) string {
+	switch {
+	case r.Open && (r.Kind == "step_attempt" || r.Kind == "execution" || r.Kind == "human_input"):
+		return "open"
+	case r.Duration <= 0:
+		return "-"
+	case r.Duration < time.Second:
+		return fmt.Sprintf("%dms", r.Duration.Milliseconds())
+	}
+	return r.Duration.Round(time.Second).String()
+}
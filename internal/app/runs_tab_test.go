@@ -1,9 +1,15 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"bdtui/internal/daemon"
+	"bdtui/internal/daemon/daemonpb"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // TestRenderRunsModalEmpty asserts the modal renders cleanly when no
@@ -28,7 +34,8 @@ func TestRenderRunsModalRows(t *testing.T) {
 		Index:  1,
 		Rows: []RunRow{
 			{RunID: "run-aaaa", Status: "completed", TaskID: "task-smooth", CurrentStepID: "implement", PaneID: "%42"},
-			{RunID: "run-bbbb", Status: "waiting_human", TaskID: "task-human", CurrentStepID: "ask-human", PaneID: "%7", HasPendingHuman: true},
+			{RunID: "run-bbbb", Status: "waiting_human", TaskID: "task-human", CurrentStepID: "ask-human", PaneID: "%7", HasPendingHuman: true,
+				Tokens: 12345, MaxTokens: 50000, CostUSD: 0.42, MaxCostUSD: 2},
 		},
 	}}
 	out := m.renderRunsModal()
@@ -47,6 +54,9 @@ func TestRenderRunsModalRows(t *testing.T) {
 	if !contains(out, "%42") {
 		t.Fatalf("expected pane reference '%%42' in modal output, got: %q", out)
 	}
+	if !contains(out, "12.3k/50k tok $0.42/$2.00") {
+		t.Fatalf("expected usage against budget in modal output, got: %q", out)
+	}
 }
 
 // TestMoveRunSelectionClamps ensures the selection index never goes
@@ -110,14 +120,80 @@ func TestCurrentRunEmpty(t *testing.T) {
 // a board key). This is a static check, not a runtime call, so it
 // does not need a fully-initialised Model.
 func TestRunsKeyHandlersCoverBindings(t *testing.T) {
-	doc := "j/k move  enter focus-pane  a answer-human  r retry  x cancel  R refresh  esc/q close"
-	for _, b := range []string{"j", "k", "enter", "a", "r", "x", "R", "esc", "q"} {
+	doc := "j/k move  enter focus-pane  i timeline  s sessions  l transcript  a answer-human  r retry  x cancel  R refresh  esc/q close"
+	for _, b := range []string{"j", "k", "enter", "i", "s", "l", "a", "r", "x", "R", "esc", "q"} {
 		if !contains(doc, b) {
 			t.Errorf("binding %q not mentioned in docs %q", b, doc)
 		}
 	}
 }
 
+// TestRenderRunTimeline asserts the detail view shows each entry with
+// its step/attempt and duration, and prints attempt errors so "attempt
+// 3 of step revise failed because ..." is visible without leaving the tab.
+func TestRenderRunTimeline(t *testing.T) {
+	m := model{Runs: &RunsTabState{
+		Loaded: true,
+		Rows:   []RunRow{{RunID: "run-aaaa", Status: "needs_attention"}},
+		Timeline: &RunTimelineState{
+			RunID:  "run-aaaa",
+			Status: "needs_attention",
+			Loaded: true,
+			Rows: []RunTimelineRow{
+				{Kind: "step_attempt", StepID: "revise", Attempt: 3, Status: "failed", Summary: "revise attempt 3", Duration: 65 * time.Second, Error: "tests failed"},
+				{Kind: "execution", StepID: "revise", Attempt: 3, Status: "running", Summary: "agent execution", Open: true},
+			},
+		},
+	}}
+	out := m.renderRunsModal()
+	for _, want := range []string{"revise#3", "1m5s", "error: tests failed", "open", "esc/q back"} {
+		if !contains(out, want) {
+			t.Fatalf("expected %q in timeline output, got: %q", want, out)
+		}
+	}
+}
+
+// TestRunTimelineKeysReturnToList verifies esc in the detail view goes
+// back to the run list instead of closing the Runs tab.
+func TestRunTimelineKeysReturnToList(t *testing.T) {
+	m := model{Mode: ModeRuns, Runs: &RunsTabState{
+		Loaded:   true,
+		Rows:     []RunRow{{RunID: "run-aaaa"}},
+		Timeline: &RunTimelineState{RunID: "run-aaaa", Loaded: true, Rows: make([]RunTimelineRow, 3)},
+	}}
+	got, _ := m.handleRunsKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	gm := got.(model)
+	if gm.Runs.Timeline.Scroll != 1 {
+		t.Fatalf("scroll = %d, want 1", gm.Runs.Timeline.Scroll)
+	}
+	got, _ = gm.handleRunsKey(tea.KeyMsg{Type: tea.KeyEsc})
+	gm = got.(model)
+	if gm.Mode != ModeRuns || gm.Runs == nil || gm.Runs.Timeline != nil {
+		t.Fatalf("esc should close the timeline only, got mode=%q runs=%+v", gm.Mode, gm.Runs)
+	}
+}
+
+// TestDaemonHandshakeFlagsStaleDaemon verifies a stale-daemon handshake
+// surfaces in the Runs header with the restart hint, while other
+// handshake errors are left to the run load.
+func TestDaemonHandshakeFlagsStaleDaemon(t *testing.T) {
+	m := model{Runs: &RunsTabState{Loaded: true}}
+	got, _ := m.handleDaemonHandshake(daemonHandshakeMsg{err: errors.New("connection refused")})
+	if gm := got.(model); gm.Runs.StaleDaemon != "" {
+		t.Fatalf("transport error should not flag a stale daemon: %q", gm.Runs.StaleDaemon)
+	}
+
+	stale := fmt.Errorf("%w: daemon dev api 0, client dev api 1", daemon.ErrStaleDaemon)
+	got, _ = m.handleDaemonHandshake(daemonHandshakeMsg{err: stale})
+	gm := got.(model)
+	if gm.Runs.StaleDaemon == "" || gm.ToastKind != "warning" {
+		t.Fatalf("expected stale flag and warning toast, got %+v / %q", gm.Runs, gm.ToastKind)
+	}
+	if out := gm.renderRunsModal(); !contains(out, "D restart") {
+		t.Fatalf("expected restart hint in header, got: %q", out)
+	}
+}
+
 // TestFocusSelectedRunPaneRequiresPaneID verifies that pressing Enter
 // on a run with no pane reference surfaces a toast instead of
 // silently doing nothing. The pane reference is the hard contract
@@ -220,4 +296,112 @@ func contains(s, sub string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+// TestRunSessionsView covers the sessions view: rows render with their
+// fork state, a failed clear keeps the loaded rows, a successful action
+// replaces them, and esc returns to the run list.
+func TestRunSessionsView(t *testing.T) {
+	m := model{Mode: ModeRuns, Runs: &RunsTabState{
+		Loaded: true,
+		Rows:   []RunRow{{RunID: "run-aaaa"}},
+		Sessions: &RunSessionsState{
+			RunID:  "run-aaaa",
+			Loaded: true,
+			Rows: []RunSessionRow{
+				{RoleID: "coder", SessionID: "sid-2", ForkedFrom: "sid-1"},
+				{RoleID: "reviewer", SessionID: "sid-9", ForkPending: true},
+			},
+		},
+	}}
+	out := m.renderRunsModal()
+	for _, want := range []string{"coder", "forked from sid-1", "[fork pending]", "c clear  f fork"} {
+		if !contains(out, want) {
+			t.Fatalf("expected %q in sessions output, got: %q", want, out)
+		}
+	}
+
+	got, _ := m.handleRunsKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	gm := got.(model)
+	if se := gm.currentSession(); se == nil || se.RoleID != "reviewer" {
+		t.Fatalf("selection after j = %+v", se)
+	}
+
+	got, _ = gm.handleRunSessionsLoaded(runSessionsLoadedMsg{runID: "run-aaaa", action: "clear", err: errors.New("boom")})
+	gm = got.(model)
+	if len(gm.Runs.Sessions.Rows) != 2 || gm.ToastKind != "warning" {
+		t.Fatalf("failed clear should keep rows and warn, got %+v / %q", gm.Runs.Sessions, gm.ToastKind)
+	}
+	got, _ = gm.handleRunSessionsLoaded(runSessionsLoadedMsg{runID: "run-aaaa", action: "clear", rows: []RunSessionRow{{RoleID: "coder", SessionID: "sid-2"}}})
+	gm = got.(model)
+	if len(gm.Runs.Sessions.Rows) != 1 || gm.Runs.Sessions.Index != 0 || gm.ToastKind != "success" {
+		t.Fatalf("clear should replace rows and clamp selection, got %+v / %q", gm.Runs.Sessions, gm.ToastKind)
+	}
+
+	got, _ = gm.handleRunsKey(tea.KeyMsg{Type: tea.KeyEsc})
+	gm = got.(model)
+	if gm.Mode != ModeRuns || gm.Runs == nil || gm.Runs.Sessions != nil {
+		t.Fatalf("esc should close the sessions view only, got mode=%q runs=%+v", gm.Mode, gm.Runs)
+	}
+}
+
+// TestRunTranscriptView feeds TailExecutionLog chunks into the transcript
+// view: a stream-json line split across chunks renders once complete,
+// tool calls and results get their markers, eof stops the stream, and esc
+// cancels it and returns to the run list.
+func TestRunTranscriptView(t *testing.T) {
+	cancelled := false
+	m := model{Mode: ModeRuns, Width: 120, Runs: &RunsTabState{
+		Loaded: true,
+		Rows:   []RunRow{{RunID: "run-aaaa", TranscriptExecID: "exec-1"}},
+		Transcript: &RunTranscriptState{
+			ExecutionID: "exec-1",
+			RunID:       "run-aaaa",
+			Follow:      true,
+			cancel:      func() { cancelled = true },
+		},
+	}}
+	line1 := `{"type":"assistant","message":{"content":[{"type":"text","text":"looking at it"},{"type":"tool_use","name":"Read","input":{"path":"a.go"}}]}}` + "\n"
+	line2 := `{"type":"user","message":{"content":[{"type":"tool_result","content":"package a"}]}}` + "\n"
+
+	got, cmd := m.handleTranscriptChunk(transcriptChunkMsg{execID: "exec-1", chunk: &daemonpb.ExecutionLogChunk{Data: []byte(line1[:20]), Status: "running"}})
+	gm := got.(model)
+	if cmd == nil || len(gm.Runs.Transcript.Lines) != 0 || gm.Runs.Transcript.Offset != 20 {
+		t.Fatalf("partial line should wait for the rest, got %+v", gm.Runs.Transcript)
+	}
+	got, _ = gm.handleTranscriptChunk(transcriptChunkMsg{execID: "exec-1", chunk: &daemonpb.ExecutionLogChunk{Data: []byte(line1[20:] + line2), Offset: 20, Status: "running"}})
+	gm = got.(model)
+	out := gm.renderRunsModal()
+	for _, want := range []string{"[following]", "    looking at it", `  → Read {"path":"a.go"}`, "  ← package a"} {
+		if !contains(out, want) {
+			t.Fatalf("expected %q in transcript output, got: %q", want, out)
+		}
+	}
+
+	// A chunk for another execution (a stale stream) is dropped.
+	got, _ = gm.handleTranscriptChunk(transcriptChunkMsg{execID: "exec-0", chunk: &daemonpb.ExecutionLogChunk{Data: []byte("stale\n")}})
+	gm = got.(model)
+	if len(gm.Runs.Transcript.Lines) != 3 {
+		t.Fatalf("stale chunk was applied: %+v", gm.Runs.Transcript.Lines)
+	}
+
+	end := int64(len(line1) + len(line2))
+	got, cmd = gm.handleTranscriptChunk(transcriptChunkMsg{execID: "exec-1", chunk: &daemonpb.ExecutionLogChunk{Offset: end, Eof: true, Status: "succeeded"}})
+	gm = got.(model)
+	if cmd != nil || !gm.Runs.Transcript.Done || !cancelled || !contains(gm.renderRunsModal(), "succeeded  [done]") {
+		t.Fatalf("eof should finish the view, got %+v cancelled=%v", gm.Runs.Transcript, cancelled)
+	}
+
+	cancelled = false
+	got, _ = gm.handleRunsKey(tea.KeyMsg{Type: tea.KeyEsc})
+	gm = got.(model)
+	if gm.Mode != ModeRuns || gm.Runs == nil || gm.Runs.Transcript != nil || !cancelled {
+		t.Fatalf("esc should cancel and close the transcript only, got runs=%+v cancelled=%v", gm.Runs, cancelled)
+	}
+
+	gm.Runs.Rows[0].TranscriptExecID = ""
+	got, _ = gm.handleRunsKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	gm = got.(model)
+	if gm.Runs.Transcript != nil || gm.ToastKind != "warning" {
+		t.Fatalf("l without a transcript should warn, got %+v / %q", gm.Runs.Transcript, gm.ToastKind)
+	}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SavedView is a named search query, stored per project in bd kv.
+type SavedView struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// activeView is the index of the saved view the search query is, or -1.
+func (m model) activeView() int {
+	if m.SearchQuery == "" {
+		return -1
+	}
+	for i, view := range m.Views {
+		if view.Query == m.SearchQuery {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyView makes saved view i the search query.
+func (m *model) applyView(i int) tea.Cmd {
+	if i < 0 || i >= len(m.Views) {
+		m.setToast("warning", fmt.Sprintf("no saved view %d", i+1))
+		return nil
+	}
+	view := m.Views[i]
+	m.SearchQuery = view.Query
+	m.SearchInput.SetValue(view.Query)
+	m.SearchInput.CursorEnd()
+	m.relayoutBoard()
+	m.setToast("info", "view: "+view.Name)
+	return m.fullDatasetCmd()
+}
+
+// saveView saves the search query under name, replacing a view of that
+// name.
+func (m *model) saveView(name string) tea.Cmd {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		m.setToast("warning", "view name is required")
+		return nil
+	}
+	if m.SearchQuery == "" {
+		m.setToast("warning", "search is empty, nothing to save")
+		return nil
+	}
+	views := append([]SavedView(nil), m.Views...)
+	view := SavedView{Name: name, Query: m.SearchQuery}
+	replaced := false
+	for i := range views {
+		if views[i].Name == name {
+			views[i] = view
+			replaced = true
+		}
+	}
+	if !replaced {
+		views = append(views, view)
+	}
+	m.Views = views
+	return persistViewsCmd(m.Client, views, "view saved: "+name)
+}
+
+// deleteView drops saved view i.
+func (m *model) deleteView(i int) tea.Cmd {
+	if i < 0 || i >= len(m.Views) {
+		return nil
+	}
+	name := m.Views[i].Name
+	views := append(append([]SavedView(nil), m.Views[:i]...), m.Views[i+1:]...)
+	m.Views = views
+	return persistViewsCmd(m.Client, views, "view deleted: "+name)
+}
+
+func persistViewsCmd(client *BdClient, views []SavedView, info string) tea.Cmd {
+	if client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return viewsPersistMsg{info: info, err: client.SetViews(views)}
+	}
+}
+
+// openSaveViewPrompt asks for the name to save the search query under.
+func (m model) openSaveViewPrompt() (tea.Model, tea.Cmd) {
+	m.applyFilterForm()
+	m.SearchExpanded = false
+	m.SearchInput.Blur()
+	if m.SearchQuery == "" {
+		m.Mode = ModeBoard
+		m.setToast("warning", "search is empty, nothing to save")
+		return m, nil
+	}
+	initial := ""
+	if i := m.activeView(); i >= 0 {
+		initial = m.Views[i].Name
+	}
+	m.Prompt = newPrompt(ModePrompt, "Save View", fmt.Sprintf("Name for %q:", m.SearchQuery), "", PromptSaveView, initial)
+	m.Mode = ModePrompt
+	return m, nil
+}
+
+func (m model) handleViewsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "v":
+		m.Mode = ModeBoard
+	case "j", "down":
+		if m.ViewsIndex < len(m.Views)-1 {
+			m.ViewsIndex++
+		}
+	case "k", "up":
+		if m.ViewsIndex > 0 {
+			m.ViewsIndex--
+		}
+	case "enter":
+		m.Mode = ModeBoard
+		return m, m.applyView(m.ViewsIndex)
+	case "d":
+		cmd := m.deleteView(m.ViewsIndex)
+		m.ViewsIndex = min(m.ViewsIndex, max(0, len(m.Views)-1))
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m model) renderViewsModal() string {
+	lines := []string{"Saved Views", ""}
+	if len(m.Views) == 0 {
+		lines = append(lines, m.Styles.Dim.Render("no saved views; press Ctrl+S in search to save one"))
+	}
+	active := m.activeView()
+	for i, view := range m.Views {
+		hotkey := " "
+		if i < 9 {
+			hotkey = fmt.Sprint(i + 1)
+		}
+		mark := ""
+		if i == active {
+			mark = " (active)"
+		}
+		var line string
+		if i == m.ViewsIndex {
+			line = m.Styles.Selected.Render(fmt.Sprintf("%s  %s  %s%s", hotkey, view.Name, view.Query, mark))
+		} else {
+			line = fmt.Sprintf("%s  %s  %s%s", hotkey, view.Name, m.Styles.Dim.Render(view.Query), mark)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", "Enter apply | d delete | 1-9 on the board | Esc close")
+	return strings.Join(lines, "\n")
+}
+
+// completeSearch replaces the term at the cursor with its first
+// completion.
+func (m *model) completeSearch() bool {
+	input, cursor := m.searchCursor()
+	start, options := queryCompletions(input, cursor, m.Issues)
+	if len(options) == 0 {
+		return false
+	}
+	completed := input[:start] + options[0]
+	if !strings.HasSuffix(completed, ":") {
+		completed += " "
+	}
+	m.SearchInput.SetValue(completed + input[cursor:])
+	m.SearchInput.SetCursor(utf8.RuneCountInString(completed))
+	return true
+}
+
+// searchCursor returns the search input and the byte offset of its
+// cursor, which the input keeps in runes.
+func (m model) searchCursor() (string, int) {
+	input := m.SearchInput.Value()
+	runes := []rune(input)
+	pos := min(max(0, m.SearchInput.Position()), len(runes))
+	return input, len(string(runes[:pos]))
+}
+
+// searchHint is shown after the search input: the completions of the term
+// at the cursor, or why the query does not parse.
+func (m model) searchHint() string {
+	const shown = 5
+	if _, err := parseQuery(m.SearchInput.Value()); err != nil {
+		return m.Styles.Warning.Render("! " + err.Error())
+	}
+	input, cursor := m.searchCursor()
+	_, options := queryCompletions(input, cursor, m.Issues)
+	if len(options) == 0 {
+		return ""
+	}
+	if len(options) > shown {
+		options = append(options[:shown:shown], "…")
+	}
+	return m.Styles.Dim.Render("Tab " + strings.Join(options, " "))
+}
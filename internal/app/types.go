@@ -1,9 +1,12 @@
 package app
 
 import (
+	"context"
 	"strings"
 	"time"
 
+	"bdtui/internal/agent"
+
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
@@ -56,6 +59,12 @@ type Issue struct {
 	Children  []string
 	BlockedBy []string
 	Blocks    []string
+
+	// Run is the task's run state, attached by renderColumn for the card
+	// badge; it never comes from bd.
+	Run *RunBadge
+	// Pending is set while a queued op on the issue has not reached bd.
+	Pending bool
 }
 
 type Filter struct {
@@ -64,6 +73,10 @@ type Filter struct {
 	Status   string
 	Priority string
 	Type     string
+	// Run narrows the board by orchestrator run state: "active" keeps
+	// tasks with an unfinished run, "attention" those waiting on the
+	// operator. "" or "any" keeps everything.
+	Run string
 }
 
 type SortMode string
@@ -117,12 +130,17 @@ func (f Filter) IsEmpty() bool {
 	if issueType == "" {
 		issueType = "any"
 	}
+	run := strings.TrimSpace(strings.ToLower(f.Run))
+	if run == "" {
+		run = "any"
+	}
 
 	return strings.TrimSpace(f.Assignee) == "" &&
 		strings.TrimSpace(f.Label) == "" &&
 		status == "any" &&
 		priority == "any" &&
-		issueType == "any"
+		issueType == "any" &&
+		run == "any"
 }
 
 type Mode string
@@ -146,6 +164,8 @@ const (
 	ModeConfirmClosedParentCreate Mode = "confirm_closed_parent_create"
 	ModeWorkflowPicker            Mode = "workflow_picker"
 	ModeRuns                      Mode = "runs"
+	ModeHistory                   Mode = "history"
+	ModeViews                     Mode = "views"
 )
 
 // WorkflowOption is a single workflow available for Run launch.
@@ -157,9 +177,10 @@ type WorkflowOption struct {
 // WorkflowPickerState holds the workflow list shown when the user picks a
 // workflow to launch a Run for the selected task.
 type WorkflowPickerState struct {
-	TargetIssueID string
-	Options       []WorkflowOption
-	Index         int
+	TargetIssueID  string
+	TargetIssueIDs []string // marked issues for a bulk launch
+	Options        []WorkflowOption
+	Index          int
 }
 
 // RunRow is a single row in the Runs tab. It carries the rendered run
@@ -176,11 +197,24 @@ type RunRow struct {
 	Status             string
 	CurrentStepID      string
 	NeedsAttention     string
-	PaneID             string // most recent Execution's PaneID, or ""
-	WorkflowStageHint  string // human-readable hint, e.g. "review -> implement"
-	HasPendingHuman    bool   // true if Run is in waiting_human
-	PendingHumanID     string // first pending human_input id, or "" if none
-	PendingHumanPrompt string // prompt of the pending human_input, for the confirm prompt
+	PaneID             string  // most recent Execution's PaneID, or ""
+	WorkflowStageHint  string  // human-readable hint, e.g. "review -> implement"
+	HasPendingHuman    bool    // true if Run is in waiting_human
+	PendingHumanID     string  // first pending human_input id, or "" if none
+	PendingHumanPrompt string  // prompt of the pending human_input, for the confirm prompt
+	TranscriptExecID   string  // most recent Execution with a transcript, or ""
+	Tokens             int64   // input+output tokens used by the run's executions
+	CostUSD            float64 // cost reported by the run's executions
+	MaxTokens          int64   // token budget, 0 = none
+	MaxCostUSD         float64 // cost budget, 0 = none
+}
+
+// RunBadge is the coarse state of a task's current run as shown on its
+// board card: the task's unfinished run if it has one, else its latest.
+type RunBadge struct {
+	RunID  string
+	Status string
+	StepID string
 }
 
 // RunsTabState owns the Runs tab view: the rows fetched from the daemon,
@@ -192,6 +226,120 @@ type RunsTabState struct {
 	Loaded     bool
 	LastError  string
 	LoadingMsg string
+	// Timeline is the detail view for one run; nil while the list is
+	// shown. Opened with "i" and closed with esc.
+	Timeline *RunTimelineState
+	// Artifact is the artifact viewer opened from a timeline artifact
+	// row; it stacks on top of Timeline and esc returns to it.
+	Artifact *ArtifactViewState
+	// Transcript tails an execution's agent output. It is opened with "l"
+	// from the run list (latest execution) or from an execution row of
+	// the timeline, and stacks on top of whichever it came from.
+	Transcript *RunTranscriptState
+	// Sessions lists the selected run's agent sessions; opened with "s"
+	// from the run list, it lets the operator clear or fork one.
+	Sessions *RunSessionsState
+	// StaleDaemon is set when the version handshake found a daemon older
+	// than this TUI; the header offers "D" to restart it.
+	StaleDaemon string
+}
+
+// RunTimelineRow is one entry of a run's history as returned by
+// GetRunTimeline: a step attempt, execution, human input, artifact or
+// event, already ordered by the daemon.
+type RunTimelineRow struct {
+	Kind     string
+	ID       string
+	At       time.Time
+	Duration time.Duration
+	Open     bool // true while the row has not ended yet
+	StepID   string
+	Attempt  int
+	Status   string
+	Summary  string
+	Detail   string
+	Error    string
+}
+
+// ArtifactViewState owns the artifact viewer: the bytes fetched through
+// ReadArtifact plus the rendered lines, cached per wrap width.
+type ArtifactViewState struct {
+	ID         string
+	Name       string
+	Hash       string
+	Size       int64
+	Verified   bool
+	Truncated  bool // only the first artifactViewMaxBytes were fetched
+	Content    []byte
+	Lines      []string
+	LinesWidth int
+	Scroll     int
+	Loaded     bool
+	LastError  string
+	LoadingMsg string
+}
+
+// RunTimelineState owns the Runs tab detail view: the selected run's
+// timeline rows and the scroll offset into them.
+type RunTimelineState struct {
+	RunID      string
+	TaskID     string
+	Status     string
+	Rows       []RunTimelineRow
+	Scroll     int
+	Loaded     bool
+	LastError  string
+	LoadingMsg string
+}
+
+// RunTranscriptState owns the transcript view: the lines rendered so far
+// from a TailExecutionLog stream that keeps following the execution until
+// it finishes or the view closes.
+type RunTranscriptState struct {
+	ExecutionID string
+	RunID       string
+	Status      string
+	Lines       []TranscriptLine
+	Offset      int64 // transcript bytes received
+	Done        bool  // the execution finished and everything was received
+	Scroll      int
+	// Follow keeps the newest lines in view; scrolling up turns it off
+	// and G turns it back on.
+	Follow     bool
+	LastError  string
+	LoadingMsg string
+	partial    []byte // an incomplete last line, kept until its newline
+	cancel     context.CancelFunc
+}
+
+// TranscriptLine is one rendered line of the transcript view. Cont marks
+// the second and later lines of a multi-line entry.
+type TranscriptLine struct {
+	Kind    agent.TranscriptKind
+	Text    string
+	IsError bool
+	Cont    bool
+}
+
+// RunSessionRow is one role's agent session in a run, as returned by
+// ListSessions.
+type RunSessionRow struct {
+	RoleID      string
+	SessionID   string
+	ForkPending bool
+	ForkedFrom  string
+	UpdatedAt   time.Time
+}
+
+// RunSessionsState owns the Runs tab sessions view for one run.
+type RunSessionsState struct {
+	RunID      string
+	TaskID     string
+	Rows       []RunSessionRow
+	Index      int
+	Loaded     bool
+	LastError  string
+	LoadingMsg string
 }
 
 type PromptAction string
@@ -204,6 +352,7 @@ const (
 	PromptParentSet    PromptAction = "parent_set"
 	PromptCloseReason  PromptAction = "close_reason"
 	PromptReopenReason PromptAction = "reopen_reason"
+	PromptSaveView     PromptAction = "save_view"
 	// PromptAnswerHuman is used by the Runs tab to capture a typed
 	// response to a waiting_human HumanInput. The HumanInputID is
 	// stashed in PromptState.HumanInputID; RunID preserves the run
@@ -216,8 +365,9 @@ type PromptState struct {
 	Description  string
 	Action       PromptAction
 	TargetIssue  string
-	HumanInputID string // used when Action == PromptAnswerHuman
-	RunID        string // used when Action == PromptAnswerHuman (for the toast)
+	TargetIssues []string // marked issues for a bulk action
+	HumanInputID string   // used when Action == PromptAnswerHuman
+	RunID        string   // used when Action == PromptAnswerHuman (for the toast)
 	Input        textinput.Model
 }
 
@@ -251,11 +401,31 @@ type ParentOption struct {
 }
 
 type ParentPickerState struct {
-	TargetIssueID string
-	Options       []ParentOption
-	Index         int
+	TargetIssueID  string
+	TargetIssueIDs []string // marked issues for a bulk parent change
+	Options        []ParentOption
+	Index          int
+}
+
+// DragState tracks a card dragged with the mouse. DropStatus is the column
+// under the pointer; DropIssueID is the card under it when a modifier asks
+// for a parent (Ctrl) or blocker (Alt) drop instead of a status change.
+type DragState struct {
+	IssueID     string
+	FromStatus  Status
+	DropStatus  Status
+	DropIssueID string
+	Link        DropLink
+	Moved       bool
 }
 
+type DropLink string
+
+const (
+	DropLinkParent  DropLink = "parent"
+	DropLinkBlocker DropLink = "blocker"
+)
+
 type MuxPickerState struct {
 	IssueID string
 	Targets []MuxTarget
@@ -279,6 +449,7 @@ type FilterForm struct {
 	Status   string
 	Priority string
 	Type     string
+	Run      string
 	Input    textinput.Model
 }
 
@@ -319,6 +490,11 @@ type loadedMsg struct {
 	hash   string
 	err    error
 	source string
+	// window is what the load asked for; complete reports whether the
+	// issues are the whole board regardless.
+	window   IssueWindow
+	complete bool
+	started  time.Time // when the load began, to reconcile the op queue
 }
 
 type opMsg struct {
@@ -363,3 +539,17 @@ type sortModePersistMsg struct {
 	Mode SortMode
 	err  error
 }
+
+type boardLayoutPersistMsg struct {
+	err error
+}
+
+type viewsPersistMsg struct {
+	info string
+	err  error
+}
+
+type boardLayoutEditorMsg struct {
+	layout BoardLayout
+	err    error
+}
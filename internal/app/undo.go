@@ -0,0 +1,309 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoHistoryLimit caps how many operations u can walk back.
+const undoHistoryLimit = 50
+
+// opOrigin tells a new op from the replay of a history entry.
+type opOrigin int
+
+const (
+	opNew opOrigin = iota
+	opUndo
+	opRedo
+)
+
+// undoEntry is one undoable operation: do applies it again, undo reverts
+// it. Entries of one bulk operation share batch.
+type undoEntry struct {
+	label string
+	do    boardOp
+	undo  boardOp
+	batch *opBatch
+	at    time.Time
+}
+
+// recordHistory files a finished op in the undo history. A new op that bd
+// applied becomes undoable and drops the redo history. An undo or redo
+// moves its entry to the other stack, or back where it came from when bd
+// rejected it.
+func (m *model) recordHistory(op pendingOp, ok bool) {
+	if op.origin == opNew {
+		if !ok {
+			return
+		}
+		m.RedoStack = nil
+		if op.entry == nil {
+			return
+		}
+	}
+	entry := *op.entry
+	entry.at = time.Now()
+	switch {
+	case op.origin == opNew, op.origin == opRedo && ok, op.origin == opUndo && !ok:
+		m.UndoStack = append(m.UndoStack, entry)
+		if len(m.UndoStack) > undoHistoryLimit {
+			m.UndoStack = m.UndoStack[len(m.UndoStack)-undoHistoryLimit:]
+		}
+	default:
+		m.RedoStack = append(m.RedoStack, entry)
+	}
+}
+
+// undoCmd queues the inverse of the latest undoable operation.
+func (m *model) undoCmd() tea.Cmd {
+	if len(m.UndoStack) == 0 {
+		m.setToast("info", "nothing to undo")
+		return nil
+	}
+	return m.replayHistory(popHistory(&m.UndoStack), opUndo)
+}
+
+// redoCmd queues the latest undone operation again.
+func (m *model) redoCmd() tea.Cmd {
+	if len(m.RedoStack) == 0 {
+		m.setToast("info", "nothing to redo")
+		return nil
+	}
+	return m.replayHistory(popHistory(&m.RedoStack), opRedo)
+}
+
+// popHistory takes the latest entry off stack, together with the other
+// entries of its bulk operation.
+func popHistory(stack *[]undoEntry) []undoEntry {
+	entries := *stack
+	n := len(entries) - 1
+	if batch := entries[n].batch; batch != nil {
+		for n > 0 && entries[n-1].batch == batch {
+			n--
+		}
+	}
+	group := append([]undoEntry(nil), entries[n:]...)
+	*stack = entries[:n]
+	return group
+}
+
+// replayHistory queues the undo or redo side of each entry, latest first.
+func (m *model) replayHistory(group []undoEntry, origin opOrigin) tea.Cmd {
+	prefix := "undone: "
+	if origin == opRedo {
+		prefix = "redone: "
+	}
+	var batch *opBatch
+	if b := group[0].batch; b != nil {
+		batch = &opBatch{label: prefix + b.label}
+	}
+	var cmds []tea.Cmd
+	for i := len(group) - 1; i >= 0; i-- {
+		entry := group[i]
+		op := entry.do
+		if origin == opUndo {
+			op = entry.undo
+		}
+		op.label = prefix + entry.label
+		cmds = append(cmds, m.queueOp(op, origin, &entry, batch))
+	}
+	return tea.Batch(cmds...)
+}
+
+// inverseUpdateParams is the update that restores the fields p changes to
+// their values on issue.
+func inverseUpdateParams(issue Issue, p UpdateParams) UpdateParams {
+	inv := UpdateParams{ID: p.ID}
+	if p.Title != nil {
+		v := issue.Title
+		inv.Title = &v
+	}
+	if p.Description != nil {
+		v := issue.Description
+		inv.Description = &v
+	}
+	if p.Notes != nil {
+		v := issue.Notes
+		inv.Notes = &v
+	}
+	if p.Status != nil {
+		v := issue.Status
+		inv.Status = &v
+	}
+	if p.Priority != nil {
+		v := issue.Priority
+		inv.Priority = &v
+	}
+	if p.IssueType != nil {
+		v := issue.IssueType
+		inv.IssueType = &v
+	}
+	if p.Assignee != nil {
+		v := issue.Assignee
+		inv.Assignee = &v
+	}
+	if p.Labels != nil {
+		v := append([]string{}, issue.Labels...)
+		inv.Labels = &v
+	}
+	if p.Parent != nil {
+		v := issue.Parent
+		inv.Parent = &v
+	}
+	return inv
+}
+
+// closeBoardOp sets the description of issue id and closes it.
+func closeBoardOp(label, id, description string) boardOp {
+	return boardOp{label: label, id: id, apply: func(issue *Issue) {
+		issue.Description = description
+		setIssueStatus(issue, StatusClosed)
+	}, run: func(c *BdClient) error {
+		if err := c.UpdateIssue(UpdateParams{ID: id, Description: &description}); err != nil {
+			return err
+		}
+		return c.CloseIssue(id)
+	}}
+}
+
+// reopenBoardOp sets the description of a closed issue and reopens it
+// with the status of to.
+func reopenBoardOp(label string, to Issue) boardOp {
+	return boardOp{label: label, id: to.ID, apply: func(issue *Issue) {
+		issue.Description = to.Description
+		setIssueStatus(issue, to.Status)
+	}, run: func(c *BdClient) error {
+		if err := c.UpdateIssue(UpdateParams{ID: to.ID, Description: &to.Description}); err != nil {
+			return err
+		}
+		if err := c.ReopenIssue(to.ID); err != nil {
+			return err
+		}
+		if to.Status == StatusOpen {
+			return nil
+		}
+		return c.UpdateIssue(UpdateParams{ID: to.ID, Status: &to.Status})
+	}}
+}
+
+// depOps are the ops that remove and then add blockers of id. Swapping
+// the lists gives the inverse.
+func depOps(label, id string, remove, add []string) boardOp {
+	return boardOp{label: label, id: id, run: func(c *BdClient) error {
+		for _, blockerID := range remove {
+			if err := c.DepRemove(id, blockerID); err != nil {
+				return err
+			}
+		}
+		for _, blockerID := range add {
+			if err := c.DepAdd(id, blockerID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+// recreateIssueOp creates a deleted issue again under its old id, with
+// its fields, status and the blockers it had and blocked. Blockers that
+// were already closed are not known to the board and are not restored.
+func recreateIssueOp(label string, issue Issue) boardOp {
+	return boardOp{label: label, id: issue.ID, run: func(c *BdClient) error {
+		if _, err := c.CreateIssue(CreateParams{
+			ID:          issue.ID,
+			Title:       issue.Title,
+			Description: issue.Description,
+			Priority:    issue.Priority,
+			IssueType:   issue.IssueType,
+			Assignee:    issue.Assignee,
+			Labels:      issue.Labels,
+			Parent:      issue.Parent,
+		}); err != nil {
+			return err
+		}
+		upd := UpdateParams{ID: issue.ID}
+		if issue.Notes != "" {
+			upd.Notes = &issue.Notes
+		}
+		if issue.Status != StatusOpen {
+			upd.Status = &issue.Status
+		}
+		if upd.Notes != nil || upd.Status != nil {
+			if err := c.UpdateIssue(upd); err != nil {
+				return err
+			}
+		}
+		for _, blockerID := range issue.BlockedBy {
+			if err := c.DepAdd(issue.ID, blockerID); err != nil {
+				return err
+			}
+		}
+		for _, blockedID := range issue.Blocks {
+			if err := c.DepAdd(blockedID, issue.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+func (m model) handleHistoryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "U":
+		m.HistoryScroll = 0
+		m.Mode = ModeBoard
+	case "j", "down":
+		if m.HistoryScroll < len(m.UndoStack)+len(m.RedoStack)-1 {
+			m.HistoryScroll++
+		}
+	case "k", "up":
+		if m.HistoryScroll > 0 {
+			m.HistoryScroll--
+		}
+	case "u":
+		return m, m.undoCmd()
+	case "ctrl+r":
+		return m, m.redoCmd()
+	}
+	return m, nil
+}
+
+// historyLines lists the undone operations, latest redo last, above the
+// undoable ones, newest first.
+func (m model) historyLines() []string {
+	var lines []string
+	for _, entry := range m.RedoStack {
+		lines = append(lines, m.Styles.Dim.Render(fmt.Sprintf("  %s  %s (undone)", entry.at.Format("15:04:05"), entry.label)))
+	}
+	for i := len(m.UndoStack) - 1; i >= 0; i-- {
+		entry := m.UndoStack[i]
+		marker := "  "
+		if i == len(m.UndoStack)-1 {
+			marker = "▸ "
+		}
+		lines = append(lines, fmt.Sprintf("%s%s  %s", marker, entry.at.Format("15:04:05"), entry.label))
+	}
+	return lines
+}
+
+func (m model) renderHistoryModal() string {
+	maxLines := 18
+	if m.Height > 24 {
+		maxLines = m.Height - 8
+	}
+
+	entries := m.historyLines()
+	if len(entries) == 0 {
+		entries = []string{m.Styles.Dim.Render("no operations yet")}
+	}
+	start := min(max(0, m.HistoryScroll), max(0, len(entries)-1))
+	end := min(len(entries), start+maxLines)
+
+	lines := []string{"History", ""}
+	lines = append(lines, entries[start:end]...)
+	lines = append(lines, "", "u undo | Ctrl+R redo | j/k scroll | Esc close")
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUndoRedoPriorityChange(t *testing.T) {
+	m := opQueueModel(Issue{ID: "a", Title: "a", Status: StatusOpen, Display: StatusOpen, Priority: 2})
+
+	next, _ := m.handleBoardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = next.(model)
+	next, _ = m.handleOpDone(opDoneMsg{seq: m.OpSeq})
+	m = next.(model)
+	if len(m.UndoStack) != 1 {
+		t.Fatalf("undo stack = %d entries, want 1", len(m.UndoStack))
+	}
+
+	next, cmd := m.handleBoardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m = next.(model)
+	if cmd == nil || m.ByID["a"].Priority != 2 {
+		t.Fatalf("undo should restore P2, got P%d", m.ByID["a"].Priority)
+	}
+	next, _ = m.handleOpDone(opDoneMsg{seq: m.OpSeq})
+	m = next.(model)
+	if len(m.UndoStack) != 0 || len(m.RedoStack) != 1 {
+		t.Fatalf("after undo: undo %d, redo %d", len(m.UndoStack), len(m.RedoStack))
+	}
+
+	next, _ = m.handleBoardKey(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = next.(model)
+	if m.ByID["a"].Priority != 3 {
+		t.Fatalf("redo should apply P3 again, got P%d", m.ByID["a"].Priority)
+	}
+	next, _ = m.handleOpDone(opDoneMsg{seq: m.OpSeq, err: errors.New("bd rejected")})
+	m = next.(model)
+	if len(m.RedoStack) != 1 || m.ByID["a"].Priority != 2 {
+		t.Fatal("a rejected redo should stay redoable and roll back")
+	}
+
+	next, _ = m.handleBoardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = next.(model)
+	next, _ = m.handleOpDone(opDoneMsg{seq: m.OpSeq})
+	m = next.(model)
+	if len(m.RedoStack) != 0 || len(m.UndoStack) != 1 {
+		t.Fatal("a new change should drop the redo history")
+	}
+
+	next, _ = m.handleBoardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	m = next.(model)
+	if out := m.renderHistoryModal(); m.Mode != ModeHistory || !strings.Contains(out, "a: status -> in_progress") {
+		t.Fatalf("history modal = %q", out)
+	}
+}
+
+func TestUndoCloseAndDelete(t *testing.T) {
+	marker := fakeBdOnPath(t, "")
+	m := opQueueModel(
+		Issue{ID: "a", Title: "a", Description: "desc", Status: StatusInProgress, Display: StatusInProgress},
+		Issue{ID: "b", Title: "gone", Status: StatusOpen, Display: StatusOpen, Priority: 1, BlockedBy: []string{"a"}},
+	)
+	m.Client = NewBdClient(t.TempDir())
+
+	m.submitPrompt("a", PromptCloseReason, "done")
+	next, _ := m.handleOpDone(opDoneMsg{seq: m.OpSeq})
+	m = next.(model)
+	m.undoCmd()
+	if got := m.ByID["a"]; got.Display != StatusInProgress || got.Description != "desc" {
+		t.Fatalf("undo close preview = %+v", got)
+	}
+
+	next, _ = m.handleOpDone(opDoneMsg{seq: m.OpSeq})
+	m = next.(model)
+	m.ConfirmDelete = &ConfirmDelete{IssueID: "b", Mode: DeleteModeForce}
+	next, _ = m.handleDeleteConfirmKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	next, _ = m.handleOpDone(opDoneMsg{seq: m.OpSeq})
+	m = next.(model)
+	if err := m.UndoStack[len(m.UndoStack)-1].undo.run(m.Client); err != nil {
+		t.Fatalf("recreate: %v", err)
+	}
+	calls, err := os.ReadFile(marker)
+	if err != nil {
+		t.Fatalf("bd was not run: %v", err)
+	}
+	if !strings.Contains(string(calls), "create gone --silent --id b -p 1") || !strings.Contains(string(calls), "dep add b a") {
+		t.Fatalf("bd calls = %q", calls)
+	}
+}
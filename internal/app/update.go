@@ -13,7 +13,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.Width = msg.Width
 		m.Height = msg.Height
-		for _, st := range statusOrder {
+		for _, st := range m.columnKeys() {
 			m.ensureSelectionVisible(st)
 		}
 		m.clampDetailsScroll()
@@ -41,6 +41,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 
 	case loadedMsg:
+		if msg.source == "prefetch" {
+			return m.handleClosedPrefetch(msg)
+		}
+		if msg.window.ClosedSince.IsZero() {
+			m.LoadingFull = false
+		}
+		if !msg.complete && msg.window != m.loadWindow() {
+			// A load for another window is already on its way.
+			return m, nil
+		}
 		if msg.err != nil {
 			if msg.source != "tick" {
 				m.setToast("error", msg.err.Error())
@@ -53,11 +63,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		m.applyLoadedIssues(msg.Issues, msg.hash)
+		m.reconcileOps(msg.started)
+		m.applyLoadedIssues(msg.Issues, msg.hash, msg.complete)
 		if msg.source == "manual" || msg.source == "mutation" {
 			m.setToast("success", "data refreshed")
 		}
-		return m, nil
+		return m, m.prefetchClosedWindowCmd()
+
+	case opDoneMsg:
+		return m.handleOpDone(msg)
 
 	case opMsg:
 		if msg.err != nil {
@@ -90,6 +104,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.setToast("success", fmt.Sprintf("sort mode: %s", msg.Mode.Label()))
 		return m, nil
 
+	case boardLayoutPersistMsg:
+		if msg.err != nil {
+			m.setToast("warning", "board layout changed but not saved: "+msg.err.Error())
+			return m, nil
+		}
+		m.setToast("success", "board layout saved")
+		return m, nil
+
+	case viewsPersistMsg:
+		if msg.err != nil {
+			m.setToast("warning", "views changed but not saved: "+msg.err.Error())
+			return m, nil
+		}
+		m.setToast("success", msg.info)
+		return m, nil
+
+	case boardLayoutEditorMsg:
+		return m.applyEditedLayout(msg)
+
 	case FormEditorMsg:
 		return m.Update(msg.toInternal())
 
@@ -153,9 +186,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case runsLoadedMsg:
 		return m.handleRunsLoadedMsg(msg)
 
+	case runStateMsg:
+		return m.handleRunState(msg)
+
+	case runStatePollMsg:
+		return m, m.loadRunStateCmd()
+
 	case runsActionMsg:
 		return m.handleRunsActionMsg(msg)
 
+	case runTimelineLoadedMsg:
+		return m.handleRunTimelineLoaded(msg)
+
+	case artifactLoadedMsg:
+		return m.handleArtifactLoaded(msg)
+
+	case runSessionsLoadedMsg:
+		return m.handleRunSessionsLoaded(msg)
+
+	case transcriptChunkMsg:
+		return m.handleTranscriptChunk(msg)
+
+	case daemonHandshakeMsg:
+		return m.handleDaemonHandshake(msg)
+
+	case daemonRestartedMsg:
+		return m.handleDaemonRestarted(msg)
+
 	case deletePreviewMsg:
 		if msg.err != nil {
 			m.setToast("error", msg.err.Error())
@@ -46,6 +46,10 @@ func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleRunsKey(msg)
 	case ModeDepList:
 		return m.handleDepListKey(msg)
+	case ModeHistory:
+		return m.handleHistoryKey(msg)
+	case ModeViews:
+		return m.handleViewsKey(msg)
 	case ModeConfirmDelete:
 		return m.handleDeleteConfirmKey(msg)
 	case ModeConfirmClosedParentCreate:
@@ -227,6 +231,8 @@ func (m model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+f":
 		m.SearchExpanded = true
 		return m, nil
+	case "ctrl+s":
+		return m.openSaveViewPrompt()
 	case "up":
 		if m.SearchExpanded {
 			m.shiftSearchFilterField(-1)
@@ -240,22 +246,32 @@ func (m model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "tab", "ctrl+i":
 		if m.SearchExpanded {
 			m.cycleSearchFilterValue(1)
+			return m, m.fullDatasetCmd()
+		}
+		if !m.completeSearch() {
 			return m, nil
 		}
+		return m.searchInputChanged(nil)
 	case "shift+tab":
 		if m.SearchExpanded {
 			m.cycleSearchFilterValue(-1)
-			return m, nil
+			return m, m.fullDatasetCmd()
 		}
 	}
 
 	var cmd tea.Cmd
 	m.SearchInput, cmd = m.SearchInput.Update(msg)
+	return m.searchInputChanged(cmd)
+}
+
+// searchInputChanged filters the board by the edited search input.
+func (m model) searchInputChanged(cmd tea.Cmd) (tea.Model, tea.Cmd) {
 	nextQuery := strings.TrimSpace(m.SearchInput.Value())
 	if nextQuery != m.SearchQuery {
 		m.SearchQuery = nextQuery
 		m.computeColumns()
 		m.normalizeSelectionBounds()
+		cmd = tea.Batch(cmd, m.fullDatasetCmd())
 	}
 	return m, cmd
 }
@@ -269,6 +285,7 @@ func (m *model) clearSearchAndFilters() {
 		Status:   "any",
 		Priority: "any",
 		Type:     "any",
+		Run:      "any",
 	}
 	if m.FilterForm != nil {
 		m.FilterForm = newFilterForm(m.Filter)
@@ -291,6 +308,9 @@ func (m *model) applyFilterForm() {
 	if m.Filter.Type == "" {
 		m.Filter.Type = "any"
 	}
+	if m.Filter.Run == "" {
+		m.Filter.Run = "any"
+	}
 	m.computeColumns()
 	m.normalizeSelectionBounds()
 }
@@ -353,6 +373,8 @@ func (m model) searchFilterFieldValue(field string) string {
 		return m.FilterForm.Priority
 	case "type":
 		return m.FilterForm.Type
+	case "run":
+		return m.FilterForm.Run
 	default:
 		return "any"
 	}
@@ -369,6 +391,8 @@ func (m *model) setSearchFilterFieldValue(field string, value string) {
 		m.FilterForm.Priority = value
 	case "type":
 		m.FilterForm.Type = value
+	case "run":
+		m.FilterForm.Run = value
 	}
 }
 
@@ -380,6 +404,8 @@ func (m model) searchFilterOptions(field string) []string {
 		return []string{"any", "0", "1", "2", "3", "4"}
 	case "type":
 		return []string{"any", "task", "epic", "bug", "feature", "chore", "decision"}
+	case "run":
+		return runFilterOptions()
 	default:
 		return nil
 	}
@@ -407,12 +433,15 @@ func (m model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.Filter.Type == "" {
 			m.Filter.Type = "any"
 		}
+		if m.Filter.Run == "" {
+			m.Filter.Run = "any"
+		}
 		m.computeColumns()
 		m.normalizeSelectionBounds()
 		m.Mode = ModeBoard
 		m.FilterForm = nil
 		m.setToast("success", "filters applied")
-		return m, nil
+		return m, m.fullDatasetCmd()
 	case "tab":
 		m.FilterForm.nextField()
 		return m, nil
@@ -433,6 +462,7 @@ func (m model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.FilterForm.Status = "any"
 		m.FilterForm.Priority = "any"
 		m.FilterForm.Type = "any"
+		m.FilterForm.Run = "any"
 		m.FilterForm.loadInput()
 		return m, nil
 	}
@@ -539,6 +569,9 @@ func (m model) handlePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// after the answer is sent.
 			m.Mode = ModeRuns
 			cmd = m.submitAnswerHuman(m.Prompt.HumanInputID, m.Prompt.RunID, value)
+		} else if ids := m.Prompt.TargetIssues; len(ids) > 0 {
+			m.Mode = ModeBoard
+			cmd = m.submitBulkPrompt(ids, action, value)
 		} else {
 			issueID := m.Prompt.TargetIssue
 			m.Mode = ModeBoard
@@ -586,13 +619,23 @@ func (m model) handleParentPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		targetID := m.ParentPicker.TargetIssueID
+		targetIDs := m.ParentPicker.TargetIssueIDs
 		selected := m.ParentPicker.Options[m.ParentPicker.Index]
 		parent := strings.TrimSpace(selected.ID)
 		m.ParentPicker = nil
 		m.Mode = ModeBoard
-		return m, opCmd("parent updated", func() error {
-			return m.Client.UpdateIssue(UpdateParams{ID: targetID, Parent: &parent})
-		})
+		if len(targetIDs) > 0 {
+			var issues []Issue
+			for _, id := range targetIDs {
+				if issue := m.ByID[id]; issue != nil && id != parent {
+					issues = append(issues, *issue)
+				}
+			}
+			return m, m.bulkUpdate("parent -> "+defaultString(parent, "none"), issues, func(Issue) UpdateParams {
+				return UpdateParams{Parent: &parent}
+			})
+		}
+		return m, m.updateOp("parent updated", UpdateParams{ID: targetID, Parent: &parent})
 	}
 	return m, nil
 }
@@ -600,9 +643,22 @@ func (m model) handleParentPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleRunsKey handles navigation and actions inside the Runs tab.
 // j/k move the selection; enter focuses the row's Herdr pane;
 // a answers the pending human input on a waiting_human row;
+// l opens the transcript of the run's latest execution;
 // r retries the selected run; x cancels it; R reloads;
 // Esc / q closes the tab and returns to the board.
 func (m model) handleRunsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.Runs != nil && m.Runs.Artifact != nil {
+		return m.handleArtifactViewKey(msg)
+	}
+	if m.Runs != nil && m.Runs.Transcript != nil {
+		return m.handleRunTranscriptKey(msg)
+	}
+	if m.Runs != nil && m.Runs.Timeline != nil {
+		return m.handleRunTimelineKey(msg)
+	}
+	if m.Runs != nil && m.Runs.Sessions != nil {
+		return m.handleRunSessionsKey(msg)
+	}
 	switch msg.String() {
 	case "esc", "q":
 		m.Mode = ModeBoard
@@ -616,6 +672,12 @@ func (m model) handleRunsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "enter":
 		return m.focusSelectedRunPane()
+	case "i":
+		return m.openRunTimeline()
+	case "s":
+		return m.openRunSessions()
+	case "l":
+		return m.openSelectedRunTranscript()
 	case "a":
 		return m.answerSelectedHumanInput()
 	case "r":
@@ -631,6 +693,147 @@ func (m model) handleRunsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.Runs.LoadingMsg = "refreshing..."
 		}
 		return m, loadRunsCmd(m.Daemon)
+	case "D":
+		if m.Runs == nil || m.Runs.StaleDaemon == "" {
+			return m, nil
+		}
+		return m.restartDaemon()
+	}
+	return m, nil
+}
+
+// handleRunTimelineKey drives the Runs tab detail view. esc returns to
+// the run list rather than closing the tab.
+func (m model) handleRunTimelineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tl := m.Runs.Timeline
+	switch msg.String() {
+	case "esc", "q", "i":
+		m.Runs.Timeline = nil
+		return m, nil
+	case "j", "down":
+		m.scrollRunTimeline(1)
+		return m, nil
+	case "k", "up":
+		m.scrollRunTimeline(-1)
+		return m, nil
+	case "g", "home":
+		tl.Scroll = 0
+		return m, nil
+	case "G", "end":
+		m.scrollRunTimeline(len(tl.Rows))
+		return m, nil
+	case "enter":
+		return m.openSelectedArtifact()
+	case "l":
+		return m.openTimelineTranscript()
+	case "R":
+		if m.Daemon == nil {
+			m.setToast("warning", "daemon not running")
+			return m, nil
+		}
+		tl.LoadingMsg = "refreshing..."
+		return m, loadRunTimelineCmd(m.Daemon, tl.RunID)
+	}
+	return m, nil
+}
+
+// handleRunSessionsKey drives the Runs tab sessions view. esc returns to
+// the run list.
+func (m model) handleRunSessionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ss := m.Runs.Sessions
+	switch msg.String() {
+	case "esc", "q", "s":
+		m.Runs.Sessions = nil
+		return m, nil
+	case "j", "down":
+		m.moveSessionSelection(1)
+		return m, nil
+	case "k", "up":
+		m.moveSessionSelection(-1)
+		return m, nil
+	case "c":
+		return m.clearSelectedSession()
+	case "f":
+		return m.forkSelectedSession()
+	case "R":
+		if m.Daemon == nil {
+			m.setToast("warning", "daemon not running")
+			return m, nil
+		}
+		ss.LoadingMsg = "refreshing..."
+		return m, loadRunSessionsCmd(m.Daemon, ss.RunID)
+	}
+	return m, nil
+}
+
+// handleRunTranscriptKey drives the transcript view. Scrolling up stops
+// following new output; G resumes it. esc stops the stream and returns
+// to the view it was opened from.
+func (m model) handleRunTranscriptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tr := m.Runs.Transcript
+	switch msg.String() {
+	case "esc", "q", "l":
+		m.closeRunTranscript()
+		return m, nil
+	case "j", "down":
+		m.scrollRunTranscript(1)
+		return m, nil
+	case "k", "up":
+		m.scrollRunTranscript(-1)
+		return m, nil
+	case "ctrl+d", "pgdown":
+		m.scrollRunTranscript(transcriptViewWindow / 2)
+		return m, nil
+	case "ctrl+u", "pgup":
+		m.scrollRunTranscript(-transcriptViewWindow / 2)
+		return m, nil
+	case "g", "home":
+		tr.Scroll = 0
+		tr.Follow = len(tr.Lines) <= transcriptViewWindow
+		return m, nil
+	case "G", "end":
+		tr.Follow = true
+		tr.follow()
+		return m, nil
+	case "R":
+		return m.reconnectRunTranscript()
+	}
+	return m, nil
+}
+
+// handleArtifactViewKey drives the artifact viewer. esc returns to the
+// run timeline it was opened from.
+func (m model) handleArtifactViewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	av := m.Runs.Artifact
+	switch msg.String() {
+	case "esc", "q":
+		m.Runs.Artifact = nil
+		return m, nil
+	case "j", "down":
+		m.scrollArtifactView(1)
+		return m, nil
+	case "k", "up":
+		m.scrollArtifactView(-1)
+		return m, nil
+	case "ctrl+d", "pgdown":
+		m.scrollArtifactView(artifactViewWindow / 2)
+		return m, nil
+	case "ctrl+u", "pgup":
+		m.scrollArtifactView(-artifactViewWindow / 2)
+		return m, nil
+	case "g", "home":
+		av.Scroll = 0
+		return m, nil
+	case "G", "end":
+		m.scrollArtifactView(len(av.Lines))
+		return m, nil
+	case "R":
+		if m.Daemon == nil {
+			m.setToast("warning", "daemon not running")
+			return m, nil
+		}
+		av.LoadingMsg = "refreshing..."
+		return m, loadArtifactCmd(m.Daemon, av.ID)
 	}
 	return m, nil
 }
@@ -684,8 +887,13 @@ func (m model) handleWorkflowPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		selected := m.WorkflowPicker.Options[m.WorkflowPicker.Index]
 		targetID := m.WorkflowPicker.TargetIssueID
+		targetIDs := m.WorkflowPicker.TargetIssueIDs
 		m.WorkflowPicker = nil
 		m.Mode = ModeBoard
+		if len(targetIDs) > 0 {
+			m.commitVisual()
+			return m, m.bulkLaunchCmd(targetIDs, selected.Name)
+		}
 		return m, m.launchRunCmd(targetID, selected.Name)
 	}
 	return m, nil
@@ -880,19 +1088,10 @@ func (m model) applyBlockerPickerAndClose() (tea.Model, tea.Cmd) {
 	sort.Strings(toRemove)
 	sort.Strings(toAdd)
 
-	return m, opCmd("blockers updated", func() error {
-		for _, blockerID := range toRemove {
-			if err := m.Client.DepRemove(targetID, blockerID); err != nil {
-				return err
-			}
-		}
-		for _, blockerID := range toAdd {
-			if err := m.Client.DepAdd(targetID, blockerID); err != nil {
-				return err
-			}
-		}
-		return nil
-	})
+	return m, m.enqueueUndoableOp(
+		depOps("blockers updated", targetID, toRemove, toAdd),
+		depOps("blockers updated", targetID, toAdd, toRemove),
+	)
 }
 
 func buildBlockerPickerColumns(issues []Issue, targetIssueID string, sortMode SortMode) map[Status][]Issue {
@@ -1056,9 +1255,17 @@ func (m model) handleDeleteConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		mode := m.ConfirmDelete.Mode
 		m.ConfirmDelete = nil
 		m.Mode = ModeBoard
-		return m, opCmd("issue deleted", func() error {
-			return m.Client.DeleteIssue(issueID, mode)
-		})
+		op := boardOp{label: "issue deleted", id: issueID, apply: func(issue *Issue) {
+			issue.Display = StatusTombstone
+		}, run: func(c *BdClient) error {
+			return c.DeleteIssue(issueID, mode)
+		}}
+		// A cascade takes the children along, which cannot be restored.
+		issue := m.ByID[issueID]
+		if issue == nil || (mode == DeleteModeCascade && len(issue.Children) > 0) {
+			return m, m.queueOp(op, opNew, nil, nil)
+		}
+		return m, m.enqueueUndoableOp(op, recreateIssueOp(op.label, *issue))
 	}
 
 	return m, nil
@@ -1119,6 +1326,9 @@ func (m model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.moveSelection(-1)
 		return m, nil
 	case "down", "j":
+		if m.atClosedColumnEnd() {
+			return m, m.extendClosedWindow()
+		}
 		m.moveSelection(1)
 		return m, nil
 	case "0":
@@ -1134,7 +1344,20 @@ func (m model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.ensureSelectionVisible(st)
 		}
 		return m, nil
-	case "enter", " ":
+	case " ":
+		m.toggleMark()
+		return m, nil
+	case "V":
+		m.toggleVisual()
+		return m, nil
+	case "esc":
+		if m.clearMarks() {
+			m.setToast("info", "marks cleared")
+		}
+		return m, nil
+	case "a", "L":
+		return m.openTargetsPrompt(key)
+	case "enter":
 		logger.Info("DBG enter: BEFORE mode=%s st=%s", m.Mode, m.currentStatus())
 		issue := m.currentIssue()
 		if issue == nil {
@@ -1150,6 +1373,20 @@ func (m model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.DetailsItem = detailsDescriptionItem()
 		m.DescriptionPreview = nil
 		return m, nil
+	case "u":
+		return m, m.undoCmd()
+	case "ctrl+r":
+		return m, m.redoCmd()
+	case "U":
+		m.HistoryScroll = 0
+		m.Mode = ModeHistory
+		return m, nil
+	case "v":
+		m.ViewsIndex = max(0, m.activeView())
+		m.Mode = ModeViews
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		return m, m.applyView(int(key[0] - '1'))
 	case "/":
 		m.SearchInput.SetValue(m.SearchQuery)
 		m.SearchInput.CursorEnd()
@@ -1178,7 +1415,18 @@ func (m model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.setToast("warning", "no issue selected")
 			return m, nil
 		}
-		if issue.Status == StatusClosed {
+		var targetIDs []string
+		if marked := m.markedIssues(); len(marked) > 0 {
+			for _, it := range marked {
+				if it.Status != StatusClosed {
+					targetIDs = append(targetIDs, it.ID)
+				}
+			}
+			if len(targetIDs) == 0 {
+				m.setToast("warning", "cannot run closed issues")
+				return m, nil
+			}
+		} else if issue.Status == StatusClosed {
 			m.setToast("warning", "cannot run a closed issue")
 			return m, nil
 		}
@@ -1192,9 +1440,10 @@ func (m model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.WorkflowPicker = &WorkflowPickerState{
-			TargetIssueID: issue.ID,
-			Options:       options,
-			Index:         0,
+			TargetIssueID:  issue.ID,
+			TargetIssueIDs: targetIDs,
+			Options:        options,
+			Index:          0,
 		}
 		m.Mode = ModeWorkflowPicker
 		return m, nil
@@ -1288,54 +1537,82 @@ func (m model) handleBoardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.setToast("warning", "no issue selected")
 			return m, nil
 		}
+		if marked := m.markedIssues(); len(marked) > 0 {
+			next := cyclePriority(marked[0].Priority)
+			return m, m.bulkUpdate(fmt.Sprintf("priority -> P%d", next), marked, func(Issue) UpdateParams {
+				return UpdateParams{Priority: &next}
+			})
+		}
 		id := issue.ID
 		next := cyclePriority(issue.Priority)
-		return m, opCmd(fmt.Sprintf("%s: priority -> P%d", id, next), func() error {
-			p := next
-			return m.Client.UpdateIssue(UpdateParams{ID: id, Priority: &p})
-		})
+		return m, m.updateOp(fmt.Sprintf("%s: priority -> P%d", id, next), UpdateParams{ID: id, Priority: &next})
 	case "P":
 		issue := m.currentIssue()
 		if issue == nil {
 			m.setToast("warning", "no issue selected")
 			return m, nil
 		}
+		if marked := m.markedIssues(); len(marked) > 0 {
+			next := cyclePriorityBackward(marked[0].Priority)
+			return m, m.bulkUpdate(fmt.Sprintf("priority -> P%d", next), marked, func(Issue) UpdateParams {
+				return UpdateParams{Priority: &next}
+			})
+		}
 		id := issue.ID
 		next := cyclePriorityBackward(issue.Priority)
-		return m, opCmd(fmt.Sprintf("%s: priority -> P%d", id, next), func() error {
-			p := next
-			return m.Client.UpdateIssue(UpdateParams{ID: id, Priority: &p})
-		})
+		return m, m.updateOp(fmt.Sprintf("%s: priority -> P%d", id, next), UpdateParams{ID: id, Priority: &next})
 	case "s":
 		issue := m.currentIssue()
 		if issue == nil {
 			m.setToast("warning", "no issue selected")
 			return m, nil
 		}
+		if marked := m.markedIssues(); len(marked) > 0 {
+			next := cycleStatus(marked[0].Status)
+			return m, m.bulkUpdate(fmt.Sprintf("status -> %s", next), marked, func(Issue) UpdateParams {
+				return UpdateParams{Status: &next}
+			})
+		}
 		id := issue.ID
 		next := cycleStatus(issue.Status)
-		return m, opCmd(fmt.Sprintf("%s: status -> %s", id, next), func() error {
-			st := next
-			return m.Client.UpdateIssue(UpdateParams{ID: id, Status: &st})
-		})
+		return m, m.updateOp(fmt.Sprintf("%s: status -> %s", id, next), UpdateParams{ID: id, Status: &next})
 	case "S":
 		issue := m.currentIssue()
 		if issue == nil {
 			m.setToast("warning", "no issue selected")
 			return m, nil
 		}
+		if marked := m.markedIssues(); len(marked) > 0 {
+			next := cycleStatusBackward(marked[0].Status)
+			return m, m.bulkUpdate(fmt.Sprintf("status -> %s", next), marked, func(Issue) UpdateParams {
+				return UpdateParams{Status: &next}
+			})
+		}
 		id := issue.ID
 		next := cycleStatusBackward(issue.Status)
-		return m, opCmd(fmt.Sprintf("%s: status -> %s", id, next), func() error {
-			st := next
-			return m.Client.UpdateIssue(UpdateParams{ID: id, Status: &st})
-		})
+		return m, m.updateOp(fmt.Sprintf("%s: status -> %s", id, next), UpdateParams{ID: id, Status: &next})
 	case "x":
 		issue := m.currentIssue()
 		if issue == nil {
 			m.setToast("warning", "no issue selected")
 			return m, nil
 		}
+		if marked := m.markedIssues(); len(marked) > 0 {
+			var ids []string
+			for _, it := range marked {
+				if it.Status != StatusClosed {
+					ids = append(ids, it.ID)
+				}
+			}
+			if len(ids) == 0 {
+				m.setToast("warning", "marked issues are already closed")
+				return m, nil
+			}
+			m.Prompt = newPrompt(ModePrompt, "Close Issues", fmt.Sprintf("Enter close reason for %d issues:", len(ids)), ids[0], PromptCloseReason, "")
+			m.Prompt.TargetIssues = ids
+			m.Mode = ModePrompt
+			return m, nil
+		}
 		id := issue.ID
 		if issue.Status == StatusClosed {
 			m.Prompt = newPrompt(ModePrompt, "Reopen Issue", "Enter reopen reason:", id, PromptReopenReason, "")
@@ -1388,6 +1665,17 @@ func (m model) handleDefaultLeaderCombo(key string) (tea.Model, tea.Cmd) {
 		}
 		return m, cmd
 	}
+	switch key {
+	case "w":
+		return m.cycleSwimlanes()
+	case "c":
+		cmd, err := m.openBoardLayoutInEditorCmd()
+		if err != nil {
+			m.setToast("error", err.Error())
+			return m, nil
+		}
+		return m, cmd
+	}
 
 	issue := m.currentIssue()
 	if issue == nil {
@@ -1409,6 +1697,9 @@ func (m model) handleDefaultLeaderCombo(key string) (tea.Model, tea.Cmd) {
 		return model, cmd
 	case "p":
 		m.ParentPicker = newParentPickerState(m.Issues, issue.ID, issue.Parent)
+		for _, marked := range m.markedIssues() {
+			m.ParentPicker.TargetIssueIDs = append(m.ParentPicker.TargetIssueIDs, marked.ID)
+		}
 		m.Mode = ModeParentPicker
 		return m, nil
 	case "u":
@@ -1423,7 +1714,7 @@ func (m model) handleDefaultLeaderCombo(key string) (tea.Model, tea.Cmd) {
 		}
 
 		m.clearSearchAndFilters()
-		if m.selectIssueByID(parentID) {
+		if m.selectIssueByID(parentID) || m.revealClosedIssue(parentID) {
 			return m, nil
 		}
 
@@ -1431,10 +1722,8 @@ func (m model) handleDefaultLeaderCombo(key string) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "P":
 		id := issue.ID
-		return m, opCmd(fmt.Sprintf("%s parent cleared", id), func() error {
-			empty := ""
-			return m.Client.UpdateIssue(UpdateParams{ID: id, Parent: &empty})
-		})
+		empty := ""
+		return m, m.updateOp(fmt.Sprintf("%s parent cleared", id), UpdateParams{ID: id, Parent: &empty})
 	case "D":
 		if m.DimOverride == nil {
 			force := false
@@ -1588,9 +1877,7 @@ func (m model) submitForm() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	return m, opCmd(fmt.Sprintf("%s updated", form.IssueID), func() error {
-		return m.Client.UpdateIssue(upd)
-	})
+	return m, m.updateOp(fmt.Sprintf("%s updated", form.IssueID), upd)
 }
 
 func (m model) handleMuxLeaderCombo(key string) (tea.Model, tea.Cmd) {
@@ -1745,37 +2032,35 @@ func (m model) formatBeadsStartTaskCommand(issueID string) string {
 	return base
 }
 
-func (m model) submitPrompt(issueID string, action PromptAction, value string) tea.Cmd {
+func (m *model) submitPrompt(issueID string, action PromptAction, value string) tea.Cmd {
 	switch action {
 	case PromptAssignee:
-		return opCmd("assignee updated", func() error {
-			v := value
-			return m.Client.UpdateIssue(UpdateParams{ID: issueID, Assignee: &v})
-		})
+		return m.updateOp("assignee updated", UpdateParams{ID: issueID, Assignee: &value})
 	case PromptLabels:
 		labels := parseLabels(value)
-		return opCmd("labels updated", func() error {
-			return m.Client.UpdateIssue(UpdateParams{ID: issueID, Labels: &labels})
-		})
+		return m.updateOp("labels updated", UpdateParams{ID: issueID, Labels: &labels})
 	case PromptDepAdd:
 		if value == "" {
 			return opCmd("", func() error { return fmt.Errorf("blocker id is required") })
 		}
-		return opCmd("blocker added", func() error {
-			return m.Client.DepAdd(issueID, value)
-		})
+		blocker := []string{value}
+		return m.enqueueUndoableOp(
+			depOps("blocker added", issueID, nil, blocker),
+			depOps("blocker added", issueID, blocker, nil),
+		)
 	case PromptDepRemove:
 		if value == "" {
 			return opCmd("", func() error { return fmt.Errorf("blocker id is required") })
 		}
-		return opCmd("blocker removed", func() error {
-			return m.Client.DepRemove(issueID, value)
-		})
+		blocker := []string{value}
+		return m.enqueueUndoableOp(
+			depOps("blocker removed", issueID, blocker, nil),
+			depOps("blocker removed", issueID, nil, blocker),
+		)
 	case PromptParentSet:
-		return opCmd("parent updated", func() error {
-			v := value
-			return m.Client.UpdateIssue(UpdateParams{ID: issueID, Parent: &v})
-		})
+		return m.updateOp("parent updated", UpdateParams{ID: issueID, Parent: &value})
+	case PromptSaveView:
+		return m.saveView(value)
 	case PromptCloseReason:
 		issue := m.ByID[issueID]
 		if issue == nil {
@@ -1786,12 +2071,8 @@ func (m model) submitPrompt(issueID string, action PromptAction, value string) t
 			timestamp := time.Now().Format("2006-01-02 15:04")
 			newDesc = fmt.Sprintf("%s\n\n---\n**Closed**: %s - %s", strings.TrimSpace(newDesc), timestamp, value)
 		}
-		return opCmd(fmt.Sprintf("%s closed", issueID), func() error {
-			if err := m.Client.UpdateIssue(UpdateParams{ID: issueID, Description: &newDesc}); err != nil {
-				return err
-			}
-			return m.Client.CloseIssue(issueID)
-		})
+		label := fmt.Sprintf("%s closed", issueID)
+		return m.enqueueUndoableOp(closeBoardOp(label, issueID, newDesc), reopenBoardOp(label, *issue))
 	case PromptReopenReason:
 		issue := m.ByID[issueID]
 		if issue == nil {
@@ -1802,12 +2083,9 @@ func (m model) submitPrompt(issueID string, action PromptAction, value string) t
 			timestamp := time.Now().Format("2006-01-02 15:04")
 			newDesc = fmt.Sprintf("%s\n\n---\n**Reopened**: %s - %s", strings.TrimSpace(newDesc), timestamp, value)
 		}
-		return opCmd(fmt.Sprintf("%s reopened", issueID), func() error {
-			if err := m.Client.UpdateIssue(UpdateParams{ID: issueID, Description: &newDesc}); err != nil {
-				return err
-			}
-			return m.Client.ReopenIssue(issueID)
-		})
+		label := fmt.Sprintf("%s reopened", issueID)
+		reopen := reopenBoardOp(label, Issue{ID: issueID, Description: newDesc, Status: StatusOpen})
+		return m.enqueueUndoableOp(reopen, closeBoardOp(label, issueID, issue.Description))
 	default:
 		return opCmd("", func() error { return fmt.Errorf("unknown prompt action") })
 	}
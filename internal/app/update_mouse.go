@@ -1,14 +1,29 @@
 package app
 
 import (
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
+// mouseWheelRows is how many rows one wheel notch scrolls a column.
+const mouseWheelRows = 3
+
 func (m model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if m.Mode != ModeBoard {
+		m.Drag = nil
+		return m, nil
+	}
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp || msg.Button == tea.MouseButtonWheelDown:
+		return m.handleMouseWheel(msg)
+	case m.Drag != nil && msg.Action == tea.MouseActionMotion:
+		m.updateDrag(msg)
 		return m, nil
+	case m.Drag != nil && msg.Action == tea.MouseActionRelease:
+		return m.dropDrag(msg)
 	}
 	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
 		return m, nil
@@ -32,6 +47,7 @@ func (m model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 
 	if !target.ghost {
 		m.selectIssueByID(targetID)
+		m.Drag = &DragState{IssueID: targetID, FromStatus: status, DropStatus: status}
 		return m, nil
 	}
 
@@ -40,11 +56,138 @@ func (m model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	}
 
 	m.clearSearchAndFilters()
-	m.selectIssueByID(targetID)
+	if !m.selectIssueByID(targetID) {
+		m.revealClosedIssue(targetID)
+	}
+	return m, nil
+}
+
+// handleMouseWheel scrolls the column under the pointer without moving
+// the selection.
+func (m model) handleMouseWheel(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	status, _, _, ok := m.boardMousePoint(msg.X, msg.Y)
+	if !ok {
+		return m, nil
+	}
+	rows, _ := m.buildColumnRows(status)
+	itemsPerPage := max(1, m.columnInnerHeight()-3)
+	delta := mouseWheelRows
+	if msg.Button == tea.MouseButtonWheelUp {
+		delta = -delta
+	}
+	m.ScrollOffset[status] = min(max(0, m.ScrollOffset[status]+delta), max(0, len(rows)-itemsPerPage))
 	return m, nil
 }
 
+// updateDrag moves the drop target of the dragged card to the pointer.
+func (m *model) updateDrag(msg tea.MouseMsg) {
+	drag := *m.Drag
+	drag.DropStatus, drag.DropIssueID, drag.Link = "", "", ""
+	status, rowIdx, onRow, ok := m.boardMousePoint(msg.X, msg.Y)
+	if ok {
+		drag.DropStatus = status
+		if rows, _ := m.buildColumnRows(status); onRow && (msg.Ctrl || msg.Alt) && rowIdx < len(rows) {
+			if row := rows[rowIdx]; !row.ghost && row.issue.ID != drag.IssueID {
+				drag.DropIssueID = row.issue.ID
+				drag.Link = DropLinkBlocker
+				if msg.Ctrl {
+					drag.Link = DropLinkParent
+				}
+			}
+		}
+	}
+	drag.Moved = drag.Moved || drag.DropStatus != drag.FromStatus || drag.DropIssueID != ""
+	m.Drag = &drag
+}
+
+// dropDrag applies a finished drag: onto a card with a modifier it sets
+// the parent or adds a blocker, onto another cell it moves the card there,
+// every marked card when the dragged one is marked.
+func (m model) dropDrag(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	m.updateDrag(msg)
+	drag := *m.Drag
+	m.Drag = nil
+	if !drag.Moved {
+		return m, nil
+	}
+	id := drag.IssueID
+	switch {
+	case drag.Link == DropLinkParent:
+		parent := drag.DropIssueID
+		return m, m.updateOp(fmt.Sprintf("%s: parent -> %s", id, parent), UpdateParams{ID: id, Parent: &parent})
+	case drag.Link == DropLinkBlocker:
+		blocker := []string{drag.DropIssueID}
+		label := fmt.Sprintf("%s blocked by %s", id, drag.DropIssueID)
+		return m, m.enqueueUndoableOp(depOps(label, id, nil, blocker), depOps(label, id, blocker, nil))
+	case drag.DropStatus == "" || drag.DropStatus == drag.FromStatus:
+		return m, nil
+	}
+	label, params, err := m.moveParams(drag.FromStatus, drag.DropStatus)
+	if err != nil {
+		m.setToast("warning", err.Error())
+		return m, nil
+	}
+	if marked := m.markedIssues(); m.markedSet()[id] && len(marked) > 1 {
+		return m, m.bulkUpdate(label, marked, func(Issue) UpdateParams { return params })
+	}
+	params.ID = id
+	return m, m.updateOp(fmt.Sprintf("%s: %s", id, label), params)
+}
+
+// moveParams is the update that moves a card from one cell to another:
+// the status of the target column and, across swimlanes, the assignee or
+// parent of the target lane.
+func (m model) moveParams(from, to Status) (string, UpdateParams, error) {
+	src, _ := m.boardColumn(from)
+	dst, ok := m.boardColumn(to)
+	if !ok {
+		return "", UpdateParams{}, fmt.Errorf("no column to drop on")
+	}
+	var changes []string
+	var params UpdateParams
+	if dst.def != src.def {
+		def := m.columnDefs()[dst.def]
+		if def.Status == "" {
+			return "", UpdateParams{}, fmt.Errorf("column %s sets no status", def.Name)
+		}
+		status := def.Status
+		params.Status = &status
+		changes = append(changes, fmt.Sprintf("status -> %s", status))
+	}
+	if dst.lane != src.lane {
+		lane := dst.lane
+		switch m.Layout.Swimlanes {
+		case SwimlaneAssignee:
+			params.Assignee = &lane
+			changes = append(changes, fmt.Sprintf("assignee -> %s", defaultString(lane, "none")))
+		case SwimlaneEpic:
+			params.Parent = &lane
+			changes = append(changes, fmt.Sprintf("parent -> %s", defaultString(lane, "none")))
+		default:
+			return "", UpdateParams{}, fmt.Errorf("cards cannot move between %s lanes", m.Layout.Swimlanes)
+		}
+	}
+	return strings.Join(changes, ", "), params, nil
+}
+
+// dropTargetColumn reports whether status is where the dragged card would
+// land on release.
+func (m model) dropTargetColumn(status Status) bool {
+	d := m.Drag
+	return d != nil && d.Moved && d.DropIssueID == "" && d.DropStatus == status && status != d.FromStatus
+}
+
 func (m model) boardMouseTarget(x int, y int) (Status, int, bool) {
+	status, rowIdx, onRow, ok := m.boardMousePoint(x, y)
+	if !ok || !onRow {
+		return "", 0, false
+	}
+	return status, rowIdx, true
+}
+
+// boardMousePoint locates x, y inside a cell of the board and, when it
+// is over the card rows, the row under it.
+func (m model) boardMousePoint(x int, y int) (Status, int, bool, bool) {
 	const (
 		boardLeft = 1
 		boardTop  = 1
@@ -52,51 +195,70 @@ func (m model) boardMouseTarget(x int, y int) (Status, int, bool) {
 	)
 
 	if x < 0 || y < 0 {
-		return "", 0, false
+		return "", 0, false, false
 	}
 
-	innerHeight := m.boardInnerHeight()
+	first, shown, innerHeight := m.laneLayout()
 	outerHeight := innerHeight + 2
-	if y < boardTop || y >= boardTop+outerHeight {
-		return "", 0, false
+	titleLines := 0
+	if m.Layout.Swimlanes != SwimlaneNone {
+		titleLines = 1
+	}
+	laneHeight := titleLines + outerHeight
+	if y < boardTop || y >= boardTop+shown*laneHeight {
+		return "", 0, false, false
 	}
 
-	yLocal := y - boardTop
-	if yLocal == 0 || yLocal == outerHeight-1 {
-		return "", 0, false
+	lane := first + (y-boardTop)/laneHeight
+	yLocal := (y-boardTop)%laneHeight - titleLines
+	if yLocal <= 0 || yLocal == outerHeight-1 {
+		return "", 0, false, false
 	}
 	innerY := yLocal - 1
 	rowInViewport := innerY - taskStart
 
 	itemsPerPage := max(1, innerHeight-3)
-	if rowInViewport < 0 || rowInViewport >= itemsPerPage {
-		return "", 0, false
-	}
-
-	availableWidth := max(20, m.Width-4)
-	panelWidth := (availableWidth - (len(statusOrder) - 1)) / len(statusOrder)
-	if panelWidth < 20 {
-		panelWidth = 20
-	}
-	outerWidth := panelWidth + 2
+	onRow := rowInViewport >= 0 && rowInViewport < itemsPerPage
 
 	xLocal := x - boardLeft
 	if xLocal < 0 {
-		return "", 0, false
+		return "", 0, false, false
 	}
 
-	colIdx := xLocal / outerWidth
-	if colIdx < 0 || colIdx >= len(statusOrder) {
-		return "", 0, false
+	widths := m.columnWidths()
+	colIdx, colStart := -1, 0
+	for i, width := range widths {
+		if xLocal < colStart+width+2 {
+			colIdx = i
+			break
+		}
+		colStart += width + 2
+	}
+	if colIdx < 0 {
+		return "", 0, false, false
 	}
 
-	colStart := colIdx * outerWidth
 	xInCol := xLocal - colStart
-	if xInCol <= 0 || xInCol >= outerWidth-1 {
-		return "", 0, false
+	if xInCol <= 0 || xInCol >= widths[colIdx]+1 {
+		return "", 0, false, false
 	}
 
-	status := statusOrder[colIdx]
+	keys := m.columnKeys()
+	idx := lane*len(widths) + colIdx
+	if idx >= len(keys) {
+		return "", 0, false, false
+	}
+	status := keys[idx]
 	rowIdx := m.ScrollOffset[status] + rowInViewport
-	return status, rowIdx, true
+	return status, rowIdx, onRow, true
+}
+
+func dashboardDropTargetRowStyle(issueType string) lipgloss.Style {
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("16")).
+		Background(lipgloss.Color("214"))
+	if _, isEpic := dashboardEpicAccentStyle(issueType); isEpic {
+		style = style.Bold(true)
+	}
+	return style
 }
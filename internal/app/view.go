@@ -90,7 +90,17 @@ func (m model) renderInlineSearchBlock() string {
 		queryValue = "-"
 	}
 
-	searchLine := truncate(labelStyle.Render("search: ")+queryValue, maxWidth)
+	label := "search: "
+	if i := m.activeView(); i >= 0 {
+		label = fmt.Sprintf("search [%s]: ", m.Views[i].Name)
+	}
+	if m.Mode == ModeSearch && !m.SearchExpanded {
+		if hint := m.searchHint(); hint != "" {
+			queryValue += "  " + hint
+		}
+	}
+
+	searchLine := truncate(labelStyle.Render(label)+queryValue, maxWidth)
 	lines := []string{searchLine}
 
 	if m.inlineFiltersVisible() {
@@ -121,16 +131,19 @@ func (m model) renderInlineFiltersSummaryLine() string {
 		{name: "status", key: "status", value: "any"},
 		{name: "priority", key: "priority", value: "any"},
 		{name: "type", key: "type", value: "any"},
+		{name: "run", key: "run", value: "any"},
 	}
 
 	if m.Mode == ModeSearch && m.FilterForm != nil {
 		fields[0].value = defaultString(strings.TrimSpace(m.FilterForm.Status), "any")
 		fields[1].value = defaultString(strings.TrimSpace(m.FilterForm.Priority), "any")
 		fields[2].value = defaultString(strings.TrimSpace(m.FilterForm.Type), "any")
+		fields[3].value = defaultString(strings.TrimSpace(m.FilterForm.Run), "any")
 	} else {
 		fields[0].value = defaultString(strings.TrimSpace(m.Filter.Status), "any")
 		fields[1].value = defaultString(strings.TrimSpace(m.Filter.Priority), "any")
 		fields[2].value = defaultString(strings.TrimSpace(m.Filter.Type), "any")
+		fields[3].value = defaultString(strings.TrimSpace(m.Filter.Run), "any")
 	}
 
 	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("110")).Bold(true)
@@ -162,6 +175,7 @@ func (m model) renderInlineFiltersExpandedLines(maxWidth int) []string {
 		{name: "status", key: "status"},
 		{name: "priority", key: "priority"},
 		{name: "type", key: "type"},
+		{name: "run", key: "run"},
 	}
 
 	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("110")).Bold(true)
@@ -196,6 +210,8 @@ func (m model) inlineFilterOptionsAndCurrent(field string) ([]string, string) {
 			current = defaultString(strings.TrimSpace(m.FilterForm.Priority), "any")
 		case "type":
 			current = defaultString(strings.TrimSpace(m.FilterForm.Type), "any")
+		case "run":
+			current = defaultString(strings.TrimSpace(m.FilterForm.Run), "any")
 		}
 	} else {
 		switch field {
@@ -205,6 +221,8 @@ func (m model) inlineFilterOptionsAndCurrent(field string) ([]string, string) {
 			current = defaultString(strings.TrimSpace(m.Filter.Priority), "any")
 		case "type":
 			current = defaultString(strings.TrimSpace(m.Filter.Type), "any")
+		case "run":
+			current = defaultString(strings.TrimSpace(m.Filter.Run), "any")
 		}
 	}
 
@@ -235,22 +253,25 @@ func (m model) renderInlineFilterValues(field string, values []string, current s
 }
 
 func (m model) renderBoard() string {
-	availableWidth := max(20, m.Width-4)
-	gap := 1
-	totalGap := gap * (len(statusOrder) - 1)
-	panelWidth := (availableWidth - totalGap) / len(statusOrder)
-	if panelWidth < 20 {
-		panelWidth = 20
-	}
+	widths := m.columnWidths()
+	keys := m.columnKeys()
+	lanes := m.boardLanes()
+	first, shown, innerHeight := m.laneLayout()
 
-	innerHeight := m.boardInnerHeight()
-
-	cols := make([]string, 0, len(statusOrder))
-	for idx, status := range statusOrder {
-		cols = append(cols, m.renderColumn(status, panelWidth, innerHeight, idx == m.SelectedCol))
+	rows := make([]string, 0, 2*shown)
+	for lane := first; lane < first+shown; lane++ {
+		cols := make([]string, 0, len(widths))
+		for i, width := range widths {
+			idx := lane*len(widths) + i
+			cols = append(cols, m.renderColumn(keys[idx], width, innerHeight, idx == m.SelectedCol))
+		}
+		if m.Layout.Swimlanes != SwimlaneNone {
+			rows = append(rows, m.renderLaneTitle(lanes[lane], max(20, m.Width-4)))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cols...))
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 type boardRow struct {
@@ -292,7 +313,7 @@ func (m model) crossStatusParentChain(item Issue, status Status) []Issue {
 		if parent == nil {
 			break
 		}
-		if parent.Display != status {
+		if !m.columnHolds(status, *parent) {
 			nearestToRoot = append(nearestToRoot, *parent)
 		}
 		parentID = strings.TrimSpace(parent.Parent)
@@ -411,12 +432,19 @@ func (m model) selectedGhostCopiesSet() map[string]bool {
 }
 
 func (m model) renderColumn(status Status, width int, innerHeight int, active bool) string {
-	borderColor := columnBorderColor(status, active)
+	borderColor := columnBorderColor(m.columnStatus(status), active)
+	if _, _, over := m.overWIP(status); over {
+		borderColor = lipgloss.Color("160")
+	}
 	border := columnBorderStyle(active)
 	grayBoard := m.Mode == ModeDetails || m.Mode == ModeDescriptionPreview
 	if grayBoard {
 		borderColor = lipgloss.Color("241")
 	}
+	if m.dropTargetColumn(status) {
+		border = lipgloss.DoubleBorder()
+		borderColor = lipgloss.Color("214")
+	}
 	style := lipgloss.NewStyle().
 		Border(border).
 		BorderForeground(borderColor).
@@ -427,6 +455,7 @@ func (m model) renderColumn(status Status, width int, innerHeight int, active bo
 	selectedBlockedBy := m.selectedBlockedBySet()
 	selectedBlocks := m.selectedBlocksSet()
 	selectedGhostCopies := m.selectedGhostCopiesSet()
+	marked := m.markedSet()
 	idx := m.SelectedIdx[status]
 	if idx < 0 {
 		idx = 0
@@ -445,8 +474,20 @@ func (m model) renderColumn(status Status, width int, innerHeight int, active bo
 
 	maxTextWidth := max(1, width-4)
 
-	header := truncate(fmt.Sprintf("%s (%d)", status.Label(), len(col)), maxTextWidth)
-	headerLine := statusHeaderStyle(status).Render(header)
+	title := status.Label()
+	if cell, ok := m.boardColumn(status); ok {
+		title = m.columnDefs()[cell.def].Name
+	}
+	headerText := fmt.Sprintf("%s (%d)", title, len(col))
+	count, limit, overWIP := m.overWIP(status)
+	if limit > 0 {
+		headerText = fmt.Sprintf("%s (%d) WIP %d/%d", title, len(col), count, limit)
+	}
+	header := truncate(headerText, maxTextWidth)
+	headerLine := statusHeaderStyle(m.columnStatus(status)).Render(header)
+	if overWIP {
+		headerLine = dashboardOverWIPHeaderStyle().Render(header)
+	}
 	if grayBoard {
 		headerLine = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("245")).
@@ -482,6 +523,9 @@ func (m model) renderColumn(status Status, width int, innerHeight int, active bo
 		end := min(len(rows), offset+itemsPerPage)
 		for i := offset; i < end; i++ {
 			rowItem := rows[i]
+			if badge, ok := m.RunBadges[rowItem.issue.ID]; ok && !rowItem.ghost {
+				rowItem.issue.Run = &badge
+			}
 			row := renderIssueRow(rowItem.issue, maxTextWidth, rowItem.depth, m.Collapsed)
 			if rowItem.ghost {
 				row = dashboardDimmedRowStyle(rowItem.issue.IssueType, lipgloss.Color("242"), true).
@@ -499,6 +543,12 @@ func (m model) renderColumn(status Status, width int, innerHeight int, active bo
 			} else if !grayBoard && !rowItem.ghost && selectedBlocks != nil && selectedBlocks[rowItem.issue.ID] {
 				row = renderIssueRowDependencyAccent(rowItem.issue, maxTextWidth, rowItem.depth, m.Collapsed, dashboardBlocksRowStyle(rowItem.issue.IssueType))
 			}
+			if !grayBoard && !rowItem.ghost && marked[rowItem.issue.ID] {
+				row = renderIssueRowDependencyAccent(rowItem.issue, maxTextWidth, rowItem.depth, m.Collapsed, dashboardMarkedRowStyle(rowItem.issue.IssueType))
+			}
+			if !rowItem.ghost && m.Drag != nil && m.Drag.DropIssueID == rowItem.issue.ID {
+				row = renderIssueRowDependencyAccent(rowItem.issue, maxTextWidth, rowItem.depth, m.Collapsed, dashboardDropTargetRowStyle(rowItem.issue.IssueType))
+			}
 			if i == selectedRowIdx && active && !rowItem.ghost && !grayBoard {
 				row = m.Styles.Selected.Render(renderIssueRowSelectedPlain(rowItem.issue, maxTextWidth, rowItem.depth, m.Collapsed))
 			}
@@ -723,7 +773,7 @@ func styleDetailMetaLine(line string, keyStyle lipgloss.Style) string {
 }
 
 func (m model) renderFooter() string {
-	left := "j/k move | h/l col | Enter/Space focus details | y copy id | z toggle children | t + key herdr | n new | e edit | Ctrl+X ext edit | d delete | g + key deps | ? help | q quit"
+	left := "j/k move | h/l col | Enter focus details | Space/V mark | y copy id | z toggle children | t + key herdr | n new | e edit | Ctrl+X ext edit | d delete | g + key deps | ? help | q quit"
 	if m.Mode != ModeBoard {
 		if m.Mode == ModeDetails {
 			left = "Mode: details | d open description | n open notes | Ctrl+X ext edit | Esc close"
@@ -732,7 +782,7 @@ func (m model) renderFooter() string {
 		} else if m.Mode == ModeNotesPreview {
 			left = "Mode: notes | j/k scroll | Ctrl+X ext edit | Esc close"
 		} else if m.Mode == ModeSearch {
-			left = "Mode: search | type search query | Ctrl+F filters | ↑/↓ field | Tab/Shift+Tab value | Enter/Esc apply+exit | Ctrl+C clear"
+			left = "Mode: search | type search query | Tab complete | Ctrl+S save view | Ctrl+F filters | ↑/↓ field | Tab/Shift+Tab value | Enter/Esc apply+exit | Ctrl+C clear"
 		} else if m.Mode == ModeConfirmClosedParentCreate {
 			left = "Mode: confirm closed parent | y confirm | n/Esc cancel"
 		} else if m.Mode == ModeCreate {
@@ -747,6 +797,10 @@ func (m model) renderFooter() string {
 	right := ""
 	if m.LoadedLimit > 0 && len(m.Issues) == m.LoadedLimit {
 		right = fmt.Sprintf("loaded %d (capped at %d)", len(m.Issues), m.LoadedLimit)
+	} else if label := m.markedLabel(); m.Mode == ModeBoard && label != "" {
+		right = label
+	} else if m.Mode == ModeBoard && m.columnStatus(m.currentStatus()) == StatusClosed {
+		right = m.closedWindowLabel()
 	}
 	if m.Toast != "" {
 		switch m.ToastKind {
@@ -793,6 +847,10 @@ func (m model) renderModal() string {
 		return m.renderWorkflowPickerModal()
 	case ModeRuns:
 		return m.renderRunsModal()
+	case ModeHistory:
+		return m.renderHistoryModal()
+	case ModeViews:
+		return m.renderViewsModal()
 	default:
 		return ""
 	}
@@ -1111,6 +1169,7 @@ func (m model) renderFilterModal() string {
 		fmt.Sprintf("%s status:   %s", mark("status"), defaultString(m.FilterForm.Status, "any")),
 		fmt.Sprintf("%s priority: %s", mark("priority"), defaultString(m.FilterForm.Priority, "any")),
 		fmt.Sprintf("%s type:     %s", mark("type"), defaultString(m.FilterForm.Type, "any")),
+		fmt.Sprintf("%s run:      %s", mark("run"), defaultString(m.FilterForm.Run, "any")),
 		"",
 	}
 
@@ -1334,13 +1393,24 @@ func (m model) renderParentPickerModal() string {
 // renderRunsModal draws the Runs tab. It renders the load status,
 // then a list of coarse Run rows (task id, status, current step id,
 // pane reference, human-attention flag), then a footer with the key
-// bindings. Full agent transcripts are NOT shown -- the user follows
-// the pane reference in Herdr for that.
+// bindings. Agent transcripts open in their own view (l).
 func (m model) renderRunsModal() string {
 	state := m.Runs
 	if state == nil {
 		return "Runs tab not initialised\n"
 	}
+	if state.Artifact != nil {
+		return m.renderArtifactView(state.Artifact)
+	}
+	if state.Transcript != nil {
+		return m.renderRunTranscript(state.Transcript)
+	}
+	if state.Timeline != nil {
+		return m.renderRunTimeline(state.Timeline)
+	}
+	if state.Sessions != nil {
+		return m.renderRunSessions(state.Sessions)
+	}
 	header := "Runs (orchestrator)"
 	if state.LoadingMsg != "" {
 		header += "  -  " + state.LoadingMsg
@@ -1348,6 +1418,9 @@ func (m model) renderRunsModal() string {
 		header += "  -  " + state.LastError
 	}
 	lines := []string{header, ""}
+	if state.StaleDaemon != "" {
+		lines = append(lines, "stale daemon: "+state.StaleDaemon+"  (D restart)", "")
+	}
 
 	if !state.Loaded {
 		lines = append(lines, "loading...")
@@ -1377,6 +1450,9 @@ func (m model) renderRunsModal() string {
 			if pane == "" {
 				pane = "-"
 			}
+			if usage := runUsageLabel(r); usage != "" {
+				humanFlag = "  " + usage + humanFlag
+			}
 			line := fmt.Sprintf("%s%-8s  %-12s  %-22s  %-18s  %-8s%s",
 				marker, r.Status, shortRunID(r.RunID),
 				truncate(r.TaskID, 22), stage, truncate(pane, 8), humanFlag)
@@ -1385,7 +1461,245 @@ func (m model) renderRunsModal() string {
 	}
 
 	lines = append(lines, "")
-	lines = append(lines, "j/k move  enter focus-pane  a answer-human  r retry  x cancel  R refresh  esc/q close")
+	lines = append(lines, "j/k move  enter focus-pane  i timeline  s sessions  l transcript  a answer-human  r retry  x cancel  R refresh  esc/q close")
+	return strings.Join(lines, "\n")
+}
+
+// runUsageLabel summarises a run's token and cost usage against its
+// budget, e.g. "12.3k/50k tok $0.42/$2.00"; "" before any usage or budget.
+func runUsageLabel(r RunRow) string {
+	var parts []string
+	if r.Tokens > 0 || r.MaxTokens > 0 {
+		tok := formatTokens(r.Tokens)
+		if r.MaxTokens > 0 {
+			tok += "/" + formatTokens(r.MaxTokens)
+		}
+		parts = append(parts, tok+" tok")
+	}
+	if r.CostUSD > 0 || r.MaxCostUSD > 0 {
+		cost := fmt.Sprintf("$%.2f", r.CostUSD)
+		if r.MaxCostUSD > 0 {
+			cost += fmt.Sprintf("/$%.2f", r.MaxCostUSD)
+		}
+		parts = append(parts, cost)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatTokens abbreviates a token count: 950, 12.3k, 1.2M.
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1e6), ".0") + "M"
+	case n >= 1000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1e3), ".0") + "k"
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// runTimelineWindow is how many timeline rows the detail view shows at
+// once; j/k scroll through the rest.
+const runTimelineWindow = 20
+
+// renderRunTimeline draws the Runs tab detail view: one line per
+// attempt, execution, human input, artifact or event with its step,
+// status and duration. Errors are printed on an indented line below the
+// entry so "attempt 3 of step revise failed because ..." reads at a
+// glance.
+func (m model) renderRunTimeline(tl *RunTimelineState) string {
+	header := fmt.Sprintf("Run %s  %s  %s", shortRunID(tl.RunID), tl.Status, tl.TaskID)
+	if tl.LoadingMsg != "" {
+		header += "  -  " + tl.LoadingMsg
+	} else if tl.LastError != "" {
+		header += "  -  " + tl.LastError
+	}
+	lines := []string{header, ""}
+
+	switch {
+	case !tl.Loaded:
+		lines = append(lines, "loading...")
+	case len(tl.Rows) == 0:
+		lines = append(lines, "no history")
+	default:
+		start := tl.Scroll
+		if start < 0 || start >= len(tl.Rows) {
+			start = 0
+		}
+		end := min(len(tl.Rows), start+runTimelineWindow)
+		for i, r := range tl.Rows[start:end] {
+			marker := "  "
+			if i == 0 {
+				marker = "> "
+			}
+			step := "-"
+			if r.StepID != "" {
+				step = fmt.Sprintf("%s#%d", r.StepID, r.Attempt)
+			}
+			status := r.Status
+			if status == "" {
+				status = "-"
+			}
+			at := "--:--:--"
+			if !r.At.IsZero() {
+				at = r.At.Local().Format("15:04:05")
+			}
+			line := fmt.Sprintf("%s%s  %-12s  %-16s  %-10s  %7s  %s",
+				marker, at, r.Kind, truncate(step, 16), status,
+				formatTimelineDuration(r), truncate(r.Summary, 40))
+			lines = append(lines, line)
+			if r.Error != "" {
+				lines = append(lines, "            error: "+truncate(r.Error, 80))
+			}
+		}
+		if end < len(tl.Rows) || start > 0 {
+			lines = append(lines, fmt.Sprintf("(%d-%d of %d)", start+1, end, len(tl.Rows)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "j/k scroll  g/G top/bottom  enter view-artifact  l transcript  R refresh  esc/q back")
+	return strings.Join(lines, "\n")
+}
+
+// renderRunSessions draws the Runs tab sessions view: one line per role
+// with its agent session id, a marker for a pending fork and the session
+// it was forked from.
+func (m model) renderRunSessions(ss *RunSessionsState) string {
+	header := fmt.Sprintf("Sessions  run %s  %s", shortRunID(ss.RunID), ss.TaskID)
+	if ss.LoadingMsg != "" {
+		header += "  -  " + ss.LoadingMsg
+	} else if ss.LastError != "" {
+		header += "  -  " + ss.LastError
+	}
+	lines := []string{header, ""}
+
+	switch {
+	case !ss.Loaded:
+		lines = append(lines, "loading...")
+	case len(ss.Rows) == 0:
+		lines = append(lines, "no sessions")
+	default:
+		for i, r := range ss.Rows {
+			marker := "  "
+			if i == ss.Index {
+				marker = "> "
+			}
+			flags := ""
+			if r.ForkPending {
+				flags += "  [fork pending]"
+			}
+			if r.ForkedFrom != "" {
+				flags += "  forked from " + truncate(r.ForkedFrom, 16)
+			}
+			updated := "--:--:--"
+			if !r.UpdatedAt.IsZero() {
+				updated = r.UpdatedAt.Local().Format("15:04:05")
+			}
+			lines = append(lines, fmt.Sprintf("%s%-16s  %-36s  %s%s",
+				marker, truncate(r.RoleID, 16), truncate(r.SessionID, 36), updated, flags))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "j/k move  c clear  f fork  R refresh  esc/q back")
+	return strings.Join(lines, "\n")
+}
+
+// renderRunTranscript draws the transcript view: assistant prose, tool
+// calls and tool results in the order the agent produced them, updated as
+// chunks arrive.
+func (m model) renderRunTranscript(tr *RunTranscriptState) string {
+	header := fmt.Sprintf("Transcript %s  run %s", shortRunID(tr.ExecutionID), shortRunID(tr.RunID))
+	if tr.Status != "" {
+		header += "  " + tr.Status
+	}
+	switch {
+	case tr.LoadingMsg != "":
+		header += "  -  " + tr.LoadingMsg
+	case tr.LastError != "":
+		header += "  -  " + tr.LastError
+	case tr.Done:
+		header += "  [done]"
+	case tr.Follow:
+		header += "  [following]"
+	}
+	lines := []string{header, ""}
+
+	width := max(20, m.Width-4)
+	switch {
+	case len(tr.Lines) == 0 && tr.Done:
+		lines = append(lines, "no output")
+	case len(tr.Lines) == 0:
+		lines = append(lines, "waiting for output...")
+	default:
+		start := min(max(tr.Scroll, 0), len(tr.Lines)-1)
+		end := min(len(tr.Lines), start+transcriptViewWindow)
+		for _, l := range tr.Lines[start:end] {
+			lines = append(lines, truncate(transcriptLinePrefix(l)+l.Text, width))
+		}
+		if end < len(tr.Lines) || start > 0 {
+			lines = append(lines, fmt.Sprintf("(%d-%d of %d)", start+1, end, len(tr.Lines)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "j/k scroll  g/G top/follow  R reconnect  esc/q back")
+	return strings.Join(lines, "\n")
+}
+
+// artifactViewWindow is how many content lines the artifact viewer shows
+// at once.
+const artifactViewWindow = 30
+
+// renderArtifactView draws the artifact viewer. Lines are rendered lazily
+// and cached per wrap width so glamour does not run on every frame.
+func (m model) renderArtifactView(av *ArtifactViewState) string {
+	header := "Artifact " + av.Name
+	if av.Loaded && av.LastError == "" {
+		check := "unverified"
+		switch {
+		case av.Verified:
+			check = "sha256 ok"
+		case av.Truncated:
+			// The daemon only hashes full reads.
+			check = "hash not checked (partial read)"
+		}
+		header += fmt.Sprintf("  (%d bytes, %s)", av.Size, check)
+	}
+	if av.LoadingMsg != "" {
+		header += "  -  " + av.LoadingMsg
+	} else if av.LastError != "" {
+		header += "  -  " + av.LastError
+	}
+	lines := []string{header, ""}
+
+	switch {
+	case !av.Loaded:
+		lines = append(lines, "loading...")
+	case av.LastError != "" && av.Content == nil:
+		lines = append(lines, "artifact unavailable")
+	default:
+		width := max(20, m.Width-4)
+		if av.Lines == nil || av.LinesWidth != width {
+			av.Lines = artifactLines(av.Name, av.Content, width)
+			av.LinesWidth = width
+		}
+		start := av.Scroll
+		if start < 0 || start >= len(av.Lines) {
+			start = 0
+		}
+		end := min(len(av.Lines), start+artifactViewWindow)
+		lines = append(lines, av.Lines[start:end]...)
+		if av.Truncated {
+			lines = append(lines, fmt.Sprintf("(showing first %d of %d bytes)", len(av.Content), av.Size))
+		}
+		if end < len(av.Lines) || start > 0 {
+			lines = append(lines, fmt.Sprintf("(lines %d-%d of %d)", start+1, end, len(av.Lines)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "j/k scroll  ctrl+d/ctrl+u page  g/G top/bottom  R reload  esc/q back")
 	return strings.Join(lines, "\n")
 }
 
@@ -2230,6 +2544,15 @@ func columnBorderColor(status Status, active bool) lipgloss.Color {
 	}
 }
 
+// dashboardOverWIPHeaderStyle flags a column with more cards than its WIP
+// limit.
+func dashboardOverWIPHeaderStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("231")).
+		Background(lipgloss.Color("160")).
+		Bold(true)
+}
+
 func statusHeaderStyle(status Status) lipgloss.Style {
 	switch status {
 	case StatusOpen:
@@ -2378,7 +2701,7 @@ func renderIssueRowDependencyAccent(item Issue, maxTextWidth int, depth int, col
 	issueType := shortTypeDashboard(item.IssueType)
 	prefix := treePrefix(depth)
 	collapseIndicator := issueCollapseIndicator(item, collapsed)
-	title, id, badge, gap := layoutDashboardRowWithRightID(maxTextWidth, prefix, priority, issueType, collapseIndicator, item.Title, item.ID, item.deferBadgeLabel())
+	title, id, badge, gap := layoutDashboardRowWithRightID(maxTextWidth, prefix, priority, issueType, collapseIndicator, item.Title, item.ID, item.boardBadgeLabel())
 	epicStyle, isEpic := dashboardEpicAccentStyle(item.IssueType)
 
 	prefixStyle := lipgloss.NewStyle()
@@ -2387,7 +2710,6 @@ func renderIssueRowDependencyAccent(item Issue, maxTextWidth int, depth int, col
 	titleStyle := lipgloss.NewStyle()
 	gapStyle := lipgloss.NewStyle()
 	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
-	badgeStyle := deferBadgeStyle()
 	if isEpic {
 		prefixStyle = prefixStyle.Inherit(epicStyle)
 		priorityTokenStyle = priorityTokenStyle.Inherit(epicStyle)
@@ -2402,7 +2724,7 @@ func renderIssueRowDependencyAccent(item Issue, maxTextWidth int, depth int, col
 		" " + issueTypeTokenStyle.Render(issueType) +
 		" " + collapseIndicator + titleStyle.Render(title)
 	if badge != "" {
-		out += " " + badgeStyle.Render(badge)
+		out += " " + renderBoardBadges(item)
 	}
 	out += gapStyle.Render(gap) + idStyle.Render(id)
 	return out
@@ -2414,7 +2736,7 @@ func renderIssueRow(item Issue, maxTextWidth int, depth int, collapsed map[strin
 	prefix := treePrefix(depth)
 	collapseIndicator := issueCollapseIndicator(item, collapsed)
 
-	title, id, badge, gap := layoutDashboardRowWithRightID(maxTextWidth, prefix, priority, issueType, collapseIndicator, item.Title, item.ID, item.deferBadgeLabel())
+	title, id, badge, gap := layoutDashboardRowWithRightID(maxTextWidth, prefix, priority, issueType, collapseIndicator, item.Title, item.ID, item.boardBadgeLabel())
 	epicStyle, isEpic := dashboardEpicAccentStyle(item.IssueType)
 
 	prefixStyle := lipgloss.NewStyle()
@@ -2423,7 +2745,6 @@ func renderIssueRow(item Issue, maxTextWidth int, depth int, collapsed map[strin
 	titleStyle := lipgloss.NewStyle()
 	gapStyle := lipgloss.NewStyle()
 	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
-	badgeStyle := deferBadgeStyle()
 	if isEpic {
 		prefixStyle = prefixStyle.Inherit(epicStyle)
 		priorityTokenStyle = priorityTokenStyle.Inherit(epicStyle)
@@ -2438,7 +2759,7 @@ func renderIssueRow(item Issue, maxTextWidth int, depth int, collapsed map[strin
 		" " + issueTypeTokenStyle.Render(issueType) +
 		" " + collapseIndicator + titleStyle.Render(title)
 	if badge != "" {
-		out += " " + badgeStyle.Render(badge)
+		out += " " + renderBoardBadges(item)
 	}
 	out += gapStyle.Render(gap) + idStyle.Render(id)
 	return out
@@ -2449,7 +2770,7 @@ func renderIssueRowSelectedPlain(item Issue, maxTextWidth int, depth int, collap
 	issueType := shortTypeDashboard(item.IssueType)
 	prefix := treePrefix(depth)
 	collapseIndicator := issueCollapseIndicator(item, collapsed)
-	badge := item.deferBadgeLabel()
+	badge := item.boardBadgeLabel()
 
 	fixedWidth := lipgloss.Width(prefix) + lipgloss.Width(priority) + 1 + lipgloss.Width(issueType) + 1 + lipgloss.Width(collapseIndicator)
 	badgeWidth := 0
@@ -2470,7 +2791,7 @@ func renderIssueRowGhostPlain(item Issue, maxTextWidth int, depth int) string {
 	priority := renderPriorityLabel(item.Priority)
 	issueType := shortTypeDashboard(item.IssueType)
 	prefix := treePrefix(depth)
-	title, id, _, gap := layoutDashboardRowWithRightID(maxTextWidth, prefix, priority, issueType, "", item.Title, item.ID, item.deferBadgeLabel())
+	title, id, _, gap := layoutDashboardRowWithRightID(maxTextWidth, prefix, priority, issueType, "", item.Title, item.ID, item.boardBadgeLabel())
 
 	return prefix + priority + " " + issueType + " " + title + gap + id
 }
@@ -5,13 +5,16 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"bdtui/internal/agent"
 	"bdtui/internal/daemon"
 	"bdtui/internal/daemon/daemonpb"
 	"bdtui/internal/workflow"
@@ -68,16 +71,16 @@ func (m model) loadWorkflowOptions() ([]WorkflowOption, error) {
 	return options, nil
 }
 
-// launchRunCmd resolves the named workflow into a snapshot, sends CreateRun
-// to the daemon, then locally transitions the task to in_progress so the
-// board reflects the claim without depending on bd.
+// launchRunCmd resolves the named workflow into a snapshot and sends
+// CreateRun to the daemon.
 //
-// Run creation is the sole side-effect: we deliberately do NOT push a status
-// change back to bd. The bead description states queued/running state is
-// surfaced by the orchestrator, not encoded into Beads. Adding a follow-up
-// bd update would split the operation into two non-atomic steps where a
-// failure of the second step leaves a queued Run with no Beads claim, and a
-// follow-up retry then hits ErrActiveRunExists.
+// Run creation is the sole side-effect here: the client does NOT push a
+// status change to bd itself. A follow-up bd update would split the
+// operation into two non-atomic steps where a failure of the second leaves
+// a queued Run with no Beads claim, and a retry then hits
+// ErrActiveRunExists. Instead the daemon's beads sync updates the issue
+// (in_progress, run label, notes) from the run's own transitions, retrying
+// until bd accepts it, so the claim shows up on the next reload.
 func (m model) launchRunCmd(taskID, workflowName string) tea.Cmd {
 	workflowName = strings.TrimSpace(workflowName)
 	taskID = strings.TrimSpace(taskID)
@@ -87,6 +90,11 @@ func (m model) launchRunCmd(taskID, workflowName string) tea.Cmd {
 		})
 	}
 	return func() tea.Msg {
+		taskSnapshot, err := taskSnapshotJSON(m.Client, taskID)
+		if err != nil {
+			return opMsg{err: fmt.Errorf("task snapshot: %w", err)}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
 		defer cancel()
 
@@ -113,6 +121,8 @@ func (m model) launchRunCmd(taskID, workflowName string) tea.Cmd {
 			TaskId:              taskID,
 			WorkflowSnapshotRef: snapshot.Ref,
 			WorkflowSnapshot:    snapshot.JSON,
+			TaskSnapshot:        taskSnapshot,
+			ProjectPath:         filepath.Dir(m.BeadsDir),
 		})
 		if err != nil {
 			return opMsg{err: fmt.Errorf("create run: %w", err)}
@@ -121,6 +131,57 @@ func (m model) launchRunCmd(taskID, workflowName string) tea.Cmd {
 	}
 }
 
+// taskSnapshotJSON captures the task and where it sits in the plan (notes,
+// parent epic, the parent's other children, blockers) from bd, for the run
+// to keep. It asks bd rather than the board: the board drops resolved
+// blockers and may not have loaded the parent or its children.
+func taskSnapshotJSON(client *BdClient, taskID string) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("no bd client")
+	}
+	task, err := client.showIssue(taskID)
+	if err != nil {
+		return "", err
+	}
+	ref := func(r shownRelated) agent.TaskRef {
+		return agent.TaskRef{ID: r.ID, Title: r.Title, Status: r.Status}
+	}
+	snap := agent.TaskSnapshot{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Notes:       task.Notes,
+	}
+	parentID := strings.TrimSpace(task.Parent)
+	for _, dep := range task.Dependencies {
+		switch dep.DependencyType {
+		case "parent-child":
+			parentID = defaultString(parentID, dep.ID)
+		case "blocks":
+			snap.Blockers = append(snap.Blockers, ref(dep))
+		}
+	}
+	if parentID != "" {
+		parent, err := client.showIssue(parentID)
+		if err != nil {
+			return "", err
+		}
+		snap.Parent = &agent.TaskRef{ID: parent.ID, Title: parent.Title, Status: parent.Status, Description: parent.Description}
+		for _, child := range parent.Dependents {
+			if child.DependencyType == "parent-child" && child.ID != task.ID {
+				snap.Siblings = append(snap.Siblings, ref(child))
+			}
+		}
+	}
+	sort.Slice(snap.Siblings, func(i, j int) bool { return snap.Siblings[i].ID < snap.Siblings[j].ID })
+	sort.Slice(snap.Blockers, func(i, j int) bool { return snap.Blockers[i].ID < snap.Blockers[j].ID })
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // projectIDForBeadsDir returns the durable project_id stored at
 // `<git-dir>/.bdtui-project-id`, generating + persisting a fresh UUID hex
 // (no dashes) on first use. The id is opaque, machine-independent, and
@@ -304,8 +365,9 @@ func validProjectID(s string) bool {
 	return true
 }
 
-// snapshotWorkflow loads the named workflow bundle and compiles a canonical
-// snapshot document for the daemon.
+// snapshotWorkflow loads the named workflow bundle, adds the project's
+// instruction files and compiles a canonical snapshot document for the
+// daemon.
 func (m model) snapshotWorkflow(ctx context.Context, name string) (workflow.Snapshot, error) {
 	global, project := m.resolveWorkflowsRoots()
 	loader := workflow.Loader{Global: global, Project: project}
@@ -313,6 +375,14 @@ func (m model) snapshotWorkflow(ctx context.Context, name string) (workflow.Snap
 	if err != nil {
 		return workflow.Snapshot{}, fmt.Errorf("load workflow %q: %w", name, err)
 	}
+	if project != "" {
+		discovery := workflow.InstructionDiscovery{Root: filepath.Dir(project), Paths: bundle.Spec.Instructions}
+		ins, err := discovery.Discover()
+		if err != nil {
+			return workflow.Snapshot{}, fmt.Errorf("load workflow %q: %w", name, err)
+		}
+		bundle.AddInstructions(ins)
+	}
 	return workflow.BuildSnapshot(*bundle)
 }
 
@@ -328,11 +398,33 @@ func (m model) ensureDaemon(ctx context.Context) (*daemon.Client, error) {
 	if err := validateBeadsDir(m.BeadsDir); err != nil {
 		return nil, err
 	}
-	opts := daemon.Options{
+	return daemon.EnsureDaemon(ctx, m.daemonOptions())
+}
+
+// daemonOptions returns the socket/db the TUI talks to, honoring the
+// BDTUI_DAEMON_* overrides.
+func (m model) daemonOptions() daemon.Options {
+	return daemon.Options{
 		SocketPath: daemonSocketPath(),
 		DBPath:     daemonDBPath(m.BeadsDir),
+		Dial:       daemonDialOptions(),
+	}
+}
+
+// daemonDialOptions reads credentials for a remote daemon
+// (BDTUI_DAEMON_SOCKET=tcp://host:port) from the environment.
+func daemonDialOptions() []daemon.DialOption {
+	var opts []daemon.DialOption
+	if v := os.Getenv("BDTUI_DAEMON_TOKEN"); v != "" {
+		opts = append(opts, daemon.WithToken(v))
+	}
+	if v := os.Getenv("BDTUI_DAEMON_CA"); v != "" {
+		opts = append(opts, daemon.WithCAFile(v))
+	}
+	if cert, key := os.Getenv("BDTUI_DAEMON_CERT"), os.Getenv("BDTUI_DAEMON_KEY"); cert != "" || key != "" {
+		opts = append(opts, daemon.WithClientCert(cert, key))
 	}
-	return daemon.EnsureDaemon(ctx, opts)
+	return opts
 }
 
 func daemonSocketPath() string {
@@ -4,8 +4,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"bdtui/internal/agent"
 )
 
 func TestProjectWorkflowsRootIsLayoutRoot(t *testing.T) {
@@ -283,3 +286,50 @@ func TestProjectIDAtomicOnConcurrentFirstCall(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskSnapshotJSONCapturesPlanContextFromBd(t *testing.T) {
+	dir := t.TempDir()
+	script := `#!/bin/sh
+case "$2" in
+a) echo '[{"id":"a","title":"Add export","description":"Export.","notes":"CSV first.","status":"open","dependencies":[
+	{"id":"epic","title":"Reporting","status":"open","dependency_type":"parent-child"},
+	{"id":"c","title":"Schema v2","status":"closed","dependency_type":"blocks"}]}]' ;;
+epic) echo 'warning: stale cache'; echo '{"id":"epic","title":"Reporting","description":"All reports.","status":"open","dependents":[
+	{"id":"b","title":"Add import","status":"in_progress","dependency_type":"parent-child"},
+	{"id":"a","title":"Add export","status":"open","dependency_type":"parent-child"},
+	{"id":"x","title":"Waits on reporting","status":"open","dependency_type":"blocks"}]}' ;;
+*) echo "no issue $2" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	client := NewBdClient(t.TempDir())
+
+	// Neither the closed blocker nor the epic is on the board: the
+	// snapshot must come from bd.
+	raw, err := taskSnapshotJSON(client, "a")
+	if err != nil {
+		t.Fatalf("taskSnapshotJSON: %v", err)
+	}
+	snap, err := agent.ParseTaskSnapshot(raw)
+	if err != nil {
+		t.Fatalf("ParseTaskSnapshot: %v", err)
+	}
+	wantParent := agent.TaskRef{ID: "epic", Title: "Reporting", Status: "open", Description: "All reports."}
+	if snap.Notes != "CSV first." || snap.Parent == nil || *snap.Parent != wantParent {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+	wantSiblings := []agent.TaskRef{{ID: "b", Title: "Add import", Status: "in_progress"}}
+	if !reflect.DeepEqual(snap.Siblings, wantSiblings) {
+		t.Fatalf("siblings = %+v, want %+v", snap.Siblings, wantSiblings)
+	}
+	if len(snap.Blockers) != 1 || snap.Blockers[0] != (agent.TaskRef{ID: "c", Title: "Schema v2", Status: "closed"}) {
+		t.Fatalf("resolved blocker should be kept: %+v", snap.Blockers)
+	}
+
+	if _, err := taskSnapshotJSON(client, "missing"); err == nil {
+		t.Fatal("a task bd cannot show should fail the snapshot")
+	}
+}
@@ -0,0 +1,281 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"bdtui/internal/daemon/daemonpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// artifactChunkSize bounds each ArtifactChunk payload. gRPC's default
+// message limit is 4 MiB; 64 KiB keeps memory flat for large artifacts and
+// lets the client start rendering early.
+const artifactChunkSize = 64 * 1024
+
+// ReadArtifact streams an artifact's bytes in order. Artifact paths are
+// resolved against the daemon state directory and may not escape it, so a
+// client can never use this RPC to read arbitrary files.
+//
+// A full read verifies the stored hash over the whole file before any bytes
+// are sent. A ranged read does not hash (that would re-read the entire
+// file for a small window); it reports verified only when an earlier read
+// verified the same file, matched by path, size and modification time.
+// Either way "verified" describes the file as it was when hashed.
+func (s *Service) ReadArtifact(req *daemonpb.ReadArtifactRequest, stream daemonpb.Orchestrator_ReadArtifactServer) error {
+	ctx := stream.Context()
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Offset < 0 || req.Length < 0 {
+		return status.Error(codes.InvalidArgument, "offset and length must not be negative")
+	}
+	a, err := s.store.GetArtifact(ctx, req.Id)
+	if err != nil {
+		return toStatus(err)
+	}
+	path, err := resolveArtifactPath(s.artifactRoot, a.Path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return status.Errorf(codes.NotFound, "artifact file %s is missing", a.Path)
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	size := info.Size()
+	if req.Offset > size {
+		return status.Errorf(codes.OutOfRange, "offset %d is past the end of the artifact (%d bytes)", req.Offset, size)
+	}
+
+	full := req.Offset == 0 && (req.Length == 0 || req.Length >= size)
+	verified, err := s.artifactHashes.verify(f, path, info, a.Hash, full)
+	if err != nil {
+		return err
+	}
+
+	end := size
+	if req.Length > 0 && req.Offset+req.Length < size {
+		end = req.Offset + req.Length
+	}
+	if _, err := f.Seek(req.Offset, io.SeekStart); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	off := req.Offset
+	first := true
+	for {
+		// Each chunk gets its own buffer: gRPC may still read a message
+		// (stats handlers, tracing) after Send returns.
+		n := min(int64(artifactChunkSize), end-off)
+		data := make([]byte, n)
+		if _, err := io.ReadFull(f, data); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		chunk := &daemonpb.ArtifactChunk{
+			Data:   data,
+			Offset: off,
+			Eof:    off+n >= end,
+		}
+		if first {
+			chunk.Size = size
+			chunk.Hash = a.Hash
+			chunk.Verified = verified
+			chunk.Name = a.Name
+			first = false
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+		off += n
+		if chunk.Eof {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// resolveArtifactPath maps a stored artifact path onto the filesystem.
+// Relative paths are taken relative to root; absolute paths are accepted
+// only when they already live under root. Symlinks are resolved before the
+// containment check so a link cannot point the daemon outside root.
+func resolveArtifactPath(root, stored string) (string, error) {
+	if root == "" {
+		return "", status.Error(codes.FailedPrecondition, "artifact root is not configured")
+	}
+	p := stored
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(root, p)
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	realPath, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", status.Errorf(codes.NotFound, "artifact file %s is missing", stored)
+		}
+		return "", status.Error(codes.Internal, err.Error())
+	}
+	rel, err := filepath.Rel(realRoot, realPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", status.Errorf(codes.PermissionDenied, "artifact path %s is outside the daemon state directory", stored)
+	}
+	return realPath, nil
+}
+
+// parseArtifactHash splits a stored hash into its sha256 digest. Both
+// "sha256:<hex>" and a bare 64-character hex digest are recognised; any
+// other format returns ok=false and the artifact is served unverified.
+func parseArtifactHash(h string) (digest []byte, ok bool) {
+	h = strings.TrimSpace(h)
+	h = strings.TrimPrefix(h, "sha256:")
+	if len(h) != hex.EncodedLen(sha256.Size) {
+		return nil, false
+	}
+	d, err := hex.DecodeString(h)
+	if err != nil {
+		return nil, false
+	}
+	return d, true
+}
+
+// artifactHashCacheLimit bounds the verified-artifact cache; it is simply
+// reset when full.
+const artifactHashCacheLimit = 1024
+
+// artifactHashKey identifies one version of an artifact file. A rewrite
+// changes the size or modification time and so misses the cache.
+type artifactHashKey struct {
+	path  string
+	size  int64
+	mtime time.Time
+	hash  string
+}
+
+// artifactHashCache remembers which artifact file versions matched their
+// stored hash. The zero value is ready to use.
+type artifactHashCache struct {
+	mu sync.Mutex
+	ok map[artifactHashKey]struct{}
+}
+
+// verify reports whether f (at path, described by info) matches the stored
+// hash. Only a full read hashes the file; other reads consult the cache. It
+// returns DATA_LOSS on a mismatch, and verified=false when the stored hash
+// is in a format the daemon does not understand.
+func (c *artifactHashCache) verify(f *os.File, path string, info os.FileInfo, stored string, full bool) (bool, error) {
+	want, ok := parseArtifactHash(stored)
+	if !ok {
+		return false, nil
+	}
+	key := artifactHashKey{path: path, size: info.Size(), mtime: info.ModTime(), hash: stored}
+	c.mu.Lock()
+	_, hit := c.ok[key]
+	c.mu.Unlock()
+	if hit || !full {
+		return hit, nil
+	}
+
+	if err := verifyArtifactHash(f, stored, want); err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	if c.ok == nil || len(c.ok) >= artifactHashCacheLimit {
+		c.ok = map[artifactHashKey]struct{}{}
+	}
+	c.ok[key] = struct{}{}
+	c.mu.Unlock()
+	return true, nil
+}
+
+// verifyArtifactHash hashes the whole file and compares it with want.
+func verifyArtifactHash(f *os.File, stored string, want []byte) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		return status.Errorf(codes.DataLoss, "artifact hash mismatch: stored %s, file sha256:%x", stored, got)
+	}
+	return nil
+}
+
+// ArtifactContent is the reassembled result of a ReadArtifact stream.
+type ArtifactContent struct {
+	Name     string
+	Hash     string
+	Size     int64
+	Offset   int64
+	Verified bool
+	Data     []byte
+}
+
+// ReadArtifactBytes drains a ReadArtifact stream into memory. offset and
+// length follow ReadArtifactRequest (length 0 reads to the end). For a full
+// read of an artifact with a sha256 hash the digest is re-checked on the
+// client side as well, so corruption in transit is caught end to end.
+func (c *Client) ReadArtifactBytes(ctx context.Context, id string, offset, length int64) (*ArtifactContent, error) {
+	stream, err := c.ReadArtifact(ctx, &daemonpb.ReadArtifactRequest{Id: id, Offset: offset, Length: length})
+	if err != nil {
+		return nil, err
+	}
+	out := &ArtifactContent{Offset: offset}
+	next := offset
+	first := true
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			out.Name = chunk.Name
+			out.Hash = chunk.Hash
+			out.Size = chunk.Size
+			out.Verified = chunk.Verified
+			first = false
+		}
+		if chunk.Offset != next {
+			return nil, fmt.Errorf("artifact %s: chunk at offset %d, expected %d", id, chunk.Offset, next)
+		}
+		out.Data = append(out.Data, chunk.Data...)
+		next += int64(len(chunk.Data))
+		if chunk.Eof {
+			break
+		}
+	}
+	if offset == 0 && length == 0 && out.Verified {
+		want, _ := parseArtifactHash(out.Hash)
+		if got := sha256.Sum256(out.Data); !bytes.Equal(got[:], want) {
+			return nil, fmt.Errorf("artifact %s: hash mismatch after transfer", id)
+		}
+	}
+	return out, nil
+}
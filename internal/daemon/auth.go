@@ -0,0 +1,189 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Scope is what an authenticated remote client may do over the TCP
+// listener. The Unix socket is trusted and always acts as ScopeOperator.
+type Scope string
+
+const (
+	// ScopeRead may list and inspect runs, read artifacts and stream
+	// events, but not change anything.
+	ScopeRead Scope = "read"
+	// ScopeOperator may additionally create, retry and cancel runs,
+	// answer human inputs and clear or fork agent sessions.
+	ScopeOperator Scope = "operator"
+)
+
+func (s Scope) allows(need Scope) bool {
+	return s == ScopeOperator || s == need
+}
+
+// readOnlyMethods lists the RPCs a ScopeRead client may call. Anything
+// not listed (including RPCs added later) requires ScopeOperator, so a
+// new mutating RPC is never exposed to read-only tokens by omission.
+var readOnlyMethods = map[string]bool{
+	"/bdtui.daemon.v1.Orchestrator/ListRuns":         true,
+	"/bdtui.daemon.v1.Orchestrator/GetRun":           true,
+	"/bdtui.daemon.v1.Orchestrator/ListHumanInputs":  true,
+	"/bdtui.daemon.v1.Orchestrator/InspectExecution": true,
+	"/bdtui.daemon.v1.Orchestrator/ReadArtifact":     true,
+	"/bdtui.daemon.v1.Orchestrator/TailExecutionLog": true,
+	"/bdtui.daemon.v1.Orchestrator/ListExecutions":   true,
+	"/bdtui.daemon.v1.Orchestrator/ListStepAttempts": true,
+	"/bdtui.daemon.v1.Orchestrator/GetRunTimeline":   true,
+	"/bdtui.daemon.v1.Orchestrator/StreamEvents":     true,
+	"/bdtui.daemon.v1.Orchestrator/GetDaemonInfo":    true,
+	"/bdtui.daemon.v1.Orchestrator/ListSessions":     true,
+	"/grpc.health.v1.Health/Check":                   true,
+	"/grpc.health.v1.Health/Watch":                   true,
+	"/grpc.health.v1.Health/List":                    true,
+}
+
+// requiredScope returns the scope needed to call fullMethod.
+func requiredScope(fullMethod string) Scope {
+	if readOnlyMethods[fullMethod] {
+		return ScopeRead
+	}
+	return ScopeOperator
+}
+
+// TokenGrant binds a bearer token to a scope.
+type TokenGrant struct {
+	Token string
+	Scope Scope
+}
+
+// LoadTokens reads a tokens file: one "<scope> <token>" pair per line,
+// blank lines and "#" comments ignored. The file holds secrets, so it is
+// rejected when group or world can read it.
+func LoadTokens(path string) ([]TokenGrant, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("tokens file %s must not be readable by group or others (mode %v)", path, info.Mode().Perm())
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var grants []TokenGrant
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: want \"<scope> <token>\"", path, n)
+		}
+		scope := Scope(fields[0])
+		if scope != ScopeRead && scope != ScopeOperator {
+			return nil, fmt.Errorf("%s:%d: unknown scope %q", path, n, fields[0])
+		}
+		grants = append(grants, TokenGrant{Token: fields[1], Scope: scope})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// authenticator resolves the scope of a remote caller from its bearer
+// token or, failing that, its verified TLS client certificate.
+type authenticator struct {
+	mu     sync.RWMutex
+	tokens []TokenGrant
+	// certScope is granted to callers that present a client certificate
+	// verified against the configured CA; empty disables cert auth.
+	certScope Scope
+}
+
+func (a *authenticator) setTokens(tokens []TokenGrant) {
+	a.mu.Lock()
+	a.tokens = tokens
+	a.mu.Unlock()
+}
+
+func (a *authenticator) scopeFor(ctx context.Context) (Scope, error) {
+	a.mu.RLock()
+	tokens := a.tokens
+	a.mu.RUnlock()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get("authorization") {
+			tok, ok := strings.CutPrefix(v, "Bearer ")
+			if !ok {
+				continue
+			}
+			for _, g := range tokens {
+				if subtle.ConstantTimeCompare([]byte(tok), []byte(g.Token)) == 1 {
+					return g.Scope, nil
+				}
+			}
+			return "", status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+	}
+	if a.certScope != "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(ti.State.VerifiedChains) > 0 {
+				return a.certScope, nil
+			}
+		}
+	}
+	return "", status.Error(codes.Unauthenticated, "bearer token or client certificate required")
+}
+
+func (a *authenticator) authorize(ctx context.Context, fullMethod string) error {
+	scope, err := a.scopeFor(ctx)
+	if err != nil {
+		return err
+	}
+	if need := requiredScope(fullMethod); !scope.allows(need) {
+		return status.Errorf(codes.PermissionDenied, "%s requires %s scope, token has %s", fullMethod, need, scope)
+	}
+	return nil
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// bearerToken attaches "authorization: Bearer <token>" to every RPC. It
+// refuses to send the token over an unencrypted transport.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (bearerToken) RequireTransportSecurity() bool { return true }
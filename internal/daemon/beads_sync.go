@@ -0,0 +1,321 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"bdtui/internal/orch"
+	"bdtui/internal/workflow"
+)
+
+// DefaultBeadsLabelPrefix prefixes the label that mirrors a run's status on
+// its bd issue, e.g. "run:running".
+const DefaultBeadsLabelPrefix = "run:"
+
+const (
+	// beadsSyncInterval is how often the sync looks for due runs.
+	beadsSyncInterval = 2 * time.Second
+	// beadsRetryBase and beadsRetryMax bound the backoff between failed
+	// syncs of the same transition.
+	beadsRetryBase = 5 * time.Second
+	beadsRetryMax  = 5 * time.Minute
+	// beadsCommandTimeout bounds one bd invocation.
+	beadsCommandTimeout = 30 * time.Second
+)
+
+// Markers delimit the run summary the sync keeps in an issue's notes. Text
+// outside them is the team's and is left alone.
+const (
+	beadsNotesBegin = "<!-- bdtui:run -->"
+	beadsNotesEnd   = "<!-- /bdtui:run -->"
+)
+
+// BeadsIssue is the part of a bd issue the sync reads.
+type BeadsIssue struct {
+	Status string
+	Labels []string
+	Notes  string
+}
+
+// BeadsUpdate is one change to a bd issue. Empty fields are left alone.
+type BeadsUpdate struct {
+	Status       string
+	AddLabels    []string
+	RemoveLabels []string
+	Notes        *string
+}
+
+// BeadsClient reads and writes bd issues of the project rooted at dir.
+type BeadsClient interface {
+	ShowIssue(ctx context.Context, dir, id string) (BeadsIssue, error)
+	UpdateIssue(ctx context.Context, dir, id string, u BeadsUpdate) error
+	CloseIssue(ctx context.Context, dir, id, reason string) error
+}
+
+// SetBeadsSync turns on syncing run lifecycles back to bd: each run
+// transition updates the run's task in its project (status in_progress
+// while the run is active, a labelPrefix+status label, a run summary in the
+// notes, and closing the task when the run completes at a close_task end
+// step). Failed syncs are retried with backoff and recorded as
+// beads.sync_failed events. An empty labelPrefix means
+// DefaultBeadsLabelPrefix. Call it before Serve.
+func (s *Server) SetBeadsSync(client BeadsClient, labelPrefix string) {
+	if labelPrefix == "" {
+		labelPrefix = DefaultBeadsLabelPrefix
+	}
+	s.service.beads = &beadsSync{client: client, labelPrefix: labelPrefix}
+}
+
+type beadsSync struct {
+	client      BeadsClient
+	labelPrefix string
+}
+
+// runBeadsSync syncs due runs until ctx ends.
+func (s *Service) runBeadsSync(ctx context.Context) {
+	ticker := time.NewTicker(beadsSyncInterval)
+	defer ticker.Stop()
+	for {
+		s.syncBeads(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncBeads syncs every run with a due, unsynced transition once.
+func (s *Service) syncBeads(ctx context.Context, now time.Time) {
+	pending, err := s.store.PendingBeadsSyncs(ctx, now)
+	if err != nil {
+		return
+	}
+	for _, b := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		payload, err := s.syncRunToBeads(ctx, b.RunID)
+		if err != nil {
+			_ = s.store.FailBeadsSync(ctx, b, err, now.Add(beadsRetryDelay(b.Attempts)))
+			continue
+		}
+		_ = s.store.CompleteBeadsSync(ctx, b, payload)
+	}
+}
+
+// beadsRetryDelay doubles from beadsRetryBase per failed attempt, up to
+// beadsRetryMax.
+func beadsRetryDelay(attempts int) time.Duration {
+	d := beadsRetryBase
+	for i := 0; i < attempts && d < beadsRetryMax; i++ {
+		d *= 2
+	}
+	return min(d, beadsRetryMax)
+}
+
+// syncRunToBeads brings the run's bd issue in line with the run's current
+// state. It compares against the issue first, so repeating it is harmless;
+// the returned payload describes what was done for the beads.synced event.
+func (s *Service) syncRunToBeads(ctx context.Context, runID string) (map[string]any, error) {
+	run, err := s.store.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]any{"run_id": run.ID, "task_id": run.TaskID, "run_status": run.Status}
+	project, err := s.store.GetProject(ctx, run.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if run.TaskID == "" || project.FsPath == "" {
+		payload["skipped"] = "no task or project path"
+		return payload, nil
+	}
+	dir, client := project.FsPath, s.beads.client
+
+	issue, err := client.ShowIssue(ctx, dir, run.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	execs, err := s.store.ListExecutionsByRun(ctx, run.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var u BeadsUpdate
+	if !run.Status.Terminal() && issue.Status != "in_progress" && issue.Status != "closed" {
+		u.Status = "in_progress"
+	}
+	want := s.beads.labelPrefix + string(run.Status)
+	for _, l := range issue.Labels {
+		if strings.HasPrefix(l, s.beads.labelPrefix) && l != want {
+			u.RemoveLabels = append(u.RemoveLabels, l)
+		}
+	}
+	if !containsString(issue.Labels, want) {
+		u.AddLabels = []string{want}
+	}
+	if notes := replaceRunSummary(issue.Notes, runSummary(run, execs)); notes != issue.Notes {
+		u.Notes = &notes
+	}
+	if u.Status != "" || len(u.AddLabels) > 0 || len(u.RemoveLabels) > 0 || u.Notes != nil {
+		if err := client.UpdateIssue(ctx, dir, run.TaskID, u); err != nil {
+			return nil, err
+		}
+	}
+	payload["label"] = want
+	if u.Status != "" {
+		payload["issue_status"] = u.Status
+	}
+
+	if run.Status == orch.RunCompleted && run.CurrentStepID != nil && issue.Status != "closed" {
+		closes, err := workflow.SnapshotClosesTask(run.WorkflowSnapshot, *run.CurrentStepID)
+		if err != nil {
+			return nil, err
+		}
+		if closes {
+			if err := client.CloseIssue(ctx, dir, run.TaskID, "completed by run "+run.ID); err != nil {
+				return nil, err
+			}
+			payload["issue_status"] = "closed"
+		}
+	}
+	return payload, nil
+}
+
+// runSummary is the notes block describing a run: its status, where it
+// stopped, why, its usage and the last commit it produced.
+func runSummary(run *orch.Run, execs []orch.Execution) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "bdtui run %s: %s\n", run.ID, run.Status)
+	if run.CurrentStepID != nil {
+		fmt.Fprintf(&b, "step: %s\n", *run.CurrentStepID)
+	}
+	if run.NeedsAttentionReason != nil {
+		fmt.Fprintf(&b, "needs attention: %s\n", *run.NeedsAttentionReason)
+	}
+	if run.Error != nil {
+		fmt.Fprintf(&b, "error: %s\n", *run.Error)
+	}
+	if t := run.Usage.Tokens(); t > 0 || run.Usage.CostUSD > 0 {
+		fmt.Fprintf(&b, "usage: %d tokens, $%.2f\n", t, run.Usage.CostUSD)
+	}
+	for i := len(execs) - 1; i >= 0; i-- {
+		if c := execs[i].ResultCommit; c != nil && *c != "" {
+			fmt.Fprintf(&b, "commit: %s\n", *c)
+			break
+		}
+	}
+	return b.String()
+}
+
+// replaceRunSummary puts summary between the run markers in notes,
+// replacing the previous summary or appending a new block.
+func replaceRunSummary(notes, summary string) string {
+	block := beadsNotesBegin + "\n" + summary + beadsNotesEnd
+	if i := strings.Index(notes, beadsNotesBegin); i >= 0 {
+		if j := strings.Index(notes[i:], beadsNotesEnd); j >= 0 {
+			return notes[:i] + block + notes[i+j+len(beadsNotesEnd):]
+		}
+	}
+	if strings.TrimSpace(notes) == "" {
+		return block
+	}
+	return strings.TrimRight(notes, "\n") + "\n\n" + block
+}
+
+func containsString(xs []string, s string) bool {
+	for _, x := range xs {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// BdCLI is the BeadsClient that runs the bd binary in the project root.
+type BdCLI struct {
+	// Bin is the bd executable; empty means "bd" on PATH.
+	Bin string
+}
+
+func (c BdCLI) run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, beadsCommandTimeout)
+	defer cancel()
+	bin := c.Bin
+	if bin == "" {
+		bin = "bd"
+	}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = strings.TrimSpace(stdout.String())
+		}
+		return nil, fmt.Errorf("bd %s: %w: %s", args[0], err, msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// ShowIssue runs `bd show <id> --json`, which prints the issue alone or in
+// a one-element array, possibly after warnings.
+func (c BdCLI) ShowIssue(ctx context.Context, dir, id string) (BeadsIssue, error) {
+	out, err := c.run(ctx, dir, "show", id, "--json")
+	if err != nil {
+		return BeadsIssue{}, err
+	}
+	if i := bytes.IndexAny(out, "[{"); i > 0 {
+		out = out[i:]
+	}
+	type raw struct {
+		Status string   `json:"status"`
+		Labels []string `json:"labels"`
+		Notes  string   `json:"notes"`
+	}
+	var issues []raw
+	if err := json.Unmarshal(out, &issues); err != nil {
+		var one raw
+		if err := json.Unmarshal(out, &one); err != nil {
+			return BeadsIssue{}, fmt.Errorf("bd show %s: %w", id, err)
+		}
+		issues = []raw{one}
+	}
+	if len(issues) == 0 {
+		return BeadsIssue{}, errors.New("bd show " + id + ": no issue")
+	}
+	return BeadsIssue{Status: issues[0].Status, Labels: issues[0].Labels, Notes: issues[0].Notes}, nil
+}
+
+// UpdateIssue runs one `bd update` with the changed fields.
+func (c BdCLI) UpdateIssue(ctx context.Context, dir, id string, u BeadsUpdate) error {
+	args := []string{"update", id}
+	if u.Status != "" {
+		args = append(args, "--status", u.Status)
+	}
+	for _, l := range u.AddLabels {
+		args = append(args, "--add-label", l)
+	}
+	for _, l := range u.RemoveLabels {
+		args = append(args, "--remove-label", l)
+	}
+	if u.Notes != nil {
+		args = append(args, "--notes", *u.Notes)
+	}
+	_, err := c.run(ctx, dir, args...)
+	return err
+}
+
+// CloseIssue runs `bd close <id> --reason <reason>`.
+func (c BdCLI) CloseIssue(ctx context.Context, dir, id, reason string) error {
+	_, err := c.run(ctx, dir, "close", id, "--reason", reason)
+	return err
+}
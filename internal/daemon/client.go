@@ -2,24 +2,34 @@ package daemon
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"bdtui/internal/daemon/daemonpb"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// healthCheckTimeout bounds the health check and info probe EnsureDaemon
+// and RestartDaemon run against an existing socket.
+const healthCheckTimeout = 2 * time.Second
+
 // Client wraps a gRPC connection and the generated Orchestrator client.
 type Client struct {
-	conn *grpc.ClientConn
+	conn   *grpc.ClientConn
+	health grpc_health_v1.HealthClient
 	daemonpb.OrchestratorClient
 }
 
@@ -31,25 +41,116 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-// Dial connects to a running daemon at socketPath. It does not auto-start.
-func Dial(socketPath string) (*Client, error) {
-	conn, err := grpc.NewClient(
-		"passthrough:///bdtuid",
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
-			d := net.Dialer{}
-			return d.DialContext(ctx, "unix", socketPath)
-		}),
-	)
+// tcpScheme prefixes remote daemon targets ("tcp://buildbox:7443").
+const tcpScheme = "tcp://"
+
+// IsRemoteTarget reports whether target names a TCP daemon rather than a
+// Unix socket path.
+func IsRemoteTarget(target string) bool {
+	return strings.HasPrefix(target, tcpScheme)
+}
+
+// DialOption configures Dial for remote targets.
+type DialOption func(*dialConfig)
+
+type dialConfig struct {
+	caFile     string
+	certFile   string
+	keyFile    string
+	token      string
+	serverName string
+}
+
+// WithCAFile verifies the daemon's TLS certificate against the PEM CA in
+// path instead of the system roots.
+func WithCAFile(path string) DialOption {
+	return func(c *dialConfig) { c.caFile = path }
+}
+
+// WithClientCert presents a client certificate for mTLS.
+func WithClientCert(certFile, keyFile string) DialOption {
+	return func(c *dialConfig) { c.certFile, c.keyFile = certFile, keyFile }
+}
+
+// WithToken sends token as a bearer token on every RPC.
+func WithToken(token string) DialOption {
+	return func(c *dialConfig) { c.token = token }
+}
+
+// WithServerName overrides the name checked against the daemon's
+// certificate (defaults to the host part of the target).
+func WithServerName(name string) DialOption {
+	return func(c *dialConfig) { c.serverName = name }
+}
+
+// Dial connects to a running daemon. target is either a Unix socket path
+// or "tcp://host:port"; TCP connections always use TLS and take their
+// credentials from opts, which are ignored for Unix sockets. It does not
+// auto-start.
+func Dial(target string, opts ...DialOption) (*Client, error) {
+	var dialOpts []grpc.DialOption
+	addr := "passthrough:///bdtuid"
+	if IsRemoteTarget(target) {
+		var cfg dialConfig
+		for _, o := range opts {
+			o(&cfg)
+		}
+		hostPort := strings.TrimPrefix(target, tcpScheme)
+		tlsCfg, err := cfg.tlsConfig(hostPort)
+		if err != nil {
+			return nil, err
+		}
+		addr = "passthrough:///" + hostPort
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+		if cfg.token != "" {
+			dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(cfg.token)))
+		}
+	} else {
+		dialOpts = append(dialOpts,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				d := net.Dialer{}
+				return d.DialContext(ctx, "unix", target)
+			}),
+		)
+	}
+	conn, err := grpc.NewClient(addr, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return &Client{
-		conn:              conn,
+		conn:               conn,
+		health:             grpc_health_v1.NewHealthClient(conn),
 		OrchestratorClient: daemonpb.NewOrchestratorClient(conn),
 	}, nil
 }
 
+func (c dialConfig) tlsConfig(hostPort string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: c.serverName}
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			return nil, fmt.Errorf("daemon target %q: %w", hostPort, err)
+		}
+		cfg.ServerName = host
+	}
+	if c.caFile != "" {
+		pool, err := loadCertPool(c.caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if c.certFile != "" || c.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
 // Options configures client auto-start behavior.
 type Options struct {
 	SocketPath string
@@ -60,6 +161,8 @@ type Options struct {
 	// StartTimeout bounds how long EnsureDaemon waits for the socket after
 	// spawning the daemon.
 	StartTimeout time.Duration
+	// Dial carries credentials for a remote "tcp://" SocketPath.
+	Dial []DialOption
 }
 
 func (o Options) withDefaults() Options {
@@ -80,11 +183,101 @@ func (o Options) withDefaults() Options {
 
 // EnsureDaemon returns a client for a running daemon, spawning one first if no
 // live socket is present. The daemon is detached so it survives the client.
+//
+// A socket that dials is not enough: the daemon must also answer the gRPC
+// health check. A daemon that is shutting down (NOT_SERVING) is given
+// StartTimeout to release the socket and is then replaced.
 func EnsureDaemon(ctx context.Context, opts Options) (*Client, error) {
 	opts = opts.withDefaults()
 
+	if IsRemoteTarget(opts.SocketPath) {
+		// A remote daemon is never auto-started from here; it only has
+		// to be healthy.
+		c, err := Dial(opts.SocketPath, opts.Dial...)
+		if err != nil {
+			return nil, err
+		}
+		hctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		defer cancel()
+		if err := c.CheckHealth(hctx); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("daemon at %s is unhealthy: %w", opts.SocketPath, err)
+		}
+		return c, nil
+	}
+
+	if socketAlive(ctx, opts.SocketPath) {
+		c, err := Dial(opts.SocketPath)
+		if err != nil {
+			return nil, err
+		}
+		hctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		herr := c.CheckHealth(hctx)
+		cancel()
+		if herr == nil {
+			return c, nil
+		}
+		_ = c.Close()
+		if err := waitForDaemonExit(ctx, opts); err != nil {
+			return nil, fmt.Errorf("daemon at %s is unhealthy: %w", opts.SocketPath, herr)
+		}
+	}
+	if err := startDaemon(ctx, opts); err != nil {
+		return nil, err
+	}
+	return Dial(opts.SocketPath)
+}
+
+// ErrDaemonNotRunning is returned by DialRunning when no daemon listens on
+// the socket.
+var ErrDaemonNotRunning = errors.New("daemon: not running")
+
+// DialRunning returns a client for a daemon that is already running and
+// healthy. Unlike EnsureDaemon it never starts one, so background pollers
+// can use it without spawning bdtuid for a user who never launched a run.
+func DialRunning(ctx context.Context, opts Options) (*Client, error) {
+	opts = opts.withDefaults()
+	var dial []DialOption
+	if IsRemoteTarget(opts.SocketPath) {
+		dial = opts.Dial
+	} else if !socketAlive(ctx, opts.SocketPath) {
+		return nil, ErrDaemonNotRunning
+	}
+	c, err := Dial(opts.SocketPath, dial...)
+	if err != nil {
+		return nil, err
+	}
+	hctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := c.CheckHealth(hctx); err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("daemon at %s is unhealthy: %w", opts.SocketPath, err)
+	}
+	return c, nil
+}
+
+// RestartDaemon stops the daemon bound to opts.SocketPath with SIGTERM,
+// waits for it to release the socket (including any drain of running
+// executions) and starts a fresh one from opts.Binary. The TUI uses it to replace a stale daemon after an upgrade.
+// The pid comes from GetDaemonInfo; a daemon that cannot report it is left
+// alone and an error is returned.
+func RestartDaemon(ctx context.Context, opts Options) (*Client, error) {
+	opts = opts.withDefaults()
+	if IsRemoteTarget(opts.SocketPath) {
+		return nil, fmt.Errorf("cannot restart remote daemon %s", opts.SocketPath)
+	}
+
 	if socketAlive(ctx, opts.SocketPath) {
-		return Dial(opts.SocketPath)
+		pid, err := daemonPID(ctx, opts.SocketPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return nil, fmt.Errorf("stop daemon pid %d: %w", pid, err)
+		}
+		if err := waitForDaemonExit(ctx, opts); err != nil {
+			return nil, fmt.Errorf("daemon pid %d did not stop: %w", pid, err)
+		}
 	}
 	if err := startDaemon(ctx, opts); err != nil {
 		return nil, err
@@ -92,6 +285,27 @@ func EnsureDaemon(ctx context.Context, opts Options) (*Client, error) {
 	return Dial(opts.SocketPath)
 }
 
+// daemonPID asks the daemon serving socketPath for its pid. There is
+// deliberately no pidfile fallback: a stale pidfile may name a recycled pid,
+// and signalling it would hit an unrelated process.
+func daemonPID(ctx context.Context, socketPath string) (int, error) {
+	c, err := Dial(socketPath)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	ictx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	info, err := c.GetDaemonInfo(ictx, &daemonpb.GetDaemonInfoRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("daemon at %s did not report its pid (stop it manually): %w", socketPath, err)
+	}
+	if info.Pid <= 0 {
+		return 0, fmt.Errorf("daemon at %s reported invalid pid %d", socketPath, info.Pid)
+	}
+	return int(info.Pid), nil
+}
+
 func startDaemon(ctx context.Context, opts Options) error {
 	logPath := filepath.Join(StateDir(), "bdtuid.log")
 	var out io.Writer = io.Discard
@@ -131,6 +345,54 @@ func socketAlive(ctx context.Context, socketPath string) bool {
 	return true
 }
 
+// waitForDaemonExit waits for the daemon on opts.SocketPath to release the
+// socket and then its singleton lock; the socket goes first, and a
+// successor started in between would fail to take the lock. The budget is opts.StartTimeout, extended past the drain deadline
+// a draining daemon reports through GetDaemonInfo, so a shutdown that waits
+// for running executions is not mistaken for a hung daemon.
+func waitForDaemonExit(ctx context.Context, opts Options) error {
+	deadline := time.Now().Add(opts.StartTimeout)
+	for {
+		if !socketAlive(ctx, opts.SocketPath) && lockFree(LockPath(opts.SocketPath)) {
+			return nil
+		}
+		if d := reportedDrainDeadline(ctx, opts.SocketPath); !d.IsZero() {
+			if ext := d.Add(opts.StartTimeout); ext.After(deadline) {
+				deadline = ext
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the daemon on %s to exit", opts.SocketPath)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// reportedDrainDeadline returns the drain deadline of the daemon on
+// socketPath, or the zero time if it is not draining or cannot be asked.
+func reportedDrainDeadline(ctx context.Context, socketPath string) time.Time {
+	c, err := Dial(socketPath)
+	if err != nil {
+		return time.Time{}
+	}
+	defer c.Close()
+	ictx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	info, err := c.GetDaemonInfo(ictx, &daemonpb.GetDaemonInfoRequest{})
+	if err != nil || !info.Draining || info.DrainDeadline == "" {
+		return time.Time{}
+	}
+	d, err := time.Parse(time.RFC3339Nano, info.DrainDeadline)
+	if err != nil {
+		return time.Time{}
+	}
+	return d
+}
+
 func waitForSocket(ctx context.Context, socketPath string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
 	for {
@@ -27,6 +27,7 @@ func runToProto(r *orch.Run) *daemonpb.Run {
 		Status:               string(r.Status),
 		WorkflowSnapshotRef:  r.WorkflowSnapshotRef,
 		WorkflowSnapshot:     r.WorkflowSnapshot,
+		TaskSnapshot:         r.TaskSnapshot,
 		CurrentStepId:        r.CurrentStepID,
 		NeedsAttentionReason: r.NeedsAttentionReason,
 		Error:                r.Error,
@@ -34,9 +35,16 @@ func runToProto(r *orch.Run) *daemonpb.Run {
 		UpdatedAt:            timeToProto(r.UpdatedAt),
 		StartedAt:            timePtrToProto(r.StartedAt),
 		CompletedAt:          timePtrToProto(r.CompletedAt),
+		MaxTokens:            r.Budget.MaxTokens,
+		MaxCostUsd:           r.Budget.MaxCostUSD,
+		Usage:                usageToProto(r.Usage),
 	}
 }
 
+func usageToProto(u orch.Usage) *daemonpb.Usage {
+	return &daemonpb.Usage{InputTokens: u.InputTokens, OutputTokens: u.OutputTokens, CostUsd: u.CostUSD}
+}
+
 func humanInputToProto(h *orch.HumanInput) *daemonpb.HumanInput {
 	return &daemonpb.HumanInput{
 		Id:            h.ID,
@@ -62,6 +70,8 @@ func executionToProto(e *orch.Execution) *daemonpb.Execution {
 		ProcessId:     e.ProcessID,
 		PromptRef:     e.PromptRef,
 		PromptHash:    e.PromptHash,
+		TranscriptRef: e.TranscriptRef,
+		Usage:         usageToProto(e.Usage),
 		ResultJson:    e.ResultJSON,
 		ResultCommit:  e.ResultCommit,
 		Error:         e.Error,
@@ -93,3 +103,51 @@ func eventToProto(e *orch.Event) *daemonpb.Event {
 		CreatedAt: timeToProto(e.CreatedAt),
 	}
 }
+
+func stepAttemptToProto(sa *orch.StepAttempt) *daemonpb.StepAttempt {
+	return &daemonpb.StepAttempt{
+		Id:          sa.ID,
+		RunId:       sa.RunID,
+		StepId:      sa.StepID,
+		Attempt:     int32(sa.Attempt),
+		Status:      string(sa.Status),
+		Inputs:      sa.Inputs,
+		Result:      sa.Result,
+		Error:       sa.Error,
+		CreatedAt:   timeToProto(sa.CreatedAt),
+		UpdatedAt:   timeToProto(sa.UpdatedAt),
+		StartedAt:   timePtrToProto(sa.StartedAt),
+		CompletedAt: timePtrToProto(sa.CompletedAt),
+	}
+}
+
+func timelineEntryToProto(e *orch.TimelineEntry) *daemonpb.TimelineEntry {
+	return &daemonpb.TimelineEntry{
+		Kind:          string(e.Kind),
+		Id:            e.ID,
+		At:            timeToProto(e.At),
+		EndedAt:       timePtrToProto(e.EndedAt),
+		DurationMs:    e.Duration.Milliseconds(),
+		StepAttemptId: e.StepAttemptID,
+		ExecutionId:   e.ExecutionID,
+		StepId:        e.StepID,
+		Attempt:       int32(e.Attempt),
+		Status:        e.Status,
+		Summary:       e.Summary,
+		Detail:        e.Detail,
+		Error:         e.Error,
+		Seq:           e.Seq,
+	}
+}
+
+func sessionToProto(se *orch.Session) *daemonpb.Session {
+	return &daemonpb.Session{
+		RunId:       se.RunID,
+		RoleId:      se.RoleID,
+		SessionId:   se.SessionID,
+		ForkPending: se.ForkPending,
+		ForkedFrom:  se.ForkedFrom,
+		CreatedAt:   timeToProto(se.CreatedAt),
+		UpdatedAt:   timeToProto(se.UpdatedAt),
+	}
+}
@@ -1,19 +1,36 @@
 package daemon
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strconv"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
 
+	"bdtui/internal/agent"
 	"bdtui/internal/daemon/daemonpb"
 	"bdtui/internal/orch"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
@@ -168,6 +185,248 @@ func TestCancelRun(t *testing.T) {
 	}
 }
 
+// fakeStopper records the executions CancelRun stops, failing the ones
+// listed in fail.
+type fakeStopper struct {
+	stopped chan agent.Execution
+	fail    map[string]bool
+}
+
+func (f *fakeStopper) Stop(_ context.Context, exec agent.Execution) error {
+	f.stopped <- exec
+	if f.fail[exec.ID] {
+		return errors.New("pane is gone")
+	}
+	return nil
+}
+
+func TestCancelRunStopsExecutions(t *testing.T) {
+	store, project, _ := startTestServer(t)
+	ctx := context.Background()
+	svc := NewService(store)
+	stopper := &fakeStopper{stopped: make(chan agent.Execution, 2), fail: map[string]bool{}}
+	svc.stopper = stopper
+
+	run := &orch.Run{ProjectID: project.ID, TaskID: "task-stop", Status: orch.RunQueued}
+	if err := store.CreateRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.TransitionRun(ctx, run.ID, orch.RunRunning); err != nil {
+		t.Fatal(err)
+	}
+	sa, err := store.StartStepAttempt(ctx, run.ID, "implement", "{}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pane := "%3"
+	agentExec := &orch.Execution{RunID: run.ID, StepAttemptID: sa.ID, Kind: orch.KindAgent, Status: orch.ExecRunning, PaneID: &pane}
+	humanExec := &orch.Execution{RunID: run.ID, StepAttemptID: sa.ID, Kind: orch.KindHuman, Status: orch.ExecRunning}
+	for _, e := range []*orch.Execution{agentExec, humanExec} {
+		if err := store.CreateExecution(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stopper.fail[agentExec.ID] = true
+
+	got, err := svc.CancelRun(ctx, &daemonpb.CancelRunRequest{Id: run.ID})
+	if err != nil {
+		t.Fatalf("CancelRun: %v", err)
+	}
+	if got.Status != string(orch.RunCancelled) {
+		t.Fatalf("status = %q, want cancelled", got.Status)
+	}
+	select {
+	case exec := <-stopper.stopped:
+		if exec.ID != agentExec.ID || exec.PaneID != pane {
+			t.Fatalf("stopped %+v, want the agent execution in pane %s", exec, pane)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("agent execution was not stopped")
+	}
+	if e, _ := store.GetExecution(ctx, agentExec.ID); e.Status != orch.ExecCancelled {
+		t.Fatalf("execution status = %s, want cancelled", e.Status)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for !hasEvent(t, store, run.ID, orch.EventExecStopFailed) {
+		if time.Now().After(deadline) {
+			t.Fatal("no execution.stop_failed event for the failed stop")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case exec := <-stopper.stopped:
+		t.Fatalf("stopped %+v; human executions have no process", exec)
+	default:
+	}
+}
+
+// fakeBeads is an in-memory bd issue that records the calls made to it.
+type fakeBeads struct {
+	issue   BeadsIssue
+	updates []BeadsUpdate
+	closed  bool
+	fail    error
+}
+
+func (f *fakeBeads) ShowIssue(context.Context, string, string) (BeadsIssue, error) {
+	return f.issue, f.fail
+}
+
+func (f *fakeBeads) UpdateIssue(_ context.Context, _, _ string, u BeadsUpdate) error {
+	f.updates = append(f.updates, u)
+	if u.Status != "" {
+		f.issue.Status = u.Status
+	}
+	var labels []string
+	for _, l := range f.issue.Labels {
+		if !containsString(u.RemoveLabels, l) {
+			labels = append(labels, l)
+		}
+	}
+	f.issue.Labels = append(labels, u.AddLabels...)
+	if u.Notes != nil {
+		f.issue.Notes = *u.Notes
+	}
+	return nil
+}
+
+func (f *fakeBeads) CloseIssue(context.Context, string, string, string) error {
+	f.closed = true
+	f.issue.Status = "closed"
+	return nil
+}
+
+func TestBeadsSyncFollowsRunLifecycle(t *testing.T) {
+	store, project, _ := startTestServer(t)
+	ctx := context.Background()
+	svc := NewService(store)
+	bd := &fakeBeads{issue: BeadsIssue{Status: "open", Labels: []string{"backend", "run:failed"}, Notes: "Ask Sam first."}}
+	svc.beads = &beadsSync{client: bd, labelPrefix: DefaultBeadsLabelPrefix}
+
+	snapshot := `{"workflow":{"steps":[{"id":"plan","type":"agent"},{"id":"done","type":"end","close_task":true}]}}`
+	run := &orch.Run{ProjectID: project.ID, TaskID: "task-sync", Status: orch.RunQueued, WorkflowSnapshot: snapshot}
+	if err := store.CreateRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.TransitionRun(ctx, run.ID, orch.RunRunning); err != nil {
+		t.Fatal(err)
+	}
+	svc.syncBeads(ctx, time.Now())
+
+	if bd.issue.Status != "in_progress" || !reflect.DeepEqual(bd.issue.Labels, []string{"backend", "run:running"}) {
+		t.Fatalf("issue after start = %+v", bd.issue)
+	}
+	if !strings.HasPrefix(bd.issue.Notes, "Ask Sam first.\n\n"+beadsNotesBegin+"\nbdtui run "+run.ID+": running\n") {
+		t.Fatalf("notes = %q", bd.issue.Notes)
+	}
+	if !hasEvent(t, store, run.ID, orch.EventBeadsSynced) {
+		t.Fatal("no beads.synced event")
+	}
+
+	// Syncing again changes nothing.
+	n := len(bd.updates)
+	if _, err := svc.syncRunToBeads(ctx, run.ID); err != nil || len(bd.updates) != n {
+		t.Fatalf("repeated sync: %v, %d updates, want %d", err, len(bd.updates), n)
+	}
+
+	// A failed sync is recorded and retried with backoff.
+	bd.fail = errors.New("bd: database locked")
+	step := "done"
+	if err := store.SetRunCurrentStep(ctx, run.ID, &step); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.TransitionRun(ctx, run.ID, orch.RunCompleted); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	svc.syncBeads(ctx, now)
+	if !hasEvent(t, store, run.ID, orch.EventBeadsSyncFailed) || bd.closed {
+		t.Fatalf("failed sync: closed=%v", bd.closed)
+	}
+	bd.fail = nil
+	svc.syncBeads(ctx, now)
+	if bd.closed {
+		t.Fatal("retried before the backoff elapsed")
+	}
+	svc.syncBeads(ctx, now.Add(beadsRetryBase))
+	if !bd.closed || !containsString(bd.issue.Labels, "run:completed") || containsString(bd.issue.Labels, "run:running") {
+		t.Fatalf("issue after completion = %+v, closed=%v", bd.issue, bd.closed)
+	}
+	if strings.Count(bd.issue.Notes, beadsNotesBegin) != 1 || !strings.Contains(bd.issue.Notes, "step: done\n") {
+		t.Fatalf("notes after completion = %q", bd.issue.Notes)
+	}
+}
+
+func TestCreateRunRecordsProjectPath(t *testing.T) {
+	store, client := startBareTestServer(t)
+	ctx := context.Background()
+
+	for _, path := range []string{"/work/a", "/work/b"} {
+		_, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: "p", TaskId: "task" + path, ProjectPath: path})
+		if err != nil {
+			t.Fatalf("CreateRun: %v", err)
+		}
+		p, err := store.GetProject(ctx, "p")
+		if err != nil || p.FsPath != path {
+			t.Fatalf("project = %+v, %v; want fs_path %s", p, err, path)
+		}
+	}
+	_, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: "p", TaskId: "task-rel", ProjectPath: "work"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("relative project_path: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func hasEvent(t *testing.T, store *orch.Store, runID, typ string) bool {
+	t.Helper()
+	events, err := store.ListEventsByRun(context.Background(), runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range events {
+		if ev.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func TestClearAndForkSession(t *testing.T) {
+	store, project, client := startTestServer(t)
+	ctx := context.Background()
+
+	run, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-session"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	if err := store.PutSession(ctx, run.Id, "coder", "sid-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	forked, err := client.ForkSession(ctx, &daemonpb.SessionRequest{RunId: run.Id, RoleId: "coder"})
+	if err != nil {
+		t.Fatalf("fork session: %v", err)
+	}
+	if len(forked.Sessions) != 1 || !forked.Sessions[0].ForkPending || forked.Sessions[0].SessionId != "sid-1" {
+		t.Fatalf("sessions after fork = %v", forked.Sessions)
+	}
+
+	cleared, err := client.ClearSession(ctx, &daemonpb.SessionRequest{RunId: run.Id, RoleId: "coder"})
+	if err != nil {
+		t.Fatalf("clear session: %v", err)
+	}
+	if len(cleared.Sessions) != 0 {
+		t.Fatalf("sessions after clear = %v", cleared.Sessions)
+	}
+	if _, err := client.ClearSession(ctx, &daemonpb.SessionRequest{RunId: run.Id, RoleId: "coder"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("clear missing session = %v, want NotFound", err)
+	}
+	if _, err := client.ListSessions(ctx, &daemonpb.ListSessionsRequest{RunId: "nope"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("list sessions of missing run = %v, want NotFound", err)
+	}
+}
+
 func TestInspectExecution(t *testing.T) {
 	store, project, client := startTestServer(t)
 	ctx := context.Background()
@@ -213,6 +472,182 @@ func TestInspectExecution(t *testing.T) {
 	}
 }
 
+func TestReadArtifact(t *testing.T) {
+	store, project, client := startTestServer(t)
+	ctx := context.Background()
+
+	run, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-artifact"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	sa, err := store.StartStepAttempt(ctx, run.Id, "step-1", `{}`)
+	if err != nil {
+		t.Fatalf("start step: %v", err)
+	}
+	exec := &orch.Execution{RunID: run.Id, StepAttemptID: sa.ID, Kind: orch.KindAgent}
+	if err := store.CreateExecution(ctx, exec); err != nil {
+		t.Fatalf("create execution: %v", err)
+	}
+
+	root := filepath.Dir(store.Path())
+	content := bytes.Repeat([]byte("0123456789"), artifactChunkSize/5) // spans several chunks
+	rel := filepath.Join("runs", run.Id, "report.md")
+	if err := os.MkdirAll(filepath.Join(root, filepath.Dir(rel)), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, rel), content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	good := &orch.Artifact{ExecutionID: exec.ID, Name: "report.md", Path: rel, Hash: fmt.Sprintf("sha256:%x", sum)}
+	if err := store.CreateArtifact(ctx, good); err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := client.ReadArtifactBytes(ctx, good.ID, 0, 0)
+	if err != nil {
+		t.Fatalf("read artifact: %v", err)
+	}
+	if !bytes.Equal(full.Data, content) || !full.Verified || full.Size != int64(len(content)) || full.Name != "report.md" {
+		t.Fatalf("unexpected full read: size=%d verified=%v name=%q len=%d", full.Size, full.Verified, full.Name, len(full.Data))
+	}
+
+	part, err := client.ReadArtifactBytes(ctx, good.ID, 3, 4)
+	if err != nil {
+		t.Fatalf("range read: %v", err)
+	}
+	if string(part.Data) != "3456" || !part.Verified {
+		t.Fatalf("range read = %q verified=%v, want 3456 verified by the earlier full read", part.Data, part.Verified)
+	}
+
+	if _, err := client.ReadArtifactBytes(ctx, good.ID, int64(len(content))+1, 0); status.Code(err) != codes.OutOfRange {
+		t.Fatalf("offset past end = %v, want OutOfRange", err)
+	}
+
+	corrupt := &orch.Artifact{ExecutionID: exec.ID, Name: "corrupt.md", Path: rel, Hash: fmt.Sprintf("sha256:%064x", 0)}
+	if err := store.CreateArtifact(ctx, corrupt); err != nil {
+		t.Fatal(err)
+	}
+	// A ranged read does not hash, so it is served but not verified.
+	if got, err := client.ReadArtifactBytes(ctx, corrupt.ID, 0, 4); err != nil || got.Verified {
+		t.Fatalf("unhashed range read = %+v, %v", got, err)
+	}
+	if _, err := client.ReadArtifactBytes(ctx, corrupt.ID, 0, 0); status.Code(err) != codes.DataLoss {
+		t.Fatalf("hash mismatch = %v, want DataLoss", err)
+	}
+
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	escape := &orch.Artifact{ExecutionID: exec.ID, Name: "secret.txt", Path: outside, Hash: "x"}
+	if err := store.CreateArtifact(ctx, escape); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ReadArtifactBytes(ctx, escape.ID, 0, 0); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("path outside state dir = %v, want PermissionDenied", err)
+	}
+
+	if _, err := client.ReadArtifactBytes(ctx, "missing", 0, 0); status.Code(err) != codes.NotFound {
+		t.Fatalf("missing artifact = %v, want NotFound", err)
+	}
+}
+
+func TestTailExecutionLogFollows(t *testing.T) {
+	store, project, client := startTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	run, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-tail"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	sa, err := store.StartStepAttempt(ctx, run.Id, "step-1", `{}`)
+	if err != nil {
+		t.Fatalf("start step: %v", err)
+	}
+	rel := filepath.Join("runs", run.Id, "transcript.jsonl")
+	exec := &orch.Execution{RunID: run.Id, StepAttemptID: sa.ID, Kind: orch.KindAgent, TranscriptRef: rel}
+	if err := store.CreateExecution(ctx, exec); err != nil {
+		t.Fatalf("create execution: %v", err)
+	}
+	missing, err := client.TailExecutionLog(ctx, &daemonpb.TailExecutionLogRequest{ExecutionId: exec.ID})
+	if err == nil {
+		_, err = missing.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("tail before the transcript exists = %v, want NotFound", err)
+	}
+
+	// The follower starts before the runtime has created the transcript.
+	stream, err := client.TailExecutionLog(ctx, &daemonpb.TailExecutionLogRequest{ExecutionId: exec.ID, Follow: true})
+	if err != nil {
+		t.Fatalf("tail: %v", err)
+	}
+	path := filepath.Join(filepath.Dir(store.Path()), rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("line 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.TransitionExecution(ctx, exec.ID, orch.ExecRunning); err != nil {
+		t.Fatal(err)
+	}
+	first, err := stream.Recv()
+	if err != nil || string(first.Data) != "line 1\n" || first.Offset != 0 {
+		t.Fatalf("first chunk = %v, %v", first, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("line 2\n")
+	f.Close()
+	if err := store.TransitionExecution(ctx, exec.ID, orch.ExecCompleted); err != nil {
+		t.Fatal(err)
+	}
+	var rest []byte
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		rest = append(rest, chunk.Data...)
+		if chunk.Eof {
+			if chunk.Status != string(orch.ExecCompleted) || chunk.Offset != 14 {
+				t.Fatalf("eof chunk = %v", chunk)
+			}
+			break
+		}
+	}
+	if string(rest) != "line 2\n" {
+		t.Fatalf("followed data = %q", rest)
+	}
+
+	// Without follow the stream ends at the current end of file.
+	tail, err := client.TailExecutionLog(ctx, &daemonpb.TailExecutionLogRequest{ExecutionId: exec.ID, Offset: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chunk, err := tail.Recv(); err != nil || string(chunk.Data) != "line 2\n" || chunk.Offset != 7 {
+		t.Fatalf("offset read = %v, %v", chunk, err)
+	}
+
+	bare := &orch.Execution{RunID: run.Id, StepAttemptID: sa.ID, Kind: orch.KindAgent}
+	if err := store.CreateExecution(ctx, bare); err != nil {
+		t.Fatal(err)
+	}
+	noLog, err := client.TailExecutionLog(ctx, &daemonpb.TailExecutionLogRequest{ExecutionId: bare.ID})
+	if err == nil {
+		_, err = noLog.Recv()
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("execution without transcript = %v, want FailedPrecondition", err)
+	}
+}
+
 func TestAnswerHumanInput(t *testing.T) {
 	store, project, client := startTestServer(t)
 	ctx := context.Background()
@@ -247,6 +682,56 @@ func TestAnswerHumanInput(t *testing.T) {
 	}
 }
 
+func TestListStepAttemptsAndTimeline(t *testing.T) {
+	store, project, client := startTestServer(t)
+	ctx := context.Background()
+
+	run, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-timeline"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	sa, err := store.StartStepAttempt(ctx, run.Id, "revise", `{}`)
+	if err != nil {
+		t.Fatalf("start step: %v", err)
+	}
+	exec := &orch.Execution{RunID: run.Id, StepAttemptID: sa.ID, Kind: orch.KindAgent}
+	if err := store.CreateExecution(ctx, exec); err != nil {
+		t.Fatalf("create execution: %v", err)
+	}
+
+	attempts, err := client.ListStepAttempts(ctx, &daemonpb.ListStepAttemptsRequest{RunId: run.Id})
+	if err != nil {
+		t.Fatalf("list step attempts: %v", err)
+	}
+	if len(attempts.StepAttempts) != 1 || attempts.StepAttempts[0].StepId != "revise" || attempts.StepAttempts[0].Attempt != 1 {
+		t.Fatalf("unexpected attempts: %+v", attempts.StepAttempts)
+	}
+
+	tl, err := client.GetRunTimeline(ctx, &daemonpb.GetRunTimelineRequest{RunId: run.Id})
+	if err != nil {
+		t.Fatalf("get run timeline: %v", err)
+	}
+	if tl.Run.GetId() != run.Id {
+		t.Fatalf("timeline run = %q, want %q", tl.Run.GetId(), run.Id)
+	}
+	var sawExec bool
+	for _, e := range tl.Entries {
+		if e.Kind == string(orch.TimelineExecution) {
+			sawExec = e.Id == exec.ID && e.StepId == "revise" && e.Attempt == 1
+		}
+	}
+	if !sawExec {
+		t.Fatalf("execution missing from timeline: %+v", tl.Entries)
+	}
+
+	if _, err := client.GetRunTimeline(ctx, &daemonpb.GetRunTimelineRequest{RunId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetRunTimeline missing = %v, want NotFound", err)
+	}
+	if _, err := client.ListStepAttempts(ctx, &daemonpb.ListStepAttemptsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("ListStepAttempts without run_id = %v, want InvalidArgument", err)
+	}
+}
+
 func TestStreamEvents(t *testing.T) {
 	_, project, client := startTestServer(t)
 	ctx := context.Background()
@@ -312,6 +797,76 @@ func TestErrorMapping(t *testing.T) {
 	}
 }
 
+func TestGetDaemonInfoAndHealth(t *testing.T) {
+	store, project, client := startTestServer(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-queued"}); err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	running, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-running"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	if err := store.TransitionRun(ctx, running.Id, orch.RunRunning); err != nil {
+		t.Fatalf("transition run: %v", err)
+	}
+	parked, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-parked"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	for _, to := range []orch.RunStatus{orch.RunRunning, orch.RunNeedsAttention} {
+		if err := store.TransitionRun(ctx, parked.Id, to); err != nil {
+			t.Fatalf("transition run to %s: %v", to, err)
+		}
+	}
+
+	if err := client.CheckHealth(ctx); err != nil {
+		t.Fatalf("health check: %v", err)
+	}
+	info, err := client.Handshake(ctx)
+	if err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	if info.Version != Version || info.ApiVersion != APIVersion || info.DbPath != store.Path() {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if info.SchemaVersion < 1 || info.Pid != int32(os.Getpid()) {
+		t.Fatalf("unexpected schema/pid: %+v", info)
+	}
+	if info.ActiveRuns != 1 || info.QueueDepth != 1 || info.AttentionRuns != 1 {
+		t.Fatalf("active=%d queue=%d attention=%d, want 1/1/1", info.ActiveRuns, info.QueueDepth, info.AttentionRuns)
+	}
+}
+
+// TestHandshakeDetectsStaleDaemon serves an Orchestrator that predates
+// GetDaemonInfo and the health service, as an old bdtuid would.
+func TestHandshakeDetectsStaleDaemon(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "old.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	daemonpb.RegisterOrchestratorServer(srv, daemonpb.UnimplementedOrchestratorServer{})
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+
+	client, err := Dial(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := context.Background()
+	if err := client.CheckHealth(ctx); err != nil {
+		t.Fatalf("health on legacy daemon = %v, want nil", err)
+	}
+	if _, err := client.Handshake(ctx); !errors.Is(err, ErrStaleDaemon) {
+		t.Fatalf("handshake = %v, want ErrStaleDaemon", err)
+	}
+}
+
 func TestAcquireLockExclusive(t *testing.T) {
 	path := filepath.Join(t.TempDir(), "daemon.sock.lock")
 
@@ -365,6 +920,11 @@ func TestEnsureDaemonAutoStart(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// DialRunning never starts a daemon.
+	if _, err := DialRunning(ctx, Options{SocketPath: socketPath}); !errors.Is(err, ErrDaemonNotRunning) {
+		t.Fatalf("DialRunning before start = %v, want ErrDaemonNotRunning", err)
+	}
+
 	client, err := EnsureDaemon(ctx, Options{
 		SocketPath:   socketPath,
 		DBPath:       dbPath,
@@ -376,6 +936,12 @@ func TestEnsureDaemonAutoStart(t *testing.T) {
 	}
 	t.Cleanup(func() { _ = client.Close() })
 
+	running, err := DialRunning(ctx, Options{SocketPath: socketPath})
+	if err != nil {
+		t.Fatalf("DialRunning after start: %v", err)
+	}
+	_ = running.Close()
+
 	// The daemon is up and serving: a missing run yields NotFound, not a
 	// transport error.
 	_, err = client.GetRun(ctx, &daemonpb.GetRunRequest{Id: "missing"})
@@ -390,8 +956,55 @@ func TestEnsureDaemonAutoStart(t *testing.T) {
 		t.Fatalf("second daemon unexpectedly started: %s", out)
 	}
 
+	info, err := client.Handshake(ctx)
+	if err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+
+	// RestartDaemon replaces the process behind the same socket.
+	restarted, err := RestartDaemon(ctx, Options{
+		SocketPath:   socketPath,
+		DBPath:       dbPath,
+		Binary:       binPath,
+		StartTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("restart daemon: %v", err)
+	}
+	t.Cleanup(func() { _ = restarted.Close() })
+	after, err := restarted.Handshake(ctx)
+	if err != nil {
+		t.Fatalf("handshake after restart: %v", err)
+	}
+	if after.Pid == info.Pid {
+		t.Fatalf("restart kept pid %d", after.Pid)
+	}
+
+	// "bdtuid restart" hands the bound socket to a new process; the same
+	// client keeps working across the swap.
+	handoff := exec.Command(binPath, "restart", "--socket", socketPath, "--db", dbPath)
+	handoff.Env = os.Environ()
+	if out, err := handoff.CombinedOutput(); err != nil {
+		t.Fatalf("bdtuid restart: %v\n%s", err, out)
+	}
+	// Connections already open stay on the old process until its graceful
+	// stop sends GOAWAY, after which the client reconnects to the successor.
+	for {
+		handedOff, err := restarted.Handshake(ctx)
+		if err == nil && handedOff.Pid != after.Pid {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatalf("client still on pid %d after handoff: %v", after.Pid, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if _, err := os.Stat(socketPath); err != nil {
+		t.Fatalf("socket after handoff: %v", err)
+	}
+
 	// Stop the detached daemon so the test does not leak a background process.
-	pidBytes, err := os.ReadFile(socketPath + ".pid")
+	pidBytes, err := os.ReadFile(PIDPath(socketPath))
 	if err != nil {
 		t.Fatalf("read pidfile: %v", err)
 	}
@@ -509,6 +1122,65 @@ func TestCreateRunRejectsMissingTaskID(t *testing.T) {
 	}
 }
 
+func TestCreateRunTakesBudgetFromSnapshot(t *testing.T) {
+	_, client := startBareTestServer(t)
+	ctx := context.Background()
+
+	r, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{
+		ProjectId: "p", TaskId: "task-b",
+		WorkflowSnapshot: `{"workflow":{"budget":{"max_tokens":5000,"max_cost_usd":1.5}}}`,
+	})
+	if err != nil {
+		t.Fatalf("CreateRun: %v", err)
+	}
+	got, err := client.GetRun(ctx, &daemonpb.GetRunRequest{Id: r.Id})
+	if err != nil {
+		t.Fatalf("GetRun: %v", err)
+	}
+	if got.MaxTokens != 5000 || got.MaxCostUsd != 1.5 {
+		t.Fatalf("budget = %d/%v, want 5000/1.5", got.MaxTokens, got.MaxCostUsd)
+	}
+	if got.Usage == nil || got.Usage.InputTokens != 0 {
+		t.Fatalf("usage = %+v, want zero", got.Usage)
+	}
+
+	_, err = client.CreateRun(ctx, &daemonpb.CreateRunRequest{
+		ProjectId: "p", TaskId: "task-c",
+		WorkflowSnapshot: `{"workflow":{"budget":{"max_tokens":-1}}}`,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("negative budget: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestCreateRunStoresTaskSnapshot(t *testing.T) {
+	_, client := startBareTestServer(t)
+	ctx := context.Background()
+
+	snapshot := `{"id":"task-s","title":"Add export","parent":{"id":"epic-1","title":"Reporting"}}`
+	r, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: "p", TaskId: "task-s", TaskSnapshot: snapshot})
+	if err != nil {
+		t.Fatalf("CreateRun: %v", err)
+	}
+	got, err := client.GetRun(ctx, &daemonpb.GetRunRequest{Id: r.Id})
+	if err != nil {
+		t.Fatalf("GetRun: %v", err)
+	}
+	if got.TaskSnapshot != snapshot {
+		t.Fatalf("task_snapshot = %q, want %q", got.TaskSnapshot, snapshot)
+	}
+
+	for name, bad := range map[string]string{
+		"malformed":  `{"id":`,
+		"other task": `{"id":"task-x","title":"Other"}`,
+	} {
+		_, err := client.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: "p", TaskId: "task-t", TaskSnapshot: bad})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s task_snapshot: code = %v, want InvalidArgument", name, status.Code(err))
+		}
+	}
+}
+
 func listAllProjects(t *testing.T, store *orch.Store) []orch.Project {
 	t.Helper()
 	projects, err := store.ListProjects(context.Background())
@@ -517,3 +1189,395 @@ func listAllProjects(t *testing.T, store *orch.Store) []orch.Project {
 	}
 	return projects
 }
+
+// testPKI writes a throwaway CA plus a loopback server certificate and a
+// client certificate signed by it, returning the PEM file paths.
+type testPKI struct {
+	caFile, serverCert, serverKey, clientCert, clientKey string
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "bdtui test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writePEM := func(name, typ string, der []byte) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	issue := func(serial int64, cn string, usage x509.ExtKeyUsage, ips []net.IP) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  ips,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return writePEM(cn+".crt", "CERTIFICATE", der), writePEM(cn+".key", "EC PRIVATE KEY", keyDER)
+	}
+
+	pki := testPKI{caFile: writePEM("ca.crt", "CERTIFICATE", caDER)}
+	pki.serverCert, pki.serverKey = issue(2, "server", x509.ExtKeyUsageServerAuth, []net.IP{net.ParseIP("127.0.0.1")})
+	pki.clientCert, pki.clientKey = issue(3, "client", x509.ExtKeyUsageClientAuth, nil)
+	return pki
+}
+
+// startTCPTestServer serves the daemon on a Unix socket and on a loopback
+// TLS listener configured by cfg (Addr, cert and key are filled in).
+func startTCPTestServer(t *testing.T, pki testPKI, cfg TCPConfig) (*Server, *orch.Project, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	store, err := orch.Open(context.Background(), filepath.Join(dir, "orch.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	project := &orch.Project{Name: "test", FsPath: "/tmp/test"}
+	if err := store.CreateProject(context.Background(), project); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	srv := NewServer(store, filepath.Join(dir, "daemon.sock"))
+	cfg.Addr = "127.0.0.1:0"
+	cfg.CertFile, cfg.KeyFile = pki.serverCert, pki.serverKey
+	addr, err := srv.ListenTCP(cfg)
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		srv.Stop()
+		<-done
+	})
+	return srv, project, "tcp://" + addr.String()
+}
+
+func dialTCP(t *testing.T, target string, opts ...DialOption) *Client {
+	t.Helper()
+	c, err := Dial(target, opts...)
+	if err != nil {
+		t.Fatalf("dial %s: %v", target, err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestTCPListenerTokenScopes(t *testing.T) {
+	pki := newTestPKI(t)
+	_, project, target := startTCPTestServer(t, pki, TCPConfig{Tokens: []TokenGrant{
+		{Token: "op-secret", Scope: ScopeOperator},
+		{Token: "ro-secret", Scope: ScopeRead},
+	}})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	operator := dialTCP(t, target, WithCAFile(pki.caFile), WithToken("op-secret"))
+	run, err := operator.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-remote"})
+	if err != nil {
+		t.Fatalf("operator CreateRun: %v", err)
+	}
+
+	reader := dialTCP(t, target, WithCAFile(pki.caFile), WithToken("ro-secret"))
+	if _, err := reader.GetRun(ctx, &daemonpb.GetRunRequest{Id: run.Id}); err != nil {
+		t.Fatalf("read-only GetRun: %v", err)
+	}
+	if err := reader.CheckHealth(ctx); err != nil {
+		t.Fatalf("read-only health: %v", err)
+	}
+	if _, err := reader.CancelRun(ctx, &daemonpb.CancelRunRequest{Id: run.Id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("read-only CancelRun = %v, want PermissionDenied", err)
+	}
+
+	stream, err := reader.StreamEvents(ctx, &daemonpb.StreamEventsRequest{RunId: run.Id})
+	if err != nil {
+		t.Fatalf("read-only StreamEvents: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("read-only StreamEvents recv: %v", err)
+	}
+
+	anon := dialTCP(t, target, WithCAFile(pki.caFile))
+	if _, err := anon.ListRuns(ctx, &daemonpb.ListRunsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("anonymous ListRuns = %v, want Unauthenticated", err)
+	}
+	wrong := dialTCP(t, target, WithCAFile(pki.caFile), WithToken("guess"))
+	if _, err := wrong.ListRuns(ctx, &daemonpb.ListRunsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("bad token ListRuns = %v, want Unauthenticated", err)
+	}
+	untrusted := dialTCP(t, target, WithToken("op-secret"))
+	if _, err := untrusted.ListRuns(ctx, &daemonpb.ListRunsRequest{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("unverified server cert = %v, want Unavailable", err)
+	}
+}
+
+func TestTCPListenerClientCert(t *testing.T) {
+	pki := newTestPKI(t)
+	_, _, target := startTCPTestServer(t, pki, TCPConfig{ClientCAFile: pki.caFile, ClientCertScope: ScopeRead})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := dialTCP(t, target, WithCAFile(pki.caFile), WithClientCert(pki.clientCert, pki.clientKey))
+	if _, err := c.ListRuns(ctx, &daemonpb.ListRunsRequest{}); err != nil {
+		t.Fatalf("mTLS ListRuns: %v", err)
+	}
+	if _, err := c.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: "p", TaskId: "t"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("mTLS read-only CreateRun = %v, want PermissionDenied", err)
+	}
+
+	anon := dialTCP(t, target, WithCAFile(pki.caFile))
+	if _, err := anon.ListRuns(ctx, &daemonpb.ListRunsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("no client cert ListRuns = %v, want Unauthenticated", err)
+	}
+}
+
+func TestListenTCPRequiresTLSAndAuth(t *testing.T) {
+	pki := newTestPKI(t)
+	dir := t.TempDir()
+	store, err := orch.Open(context.Background(), filepath.Join(dir, "orch.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	srv := NewServer(store, filepath.Join(dir, "d.sock"))
+	if _, err := srv.ListenTCP(TCPConfig{Addr: "127.0.0.1:0", Tokens: []TokenGrant{{Token: "x", Scope: ScopeRead}}}); err == nil {
+		t.Fatal("ListenTCP without TLS should fail")
+	}
+	if _, err := srv.ListenTCP(TCPConfig{Addr: "127.0.0.1:0", CertFile: pki.serverCert, KeyFile: pki.serverKey}); err == nil {
+		t.Fatal("ListenTCP without tokens or client CA should fail")
+	}
+}
+
+func TestLoadTokens(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tokens")
+	body := "# remote access\noperator op-secret\n\nread ro-secret\n"
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadTokens(path)
+	if err != nil {
+		t.Fatalf("LoadTokens: %v", err)
+	}
+	if len(got) != 2 || got[0] != (TokenGrant{Token: "op-secret", Scope: ScopeOperator}) || got[1].Scope != ScopeRead {
+		t.Fatalf("unexpected grants: %+v", got)
+	}
+
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTokens(path); err == nil {
+		t.Fatal("world-readable tokens file should be rejected")
+	}
+
+	bad := filepath.Join(dir, "bad")
+	if err := os.WriteFile(bad, []byte("admin tok\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTokens(bad); err == nil {
+		t.Fatal("unknown scope should be rejected")
+	}
+}
+
+// startDrainExecution creates a run with one running execution.
+func startDrainExecution(t *testing.T, store *orch.Store, projectID, taskID string) *orch.Execution {
+	t.Helper()
+	ctx := context.Background()
+	run := newDrainRun(t, store, projectID, taskID)
+	sa, err := store.StartStepAttempt(ctx, run.ID, "step-1", `{}`)
+	if err != nil {
+		t.Fatalf("start step: %v", err)
+	}
+	exec := &orch.Execution{RunID: run.ID, StepAttemptID: sa.ID, Kind: orch.KindAgent, PromptRef: "p", PromptHash: "h"}
+	if err := store.CreateExecution(ctx, exec); err != nil {
+		t.Fatalf("create execution: %v", err)
+	}
+	if err := store.TransitionExecution(ctx, exec.ID, orch.ExecRunning); err != nil {
+		t.Fatalf("start execution: %v", err)
+	}
+	return exec
+}
+
+func newDrainRun(t *testing.T, store *orch.Store, projectID, taskID string) *orch.Run {
+	t.Helper()
+	run := &orch.Run{ProjectID: projectID, TaskID: taskID}
+	if err := store.CreateRun(context.Background(), run); err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	return run
+}
+
+func countDetached(t *testing.T, store *orch.Store, runID string) int {
+	t.Helper()
+	events, err := store.ListEventsByRun(context.Background(), runID)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	n := 0
+	for _, e := range events {
+		if e.Type == orch.EventExecDetached {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDrainRejectsNewRunsAndDetachesExecutions(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := orch.Open(ctx, filepath.Join(dir, "orch.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	project := &orch.Project{Name: "test", FsPath: "/tmp/test"}
+	if err := store.CreateProject(ctx, project); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	// Left running by an earlier daemon: not driven by this one.
+	stale := startDrainExecution(t, store, project.ID, "task-stale")
+	time.Sleep(5 * time.Millisecond)
+
+	srv := NewServer(store, filepath.Join(dir, "daemon.sock"))
+	owned := startDrainExecution(t, store, project.ID, "task-owned")
+
+	start := time.Now()
+	detached, err := srv.Drain(ctx, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if detached != 2 {
+		t.Fatalf("detached = %d, want 2", detached)
+	}
+	if waited := time.Since(start); waited < 200*time.Millisecond {
+		t.Fatalf("drain returned after %s, before the deadline for the owned execution", waited)
+	}
+	info, err := srv.service.GetDaemonInfo(ctx, &daemonpb.GetDaemonInfoRequest{})
+	if err != nil || !info.Draining || info.DrainDeadline == "" {
+		t.Fatalf("GetDaemonInfo while draining = %+v, %v", info, err)
+	}
+	resp, err := srv.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: orchestratorServiceName})
+	if err != nil || resp.Status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("health while draining = %v, %v", resp, err)
+	}
+	if _, err := srv.service.CreateRun(ctx, &daemonpb.CreateRunRequest{ProjectId: project.ID, TaskId: "task-late"}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("CreateRun while draining = %v, want Unavailable", err)
+	}
+
+	// A second shutdown neither waits on nor re-detaches the same rows.
+	start = time.Now()
+	if detached, err := srv.Drain(ctx, 5*time.Second); err != nil || detached != 0 {
+		t.Fatalf("second drain = %d, %v", detached, err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("second drain waited on already-detached executions")
+	}
+	for _, e := range []*orch.Execution{stale, owned} {
+		if n := countDetached(t, store, e.RunID); n != 1 {
+			t.Fatalf("run %s has %d %s events, want 1", e.RunID, n, orch.EventExecDetached)
+		}
+	}
+}
+
+func TestDrainWithoutOwnedExecutionsReturnsImmediately(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := orch.Open(ctx, filepath.Join(dir, "orch.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	project := &orch.Project{Name: "test", FsPath: "/tmp/test"}
+	if err := store.CreateProject(ctx, project); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	stale := startDrainExecution(t, store, project.ID, "task-crashed")
+	time.Sleep(5 * time.Millisecond)
+	srv := NewServer(store, filepath.Join(dir, "daemon.sock"))
+
+	start := time.Now()
+	detached, err := srv.Drain(ctx, 30*time.Second)
+	if err != nil || detached != 1 {
+		t.Fatalf("drain = %d, %v", detached, err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("drain waited on an execution left by a crashed daemon")
+	}
+	if n := countDetached(t, store, stale.RunID); n != 1 {
+		t.Fatalf("detached events = %d, want 1", n)
+	}
+}
+
+func TestReloadTCPSwapsTokens(t *testing.T) {
+	pki := newTestPKI(t)
+	srv, _, target := startTCPTestServer(t, pki, TCPConfig{Tokens: []TokenGrant{{Token: "old", Scope: ScopeRead}}})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	old := dialTCP(t, target, WithCAFile(pki.caFile), WithToken("old"))
+	if _, err := old.ListRuns(ctx, &daemonpb.ListRunsRequest{}); err != nil {
+		t.Fatalf("old token before reload: %v", err)
+	}
+
+	if err := srv.ReloadTCP(TCPConfig{Addr: "ignored", CertFile: pki.serverCert, KeyFile: pki.serverKey}); err == nil {
+		t.Fatal("reload without any auth should be rejected")
+	}
+	if err := srv.ReloadTCP(TCPConfig{
+		Addr:     "ignored",
+		CertFile: pki.serverCert,
+		KeyFile:  pki.serverKey,
+		Tokens:   []TokenGrant{{Token: "new", Scope: ScopeRead}},
+	}); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	if _, err := old.ListRuns(ctx, &daemonpb.ListRunsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("old token after reload = %v, want Unauthenticated", err)
+	}
+	fresh := dialTCP(t, target, WithCAFile(pki.caFile), WithToken("new"))
+	if _, err := fresh.ListRuns(ctx, &daemonpb.ListRunsRequest{}); err != nil {
+		t.Fatalf("new token after reload: %v", err)
+	}
+}
@@ -38,8 +38,16 @@ type Run struct {
 	UpdatedAt            string                 `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	StartedAt            *string                `protobuf:"bytes,12,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
 	CompletedAt          *string                `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	// Budget fixed at creation from the workflow snapshot (0 = no limit)
+	// and the usage summed over the run's executions.
+	MaxTokens  int64   `protobuf:"varint,14,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	MaxCostUsd float64 `protobuf:"fixed64,15,opt,name=max_cost_usd,json=maxCostUsd,proto3" json:"max_cost_usd,omitempty"`
+	Usage      *Usage  `protobuf:"bytes,16,opt,name=usage,proto3" json:"usage,omitempty"`
+	// Task context captured from bd at run start (JSON agent.TaskSnapshot),
+	// empty when the client sent none.
+	TaskSnapshot  string `protobuf:"bytes,17,opt,name=task_snapshot,json=taskSnapshot,proto3" json:"task_snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Run) Reset() {
@@ -163,19 +171,114 @@ func (x *Run) GetCompletedAt() string {
 	return ""
 }
 
+func (x *Run) GetMaxTokens() int64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *Run) GetMaxCostUsd() float64 {
+	if x != nil {
+		return x.MaxCostUsd
+	}
+	return 0
+}
+
+func (x *Run) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *Run) GetTaskSnapshot() string {
+	if x != nil {
+		return x.TaskSnapshot
+	}
+	return ""
+}
+
+// Usage is the token and cost accounting of an execution or a run.
+type Usage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InputTokens   int64                  `protobuf:"varint,1,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64                  `protobuf:"varint,2,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	CostUsd       float64                `protobuf:"fixed64,3,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_orchestrator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Usage) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCostUsd() float64 {
+	if x != nil {
+		return x.CostUsd
+	}
+	return 0
+}
+
 type CreateRunRequest struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId           string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	TaskId              string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	WorkflowSnapshotRef string                 `protobuf:"bytes,3,opt,name=workflow_snapshot_ref,json=workflowSnapshotRef,proto3" json:"workflow_snapshot_ref,omitempty"`
 	WorkflowSnapshot    string                 `protobuf:"bytes,4,opt,name=workflow_snapshot,json=workflowSnapshot,proto3" json:"workflow_snapshot,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Optional JSON agent.TaskSnapshot of the task and where it sits in the
+	// plan; its id must equal task_id.
+	TaskSnapshot string `protobuf:"bytes,5,opt,name=task_snapshot,json=taskSnapshot,proto3" json:"task_snapshot,omitempty"`
+	// Optional project root (the directory holding .beads). It is recorded
+	// as the project's fs_path, where the daemon runs bd to sync the task.
+	ProjectPath   string `protobuf:"bytes,6,opt,name=project_path,json=projectPath,proto3" json:"project_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateRunRequest) Reset() {
 	*x = CreateRunRequest{}
-	mi := &file_orchestrator_proto_msgTypes[1]
+	mi := &file_orchestrator_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +290,7 @@ func (x *CreateRunRequest) String() string {
 func (*CreateRunRequest) ProtoMessage() {}
 
 func (x *CreateRunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[1]
+	mi := &file_orchestrator_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +303,7 @@ func (x *CreateRunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRunRequest.ProtoReflect.Descriptor instead.
 func (*CreateRunRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{1}
+	return file_orchestrator_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateRunRequest) GetProjectId() string {
@@ -231,6 +334,20 @@ func (x *CreateRunRequest) GetWorkflowSnapshot() string {
 	return ""
 }
 
+func (x *CreateRunRequest) GetTaskSnapshot() string {
+	if x != nil {
+		return x.TaskSnapshot
+	}
+	return ""
+}
+
+func (x *CreateRunRequest) GetProjectPath() string {
+	if x != nil {
+		return x.ProjectPath
+	}
+	return ""
+}
+
 type GetRunRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -240,7 +357,7 @@ type GetRunRequest struct {
 
 func (x *GetRunRequest) Reset() {
 	*x = GetRunRequest{}
-	mi := &file_orchestrator_proto_msgTypes[2]
+	mi := &file_orchestrator_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -252,7 +369,7 @@ func (x *GetRunRequest) String() string {
 func (*GetRunRequest) ProtoMessage() {}
 
 func (x *GetRunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[2]
+	mi := &file_orchestrator_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -265,7 +382,7 @@ func (x *GetRunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRunRequest.ProtoReflect.Descriptor instead.
 func (*GetRunRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{2}
+	return file_orchestrator_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetRunRequest) GetId() string {
@@ -285,7 +402,7 @@ type ListRunsRequest struct {
 
 func (x *ListRunsRequest) Reset() {
 	*x = ListRunsRequest{}
-	mi := &file_orchestrator_proto_msgTypes[3]
+	mi := &file_orchestrator_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +414,7 @@ func (x *ListRunsRequest) String() string {
 func (*ListRunsRequest) ProtoMessage() {}
 
 func (x *ListRunsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[3]
+	mi := &file_orchestrator_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,7 +427,7 @@ func (x *ListRunsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRunsRequest.ProtoReflect.Descriptor instead.
 func (*ListRunsRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{3}
+	return file_orchestrator_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListRunsRequest) GetProjectId() string {
@@ -329,7 +446,7 @@ type ListRunsResponse struct {
 
 func (x *ListRunsResponse) Reset() {
 	*x = ListRunsResponse{}
-	mi := &file_orchestrator_proto_msgTypes[4]
+	mi := &file_orchestrator_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -341,7 +458,7 @@ func (x *ListRunsResponse) String() string {
 func (*ListRunsResponse) ProtoMessage() {}
 
 func (x *ListRunsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[4]
+	mi := &file_orchestrator_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +471,7 @@ func (x *ListRunsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRunsResponse.ProtoReflect.Descriptor instead.
 func (*ListRunsResponse) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{4}
+	return file_orchestrator_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListRunsResponse) GetRuns() []*Run {
@@ -381,7 +498,7 @@ type HumanInput struct {
 
 func (x *HumanInput) Reset() {
 	*x = HumanInput{}
-	mi := &file_orchestrator_proto_msgTypes[5]
+	mi := &file_orchestrator_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -393,7 +510,7 @@ func (x *HumanInput) String() string {
 func (*HumanInput) ProtoMessage() {}
 
 func (x *HumanInput) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[5]
+	mi := &file_orchestrator_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +523,7 @@ func (x *HumanInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HumanInput.ProtoReflect.Descriptor instead.
 func (*HumanInput) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{5}
+	return file_orchestrator_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HumanInput) GetId() string {
@@ -483,7 +600,7 @@ type ListHumanInputsRequest struct {
 
 func (x *ListHumanInputsRequest) Reset() {
 	*x = ListHumanInputsRequest{}
-	mi := &file_orchestrator_proto_msgTypes[6]
+	mi := &file_orchestrator_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -495,7 +612,7 @@ func (x *ListHumanInputsRequest) String() string {
 func (*ListHumanInputsRequest) ProtoMessage() {}
 
 func (x *ListHumanInputsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[6]
+	mi := &file_orchestrator_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,7 +625,7 @@ func (x *ListHumanInputsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListHumanInputsRequest.ProtoReflect.Descriptor instead.
 func (*ListHumanInputsRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{6}
+	return file_orchestrator_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListHumanInputsRequest) GetRunId() string {
@@ -527,7 +644,7 @@ type ListHumanInputsResponse struct {
 
 func (x *ListHumanInputsResponse) Reset() {
 	*x = ListHumanInputsResponse{}
-	mi := &file_orchestrator_proto_msgTypes[7]
+	mi := &file_orchestrator_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -539,7 +656,7 @@ func (x *ListHumanInputsResponse) String() string {
 func (*ListHumanInputsResponse) ProtoMessage() {}
 
 func (x *ListHumanInputsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[7]
+	mi := &file_orchestrator_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +669,7 @@ func (x *ListHumanInputsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListHumanInputsResponse.ProtoReflect.Descriptor instead.
 func (*ListHumanInputsResponse) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{7}
+	return file_orchestrator_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListHumanInputsResponse) GetHumanInputs() []*HumanInput {
@@ -572,7 +689,7 @@ type AnswerHumanInputRequest struct {
 
 func (x *AnswerHumanInputRequest) Reset() {
 	*x = AnswerHumanInputRequest{}
-	mi := &file_orchestrator_proto_msgTypes[8]
+	mi := &file_orchestrator_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -584,7 +701,7 @@ func (x *AnswerHumanInputRequest) String() string {
 func (*AnswerHumanInputRequest) ProtoMessage() {}
 
 func (x *AnswerHumanInputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[8]
+	mi := &file_orchestrator_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,7 +714,7 @@ func (x *AnswerHumanInputRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnswerHumanInputRequest.ProtoReflect.Descriptor instead.
 func (*AnswerHumanInputRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{8}
+	return file_orchestrator_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AnswerHumanInputRequest) GetId() string {
@@ -623,7 +740,7 @@ type RetryRunRequest struct {
 
 func (x *RetryRunRequest) Reset() {
 	*x = RetryRunRequest{}
-	mi := &file_orchestrator_proto_msgTypes[9]
+	mi := &file_orchestrator_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -635,7 +752,7 @@ func (x *RetryRunRequest) String() string {
 func (*RetryRunRequest) ProtoMessage() {}
 
 func (x *RetryRunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[9]
+	mi := &file_orchestrator_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +765,7 @@ func (x *RetryRunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetryRunRequest.ProtoReflect.Descriptor instead.
 func (*RetryRunRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{9}
+	return file_orchestrator_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RetryRunRequest) GetId() string {
@@ -667,7 +784,7 @@ type CancelRunRequest struct {
 
 func (x *CancelRunRequest) Reset() {
 	*x = CancelRunRequest{}
-	mi := &file_orchestrator_proto_msgTypes[10]
+	mi := &file_orchestrator_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +796,7 @@ func (x *CancelRunRequest) String() string {
 func (*CancelRunRequest) ProtoMessage() {}
 
 func (x *CancelRunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[10]
+	mi := &file_orchestrator_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +809,7 @@ func (x *CancelRunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelRunRequest.ProtoReflect.Descriptor instead.
 func (*CancelRunRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{10}
+	return file_orchestrator_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CancelRunRequest) GetId() string {
@@ -720,13 +837,17 @@ type Execution struct {
 	UpdatedAt     string                 `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	StartedAt     *string                `protobuf:"bytes,15,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
 	CompletedAt   *string                `protobuf:"bytes,16,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
+	// Path of the agent's stdout transcript, relative to the daemon state
+	// directory; empty when the runtime keeps none.
+	TranscriptRef string `protobuf:"bytes,17,opt,name=transcript_ref,json=transcriptRef,proto3" json:"transcript_ref,omitempty"`
+	Usage         *Usage `protobuf:"bytes,18,opt,name=usage,proto3" json:"usage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Execution) Reset() {
 	*x = Execution{}
-	mi := &file_orchestrator_proto_msgTypes[11]
+	mi := &file_orchestrator_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -738,7 +859,7 @@ func (x *Execution) String() string {
 func (*Execution) ProtoMessage() {}
 
 func (x *Execution) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[11]
+	mi := &file_orchestrator_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,7 +872,7 @@ func (x *Execution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Execution.ProtoReflect.Descriptor instead.
 func (*Execution) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{11}
+	return file_orchestrator_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Execution) GetId() string {
@@ -866,6 +987,20 @@ func (x *Execution) GetCompletedAt() string {
 	return ""
 }
 
+func (x *Execution) GetTranscriptRef() string {
+	if x != nil {
+		return x.TranscriptRef
+	}
+	return ""
+}
+
+func (x *Execution) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
 type Artifact struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -880,7 +1015,7 @@ type Artifact struct {
 
 func (x *Artifact) Reset() {
 	*x = Artifact{}
-	mi := &file_orchestrator_proto_msgTypes[12]
+	mi := &file_orchestrator_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -892,7 +1027,7 @@ func (x *Artifact) String() string {
 func (*Artifact) ProtoMessage() {}
 
 func (x *Artifact) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[12]
+	mi := &file_orchestrator_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -905,7 +1040,7 @@ func (x *Artifact) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
 func (*Artifact) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{12}
+	return file_orchestrator_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Artifact) GetId() string {
@@ -959,7 +1094,7 @@ type InspectExecutionRequest struct {
 
 func (x *InspectExecutionRequest) Reset() {
 	*x = InspectExecutionRequest{}
-	mi := &file_orchestrator_proto_msgTypes[13]
+	mi := &file_orchestrator_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -971,7 +1106,7 @@ func (x *InspectExecutionRequest) String() string {
 func (*InspectExecutionRequest) ProtoMessage() {}
 
 func (x *InspectExecutionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[13]
+	mi := &file_orchestrator_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,7 +1119,7 @@ func (x *InspectExecutionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InspectExecutionRequest.ProtoReflect.Descriptor instead.
 func (*InspectExecutionRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{13}
+	return file_orchestrator_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *InspectExecutionRequest) GetId() string {
@@ -1004,7 +1139,7 @@ type InspectExecutionResponse struct {
 
 func (x *InspectExecutionResponse) Reset() {
 	*x = InspectExecutionResponse{}
-	mi := &file_orchestrator_proto_msgTypes[14]
+	mi := &file_orchestrator_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1016,7 +1151,7 @@ func (x *InspectExecutionResponse) String() string {
 func (*InspectExecutionResponse) ProtoMessage() {}
 
 func (x *InspectExecutionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[14]
+	mi := &file_orchestrator_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1029,7 +1164,7 @@ func (x *InspectExecutionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InspectExecutionResponse.ProtoReflect.Descriptor instead.
 func (*InspectExecutionResponse) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{14}
+	return file_orchestrator_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *InspectExecutionResponse) GetExecution() *Execution {
@@ -1046,31 +1181,32 @@ func (x *InspectExecutionResponse) GetArtifacts() []*Artifact {
 	return nil
 }
 
-type ListExecutionsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// When set, only executions for this run are returned; otherwise the
-	// store returns no rows (the BIR-54 contract scopes by run so the
-	// operator only sees the executions of their selected run).
-	RunId         *string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3,oneof" json:"run_id,omitempty"`
+// ReadArtifactRequest selects an artifact and an optional byte range.
+// offset/length address the stored file; length 0 means "to the end".
+type ReadArtifactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListExecutionsRequest) Reset() {
-	*x = ListExecutionsRequest{}
-	mi := &file_orchestrator_proto_msgTypes[15]
+func (x *ReadArtifactRequest) Reset() {
+	*x = ReadArtifactRequest{}
+	mi := &file_orchestrator_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListExecutionsRequest) String() string {
+func (x *ReadArtifactRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListExecutionsRequest) ProtoMessage() {}
+func (*ReadArtifactRequest) ProtoMessage() {}
 
-func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[15]
+func (x *ReadArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1081,40 +1217,60 @@ func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListExecutionsRequest.ProtoReflect.Descriptor instead.
-func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ReadArtifactRequest.ProtoReflect.Descriptor instead.
+func (*ReadArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListExecutionsRequest) GetRunId() string {
-	if x != nil && x.RunId != nil {
-		return *x.RunId
+func (x *ReadArtifactRequest) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-type ListExecutionsResponse struct {
+func (x *ReadArtifactRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadArtifactRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+// TailExecutionLogRequest selects an execution's transcript. offset skips
+// bytes already received (e.g. before a reconnect). With follow the stream
+// stays open and sends output as the agent writes it until the execution
+// finishes; without it the stream ends at the current end of file.
+type TailExecutionLogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Executions    []*Execution           `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Follow        bool                   `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListExecutionsResponse) Reset() {
-	*x = ListExecutionsResponse{}
-	mi := &file_orchestrator_proto_msgTypes[16]
+func (x *TailExecutionLogRequest) Reset() {
+	*x = TailExecutionLogRequest{}
+	mi := &file_orchestrator_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListExecutionsResponse) String() string {
+func (x *TailExecutionLogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListExecutionsResponse) ProtoMessage() {}
+func (*TailExecutionLogRequest) ProtoMessage() {}
 
-func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[16]
+func (x *TailExecutionLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,43 +1281,62 @@ func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListExecutionsResponse.ProtoReflect.Descriptor instead.
-func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TailExecutionLogRequest.ProtoReflect.Descriptor instead.
+func (*TailExecutionLogRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListExecutionsResponse) GetExecutions() []*Execution {
+func (x *TailExecutionLogRequest) GetExecutionId() string {
 	if x != nil {
-		return x.Executions
+		return x.ExecutionId
 	}
-	return nil
+	return ""
 }
 
-type StreamEventsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	RunId string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
-	// Only events with seq > after_seq are streamed. Use 0 to replay from the
-	// beginning of the run.
-	AfterSeq      int64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+func (x *TailExecutionLogRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *TailExecutionLogRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+// ExecutionLogChunk is one piece of a TailExecutionLog stream. offset is the
+// absolute position of data within the transcript. The final chunk has eof
+// set (and may carry no data); with follow that means the execution has
+// finished and the whole transcript was sent.
+type ExecutionLogChunk struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Data   []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Eof    bool                   `protobuf:"varint,3,opt,name=eof,proto3" json:"eof,omitempty"`
+	// Status of the execution when the chunk was read.
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamEventsRequest) Reset() {
-	*x = StreamEventsRequest{}
-	mi := &file_orchestrator_proto_msgTypes[17]
+func (x *ExecutionLogChunk) Reset() {
+	*x = ExecutionLogChunk{}
+	mi := &file_orchestrator_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamEventsRequest) String() string {
+func (x *ExecutionLogChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamEventsRequest) ProtoMessage() {}
+func (*ExecutionLogChunk) ProtoMessage() {}
 
-func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[17]
+func (x *ExecutionLogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1172,52 +1347,922 @@ func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
-func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ExecutionLogChunk.ProtoReflect.Descriptor instead.
+func (*ExecutionLogChunk) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *StreamEventsRequest) GetRunId() string {
+func (x *ExecutionLogChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExecutionLogChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ExecutionLogChunk) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+func (x *ExecutionLogChunk) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ArtifactChunk is one piece of a ReadArtifact stream. Chunks arrive in
+// order; offset is the absolute position of data within the artifact. The
+// first chunk also carries the artifact metadata so a client does not need
+// a separate InspectExecution call. On a full read the daemon verifies the
+// stored hash before the first chunk is sent and fails with DATA_LOSS on a
+// mismatch; ranged reads are not hashed.
+type ArtifactChunk struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Data   []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Total size of the artifact file in bytes (not of the requested range).
+	Size int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Hash string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	// True when the file matched the stored hash: hashed by this read (full
+	// reads) or by an earlier one of the same size and mtime. It describes
+	// the file as it was when hashed.
+	Verified bool   `protobuf:"varint,5,opt,name=verified,proto3" json:"verified,omitempty"`
+	Name     string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// Set on the final chunk of the requested range.
+	Eof           bool `protobuf:"varint,7,opt,name=eof,proto3" json:"eof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtifactChunk) Reset() {
+	*x = ArtifactChunk{}
+	mi := &file_orchestrator_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtifactChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactChunk) ProtoMessage() {}
+
+func (x *ArtifactChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactChunk) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ArtifactChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ArtifactChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ArtifactChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ArtifactChunk) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *ArtifactChunk) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArtifactChunk) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+type ListExecutionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When set, only executions for this run are returned; otherwise the
+	// store returns no rows (the BIR-54 contract scopes by run so the
+	// operator only sees the executions of their selected run).
+	RunId         *string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3,oneof" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsRequest) Reset() {
+	*x = ListExecutionsRequest{}
+	mi := &file_orchestrator_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsRequest) ProtoMessage() {}
+
+func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListExecutionsRequest) GetRunId() string {
+	if x != nil && x.RunId != nil {
+		return *x.RunId
+	}
+	return ""
+}
+
+type ListExecutionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executions    []*Execution           `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsResponse) Reset() {
+	*x = ListExecutionsResponse{}
+	mi := &file_orchestrator_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsResponse) ProtoMessage() {}
+
+func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListExecutionsResponse) GetExecutions() []*Execution {
+	if x != nil {
+		return x.Executions
+	}
+	return nil
+}
+
+type StepAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	StepId        string                 `protobuf:"bytes,3,opt,name=step_id,json=stepId,proto3" json:"step_id,omitempty"`
+	Attempt       int32                  `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Inputs        string                 `protobuf:"bytes,6,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	Result        *string                `protobuf:"bytes,7,opt,name=result,proto3,oneof" json:"result,omitempty"`
+	Error         *string                `protobuf:"bytes,8,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StartedAt     *string                `protobuf:"bytes,11,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
+	CompletedAt   *string                `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepAttempt) Reset() {
+	*x = StepAttempt{}
+	mi := &file_orchestrator_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepAttempt) ProtoMessage() {}
+
+func (x *StepAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepAttempt.ProtoReflect.Descriptor instead.
+func (*StepAttempt) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StepAttempt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetStepId() string {
+	if x != nil {
+		return x.StepId
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *StepAttempt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetInputs() string {
+	if x != nil {
+		return x.Inputs
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetResult() string {
+	if x != nil && x.Result != nil {
+		return *x.Result
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetStartedAt() string {
+	if x != nil && x.StartedAt != nil {
+		return *x.StartedAt
+	}
+	return ""
+}
+
+func (x *StepAttempt) GetCompletedAt() string {
+	if x != nil && x.CompletedAt != nil {
+		return *x.CompletedAt
+	}
+	return ""
+}
+
+type ListStepAttemptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStepAttemptsRequest) Reset() {
+	*x = ListStepAttemptsRequest{}
+	mi := &file_orchestrator_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStepAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStepAttemptsRequest) ProtoMessage() {}
+
+func (x *ListStepAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStepAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*ListStepAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListStepAttemptsRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type ListStepAttemptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StepAttempts  []*StepAttempt         `protobuf:"bytes,1,rep,name=step_attempts,json=stepAttempts,proto3" json:"step_attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStepAttemptsResponse) Reset() {
+	*x = ListStepAttemptsResponse{}
+	mi := &file_orchestrator_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStepAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStepAttemptsResponse) ProtoMessage() {}
+
+func (x *ListStepAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStepAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*ListStepAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListStepAttemptsResponse) GetStepAttempts() []*StepAttempt {
+	if x != nil {
+		return x.StepAttempts
+	}
+	return nil
+}
+
+type GetRunTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRunTimelineRequest) Reset() {
+	*x = GetRunTimelineRequest{}
+	mi := &file_orchestrator_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRunTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRunTimelineRequest) ProtoMessage() {}
+
+func (x *GetRunTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRunTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetRunTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRunTimelineRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+// TimelineEntry mirrors orch.TimelineEntry. kind is one of step_attempt,
+// execution, human_input, artifact or event. duration_ms is zero while the
+// entry is still open (ended_at unset).
+type TimelineEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	At            string                 `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	EndedAt       *string                `protobuf:"bytes,4,opt,name=ended_at,json=endedAt,proto3,oneof" json:"ended_at,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	StepAttemptId string                 `protobuf:"bytes,6,opt,name=step_attempt_id,json=stepAttemptId,proto3" json:"step_attempt_id,omitempty"`
+	ExecutionId   string                 `protobuf:"bytes,7,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	StepId        string                 `protobuf:"bytes,8,opt,name=step_id,json=stepId,proto3" json:"step_id,omitempty"`
+	Attempt       int32                  `protobuf:"varint,9,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	Summary       string                 `protobuf:"bytes,11,opt,name=summary,proto3" json:"summary,omitempty"`
+	Detail        string                 `protobuf:"bytes,12,opt,name=detail,proto3" json:"detail,omitempty"`
+	Error         *string                `protobuf:"bytes,13,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Seq           int64                  `protobuf:"varint,14,opt,name=seq,proto3" json:"seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineEntry) Reset() {
+	*x = TimelineEntry{}
+	mi := &file_orchestrator_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEntry) ProtoMessage() {}
+
+func (x *TimelineEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEntry.ProtoReflect.Descriptor instead.
+func (*TimelineEntry) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TimelineEntry) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetEndedAt() string {
+	if x != nil && x.EndedAt != nil {
+		return *x.EndedAt
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetStepAttemptId() string {
+	if x != nil {
+		return x.StepAttemptId
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetStepId() string {
+	if x != nil {
+		return x.StepId
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// RunTimeline is the joined, time-ordered history of a single run.
+type RunTimeline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Run           *Run                   `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	Entries       []*TimelineEntry       `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunTimeline) Reset() {
+	*x = RunTimeline{}
+	mi := &file_orchestrator_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunTimeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTimeline) ProtoMessage() {}
+
+func (x *RunTimeline) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTimeline.ProtoReflect.Descriptor instead.
+func (*RunTimeline) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RunTimeline) GetRun() *Run {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *RunTimeline) GetEntries() []*TimelineEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	RunId string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Only events with seq > after_seq are streamed. Use 0 to replay from the
+	// beginning of the run.
+	AfterSeq      int64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_orchestrator_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StreamEventsRequest) GetRunId() string {
 	if x != nil {
 		return x.RunId
 	}
 	return ""
 }
 
-func (x *StreamEventsRequest) GetAfterSeq() int64 {
+func (x *StreamEventsRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RunId         *string                `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3,oneof" json:"run_id,omitempty"`
+	Seq           int64                  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_orchestrator_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetRunId() string {
+	if x != nil && x.RunId != nil {
+		return *x.RunId
+	}
+	return ""
+}
+
+func (x *Event) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Event) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetDaemonInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDaemonInfoRequest) Reset() {
+	*x = GetDaemonInfoRequest{}
+	mi := &file_orchestrator_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDaemonInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDaemonInfoRequest) ProtoMessage() {}
+
+func (x *GetDaemonInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[30]
 	if x != nil {
-		return x.AfterSeq
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type Event struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	RunId         *string                `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3,oneof" json:"run_id,omitempty"`
-	Seq           int64                  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
-	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+// Deprecated: Use GetDaemonInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetDaemonInfoRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{30}
+}
+
+// DaemonInfo describes the running daemon. Clients compare api_version with
+// their own to detect a stale daemon left over from an older install.
+type DaemonInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Build version of bdtuid ("dev" for untagged builds).
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Wire contract version; bumped when an older daemon can no longer serve
+	// a newer client.
+	ApiVersion int32 `protobuf:"varint,2,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// Highest migration version recorded in schema_migrations.
+	SchemaVersion int64  `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	StartedAt     string `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	UptimeSeconds int64  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	DbPath        string `protobuf:"bytes,6,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`
+	// Runs that are running or waiting on a human. Parked needs_attention
+	// runs are reported separately in attention_runs.
+	ActiveRuns int64 `protobuf:"varint,7,opt,name=active_runs,json=activeRuns,proto3" json:"active_runs,omitempty"`
+	// Runs queued and not yet picked up.
+	QueueDepth int64 `protobuf:"varint,8,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	Pid        int32 `protobuf:"varint,9,opt,name=pid,proto3" json:"pid,omitempty"`
+	// True once the daemon stopped accepting new runs ahead of a shutdown.
+	Draining bool `protobuf:"varint,10,opt,name=draining,proto3" json:"draining,omitempty"`
+	// Runs parked in needs_attention until an operator acts on them.
+	AttentionRuns int64 `protobuf:"varint,11,opt,name=attention_runs,json=attentionRuns,proto3" json:"attention_runs,omitempty"`
+	// While draining for a shutdown: when the daemon stops waiting for
+	// executions and exits (RFC 3339). Empty otherwise, including during a
+	// restart handoff, which does not wait.
+	DrainDeadline string `protobuf:"bytes,12,opt,name=drain_deadline,json=drainDeadline,proto3" json:"drain_deadline,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
-	mi := &file_orchestrator_proto_msgTypes[18]
+func (x *DaemonInfo) Reset() {
+	*x = DaemonInfo{}
+	mi := &file_orchestrator_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *DaemonInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*DaemonInfo) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_orchestrator_proto_msgTypes[18]
+func (x *DaemonInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1228,58 +2273,337 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
-	return file_orchestrator_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use DaemonInfo.ProtoReflect.Descriptor instead.
+func (*DaemonInfo) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *Event) GetId() int64 {
+func (x *DaemonInfo) GetVersion() string {
 	if x != nil {
-		return x.Id
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DaemonInfo) GetApiVersion() int32 {
+	if x != nil {
+		return x.ApiVersion
 	}
 	return 0
 }
 
-func (x *Event) GetRunId() string {
-	if x != nil && x.RunId != nil {
-		return *x.RunId
+func (x *DaemonInfo) GetSchemaVersion() int64 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
 	}
 	return ""
 }
 
-func (x *Event) GetSeq() int64 {
+func (x *DaemonInfo) GetUptimeSeconds() int64 {
 	if x != nil {
-		return x.Seq
+		return x.UptimeSeconds
 	}
 	return 0
 }
 
-func (x *Event) GetType() string {
+func (x *DaemonInfo) GetDbPath() string {
 	if x != nil {
-		return x.Type
+		return x.DbPath
 	}
 	return ""
 }
 
-func (x *Event) GetPayload() string {
+func (x *DaemonInfo) GetActiveRuns() int64 {
 	if x != nil {
-		return x.Payload
+		return x.ActiveRuns
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetQueueDepth() int64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *DaemonInfo) GetAttentionRuns() int64 {
+	if x != nil {
+		return x.AttentionRuns
+	}
+	return 0
+}
+
+func (x *DaemonInfo) GetDrainDeadline() string {
+	if x != nil {
+		return x.DrainDeadline
 	}
 	return ""
 }
 
-func (x *Event) GetCreatedAt() string {
+// Session mirrors orch.Session: the agent conversation one role reuses
+// across the attempts of a run.
+type Session struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RunId     string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	RoleId    string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	SessionId string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Set by ForkSession until the role's next invocation branches off.
+	ForkPending   bool    `protobuf:"varint,4,opt,name=fork_pending,json=forkPending,proto3" json:"fork_pending,omitempty"`
+	ForkedFrom    *string `protobuf:"bytes,5,opt,name=forked_from,json=forkedFrom,proto3,oneof" json:"forked_from,omitempty"`
+	CreatedAt     string  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string  `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_orchestrator_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Session) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *Session) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *Session) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Session) GetForkPending() bool {
+	if x != nil {
+		return x.ForkPending
+	}
+	return false
+}
+
+func (x *Session) GetForkedFrom() string {
+	if x != nil && x.ForkedFrom != nil {
+		return *x.ForkedFrom
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() string {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return ""
 }
 
+func (x *Session) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_orchestrator_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSessionsRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_orchestrator_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// SessionRequest names one role's session. ClearSession and ForkSession
+// answer with the run's sessions after the change.
+type SessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	RoleId        string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_orchestrator_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SessionRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
 var File_orchestrator_proto protoreflect.FileDescriptor
 
 const file_orchestrator_proto_rawDesc = "" +
 	"\n" +
-	"\x12orchestrator.proto\x12\x0fbdtui.daemon.v1\"\xac\x04\n" +
+	"\x12orchestrator.proto\x12\x0fbdtui.daemon.v1\"\xc0\x05\n" +
 	"\x03Run\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1298,18 +2622,30 @@ const file_orchestrator_proto_rawDesc = "" +
 	"updated_at\x18\v \x01(\tR\tupdatedAt\x12\"\n" +
 	"\n" +
 	"started_at\x18\f \x01(\tH\x03R\tstartedAt\x88\x01\x01\x12&\n" +
-	"\fcompleted_at\x18\r \x01(\tH\x04R\vcompletedAt\x88\x01\x01B\x12\n" +
+	"\fcompleted_at\x18\r \x01(\tH\x04R\vcompletedAt\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x0e \x01(\x03R\tmaxTokens\x12 \n" +
+	"\fmax_cost_usd\x18\x0f \x01(\x01R\n" +
+	"maxCostUsd\x12,\n" +
+	"\x05usage\x18\x10 \x01(\v2\x16.bdtui.daemon.v1.UsageR\x05usage\x12#\n" +
+	"\rtask_snapshot\x18\x11 \x01(\tR\ftaskSnapshotB\x12\n" +
 	"\x10_current_step_idB\x19\n" +
 	"\x17_needs_attention_reasonB\b\n" +
 	"\x06_errorB\r\n" +
 	"\v_started_atB\x0f\n" +
-	"\r_completed_at\"\xab\x01\n" +
+	"\r_completed_at\"j\n" +
+	"\x05Usage\x12!\n" +
+	"\finput_tokens\x18\x01 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x02 \x01(\x03R\foutputTokens\x12\x19\n" +
+	"\bcost_usd\x18\x03 \x01(\x01R\acostUsd\"\xf3\x01\n" +
 	"\x10CreateRunRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\tR\x06taskId\x122\n" +
 	"\x15workflow_snapshot_ref\x18\x03 \x01(\tR\x13workflowSnapshotRef\x12+\n" +
-	"\x11workflow_snapshot\x18\x04 \x01(\tR\x10workflowSnapshot\"\x1f\n" +
+	"\x11workflow_snapshot\x18\x04 \x01(\tR\x10workflowSnapshot\x12#\n" +
+	"\rtask_snapshot\x18\x05 \x01(\tR\ftaskSnapshot\x12!\n" +
+	"\fproject_path\x18\x06 \x01(\tR\vprojectPath\"\x1f\n" +
 	"\rGetRunRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
 	"\x0fListRunsRequest\x12\"\n" +
@@ -1345,7 +2681,7 @@ const file_orchestrator_proto_rawDesc = "" +
 	"\x0fRetryRunRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
 	"\x10CancelRunRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xe4\x04\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb9\x05\n" +
 	"\tExecution\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
 	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12&\n" +
@@ -1370,7 +2706,9 @@ const file_orchestrator_proto_rawDesc = "" +
 	"updated_at\x18\x0e \x01(\tR\tupdatedAt\x12\"\n" +
 	"\n" +
 	"started_at\x18\x0f \x01(\tH\x05R\tstartedAt\x88\x01\x01\x12&\n" +
-	"\fcompleted_at\x18\x10 \x01(\tH\x06R\vcompletedAt\x88\x01\x01B\n" +
+	"\fcompleted_at\x18\x10 \x01(\tH\x06R\vcompletedAt\x88\x01\x01\x12%\n" +
+	"\x0etranscript_ref\x18\x11 \x01(\tR\rtranscriptRef\x12,\n" +
+	"\x05usage\x18\x12 \x01(\v2\x16.bdtui.daemon.v1.UsageR\x05usageB\n" +
 	"\n" +
 	"\b_pane_idB\r\n" +
 	"\v_process_idB\x0e\n" +
@@ -1391,14 +2729,84 @@ const file_orchestrator_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x8d\x01\n" +
 	"\x18InspectExecutionResponse\x128\n" +
 	"\texecution\x18\x01 \x01(\v2\x1a.bdtui.daemon.v1.ExecutionR\texecution\x127\n" +
-	"\tartifacts\x18\x02 \x03(\v2\x19.bdtui.daemon.v1.ArtifactR\tartifacts\">\n" +
+	"\tartifacts\x18\x02 \x03(\v2\x19.bdtui.daemon.v1.ArtifactR\tartifacts\"U\n" +
+	"\x13ReadArtifactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"l\n" +
+	"\x17TailExecutionLogRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06follow\x18\x03 \x01(\bR\x06follow\"i\n" +
+	"\x11ExecutionLogChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x10\n" +
+	"\x03eof\x18\x03 \x01(\bR\x03eof\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\xa5\x01\n" +
+	"\rArtifactChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04hash\x18\x04 \x01(\tR\x04hash\x12\x1a\n" +
+	"\bverified\x18\x05 \x01(\bR\bverified\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x10\n" +
+	"\x03eof\x18\a \x01(\bR\x03eof\">\n" +
 	"\x15ListExecutionsRequest\x12\x1a\n" +
 	"\x06run_id\x18\x01 \x01(\tH\x00R\x05runId\x88\x01\x01B\t\n" +
 	"\a_run_id\"T\n" +
 	"\x16ListExecutionsResponse\x12:\n" +
 	"\n" +
 	"executions\x18\x01 \x03(\v2\x1a.bdtui.daemon.v1.ExecutionR\n" +
-	"executions\"I\n" +
+	"executions\"\x8e\x03\n" +
+	"\vStepAttempt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12\x17\n" +
+	"\astep_id\x18\x03 \x01(\tR\x06stepId\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\x05R\aattempt\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06inputs\x18\x06 \x01(\tR\x06inputs\x12\x1b\n" +
+	"\x06result\x18\a \x01(\tH\x00R\x06result\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\b \x01(\tH\x01R\x05error\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\x12\"\n" +
+	"\n" +
+	"started_at\x18\v \x01(\tH\x02R\tstartedAt\x88\x01\x01\x12&\n" +
+	"\fcompleted_at\x18\f \x01(\tH\x03R\vcompletedAt\x88\x01\x01B\t\n" +
+	"\a_resultB\b\n" +
+	"\x06_errorB\r\n" +
+	"\v_started_atB\x0f\n" +
+	"\r_completed_at\"0\n" +
+	"\x17ListStepAttemptsRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\"]\n" +
+	"\x18ListStepAttemptsResponse\x12A\n" +
+	"\rstep_attempts\x18\x01 \x03(\v2\x1c.bdtui.daemon.v1.StepAttemptR\fstepAttempts\".\n" +
+	"\x15GetRunTimelineRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\"\x90\x03\n" +
+	"\rTimelineEntry\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x0e\n" +
+	"\x02at\x18\x03 \x01(\tR\x02at\x12\x1e\n" +
+	"\bended_at\x18\x04 \x01(\tH\x00R\aendedAt\x88\x01\x01\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12&\n" +
+	"\x0fstep_attempt_id\x18\x06 \x01(\tR\rstepAttemptId\x12!\n" +
+	"\fexecution_id\x18\a \x01(\tR\vexecutionId\x12\x17\n" +
+	"\astep_id\x18\b \x01(\tR\x06stepId\x12\x18\n" +
+	"\aattempt\x18\t \x01(\x05R\aattempt\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x18\n" +
+	"\asummary\x18\v \x01(\tR\asummary\x12\x16\n" +
+	"\x06detail\x18\f \x01(\tR\x06detail\x12\x19\n" +
+	"\x05error\x18\r \x01(\tH\x01R\x05error\x88\x01\x01\x12\x10\n" +
+	"\x03seq\x18\x0e \x01(\x03R\x03seqB\v\n" +
+	"\t_ended_atB\b\n" +
+	"\x06_error\"o\n" +
+	"\vRunTimeline\x12&\n" +
+	"\x03run\x18\x01 \x01(\v2\x14.bdtui.daemon.v1.RunR\x03run\x128\n" +
+	"\aentries\x18\x02 \x03(\v2\x1e.bdtui.daemon.v1.TimelineEntryR\aentries\"I\n" +
 	"\x13StreamEventsRequest\x12\x15\n" +
 	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x1b\n" +
 	"\tafter_seq\x18\x02 \x01(\x03R\bafterSeq\"\x9d\x01\n" +
@@ -1410,7 +2818,47 @@ const file_orchestrator_proto_rawDesc = "" +
 	"\apayload\x18\x05 \x01(\tR\apayload\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAtB\t\n" +
-	"\a_run_id2\xcc\x06\n" +
+	"\a_run_id\"\x16\n" +
+	"\x14GetDaemonInfoRequest\"\x8b\x03\n" +
+	"\n" +
+	"DaemonInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1f\n" +
+	"\vapi_version\x18\x02 \x01(\x05R\n" +
+	"apiVersion\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x03R\rschemaVersion\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\tR\tstartedAt\x12%\n" +
+	"\x0euptime_seconds\x18\x05 \x01(\x03R\ruptimeSeconds\x12\x17\n" +
+	"\adb_path\x18\x06 \x01(\tR\x06dbPath\x12\x1f\n" +
+	"\vactive_runs\x18\a \x01(\x03R\n" +
+	"activeRuns\x12\x1f\n" +
+	"\vqueue_depth\x18\b \x01(\x03R\n" +
+	"queueDepth\x12\x10\n" +
+	"\x03pid\x18\t \x01(\x05R\x03pid\x12\x1a\n" +
+	"\bdraining\x18\n" +
+	" \x01(\bR\bdraining\x12%\n" +
+	"\x0eattention_runs\x18\v \x01(\x03R\rattentionRuns\x12%\n" +
+	"\x0edrain_deadline\x18\f \x01(\tR\rdrainDeadline\"\xef\x01\n" +
+	"\aSession\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12!\n" +
+	"\ffork_pending\x18\x04 \x01(\bR\vforkPending\x12$\n" +
+	"\vforked_from\x18\x05 \x01(\tH\x00R\n" +
+	"forkedFrom\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAtB\x0e\n" +
+	"\f_forked_from\",\n" +
+	"\x13ListSessionsRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\"L\n" +
+	"\x14ListSessionsResponse\x124\n" +
+	"\bsessions\x18\x01 \x03(\v2\x18.bdtui.daemon.v1.SessionR\bsessions\"@\n" +
+	"\x0eSessionRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x17\n" +
+	"\arole_id\x18\x02 \x01(\tR\x06roleId2\xaa\f\n" +
 	"\fOrchestrator\x12D\n" +
 	"\tCreateRun\x12!.bdtui.daemon.v1.CreateRunRequest\x1a\x14.bdtui.daemon.v1.Run\x12O\n" +
 	"\bListRuns\x12 .bdtui.daemon.v1.ListRunsRequest\x1a!.bdtui.daemon.v1.ListRunsResponse\x12>\n" +
@@ -1419,9 +2867,17 @@ const file_orchestrator_proto_rawDesc = "" +
 	"\x10AnswerHumanInput\x12(.bdtui.daemon.v1.AnswerHumanInputRequest\x1a\x1b.bdtui.daemon.v1.HumanInput\x12B\n" +
 	"\bRetryRun\x12 .bdtui.daemon.v1.RetryRunRequest\x1a\x14.bdtui.daemon.v1.Run\x12D\n" +
 	"\tCancelRun\x12!.bdtui.daemon.v1.CancelRunRequest\x1a\x14.bdtui.daemon.v1.Run\x12g\n" +
-	"\x10InspectExecution\x12(.bdtui.daemon.v1.InspectExecutionRequest\x1a).bdtui.daemon.v1.InspectExecutionResponse\x12a\n" +
-	"\x0eListExecutions\x12&.bdtui.daemon.v1.ListExecutionsRequest\x1a'.bdtui.daemon.v1.ListExecutionsResponse\x12N\n" +
-	"\fStreamEvents\x12$.bdtui.daemon.v1.StreamEventsRequest\x1a\x16.bdtui.daemon.v1.Event0\x01B)Z'bdtui/internal/daemon/daemonpb;daemonpbb\x06proto3"
+	"\x10InspectExecution\x12(.bdtui.daemon.v1.InspectExecutionRequest\x1a).bdtui.daemon.v1.InspectExecutionResponse\x12V\n" +
+	"\fReadArtifact\x12$.bdtui.daemon.v1.ReadArtifactRequest\x1a\x1e.bdtui.daemon.v1.ArtifactChunk0\x01\x12b\n" +
+	"\x10TailExecutionLog\x12(.bdtui.daemon.v1.TailExecutionLogRequest\x1a\".bdtui.daemon.v1.ExecutionLogChunk0\x01\x12a\n" +
+	"\x0eListExecutions\x12&.bdtui.daemon.v1.ListExecutionsRequest\x1a'.bdtui.daemon.v1.ListExecutionsResponse\x12g\n" +
+	"\x10ListStepAttempts\x12(.bdtui.daemon.v1.ListStepAttemptsRequest\x1a).bdtui.daemon.v1.ListStepAttemptsResponse\x12V\n" +
+	"\x0eGetRunTimeline\x12&.bdtui.daemon.v1.GetRunTimelineRequest\x1a\x1c.bdtui.daemon.v1.RunTimeline\x12N\n" +
+	"\fStreamEvents\x12$.bdtui.daemon.v1.StreamEventsRequest\x1a\x16.bdtui.daemon.v1.Event0\x01\x12S\n" +
+	"\rGetDaemonInfo\x12%.bdtui.daemon.v1.GetDaemonInfoRequest\x1a\x1b.bdtui.daemon.v1.DaemonInfo\x12[\n" +
+	"\fListSessions\x12$.bdtui.daemon.v1.ListSessionsRequest\x1a%.bdtui.daemon.v1.ListSessionsResponse\x12V\n" +
+	"\fClearSession\x12\x1f.bdtui.daemon.v1.SessionRequest\x1a%.bdtui.daemon.v1.ListSessionsResponse\x12U\n" +
+	"\vForkSession\x12\x1f.bdtui.daemon.v1.SessionRequest\x1a%.bdtui.daemon.v1.ListSessionsResponseB)Z'bdtui/internal/daemon/daemonpb;daemonpbb\x06proto3"
 
 var (
 	file_orchestrator_proto_rawDescOnce sync.Once
@@ -1435,59 +2891,98 @@ func file_orchestrator_proto_rawDescGZIP() []byte {
 	return file_orchestrator_proto_rawDescData
 }
 
-var file_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_orchestrator_proto_goTypes = []any{
 	(*Run)(nil),                      // 0: bdtui.daemon.v1.Run
-	(*CreateRunRequest)(nil),         // 1: bdtui.daemon.v1.CreateRunRequest
-	(*GetRunRequest)(nil),            // 2: bdtui.daemon.v1.GetRunRequest
-	(*ListRunsRequest)(nil),          // 3: bdtui.daemon.v1.ListRunsRequest
-	(*ListRunsResponse)(nil),         // 4: bdtui.daemon.v1.ListRunsResponse
-	(*HumanInput)(nil),               // 5: bdtui.daemon.v1.HumanInput
-	(*ListHumanInputsRequest)(nil),   // 6: bdtui.daemon.v1.ListHumanInputsRequest
-	(*ListHumanInputsResponse)(nil),  // 7: bdtui.daemon.v1.ListHumanInputsResponse
-	(*AnswerHumanInputRequest)(nil),  // 8: bdtui.daemon.v1.AnswerHumanInputRequest
-	(*RetryRunRequest)(nil),          // 9: bdtui.daemon.v1.RetryRunRequest
-	(*CancelRunRequest)(nil),         // 10: bdtui.daemon.v1.CancelRunRequest
-	(*Execution)(nil),                // 11: bdtui.daemon.v1.Execution
-	(*Artifact)(nil),                 // 12: bdtui.daemon.v1.Artifact
-	(*InspectExecutionRequest)(nil),  // 13: bdtui.daemon.v1.InspectExecutionRequest
-	(*InspectExecutionResponse)(nil), // 14: bdtui.daemon.v1.InspectExecutionResponse
-	(*ListExecutionsRequest)(nil),    // 15: bdtui.daemon.v1.ListExecutionsRequest
-	(*ListExecutionsResponse)(nil),   // 16: bdtui.daemon.v1.ListExecutionsResponse
-	(*StreamEventsRequest)(nil),      // 17: bdtui.daemon.v1.StreamEventsRequest
-	(*Event)(nil),                    // 18: bdtui.daemon.v1.Event
+	(*Usage)(nil),                    // 1: bdtui.daemon.v1.Usage
+	(*CreateRunRequest)(nil),         // 2: bdtui.daemon.v1.CreateRunRequest
+	(*GetRunRequest)(nil),            // 3: bdtui.daemon.v1.GetRunRequest
+	(*ListRunsRequest)(nil),          // 4: bdtui.daemon.v1.ListRunsRequest
+	(*ListRunsResponse)(nil),         // 5: bdtui.daemon.v1.ListRunsResponse
+	(*HumanInput)(nil),               // 6: bdtui.daemon.v1.HumanInput
+	(*ListHumanInputsRequest)(nil),   // 7: bdtui.daemon.v1.ListHumanInputsRequest
+	(*ListHumanInputsResponse)(nil),  // 8: bdtui.daemon.v1.ListHumanInputsResponse
+	(*AnswerHumanInputRequest)(nil),  // 9: bdtui.daemon.v1.AnswerHumanInputRequest
+	(*RetryRunRequest)(nil),          // 10: bdtui.daemon.v1.RetryRunRequest
+	(*CancelRunRequest)(nil),         // 11: bdtui.daemon.v1.CancelRunRequest
+	(*Execution)(nil),                // 12: bdtui.daemon.v1.Execution
+	(*Artifact)(nil),                 // 13: bdtui.daemon.v1.Artifact
+	(*InspectExecutionRequest)(nil),  // 14: bdtui.daemon.v1.InspectExecutionRequest
+	(*InspectExecutionResponse)(nil), // 15: bdtui.daemon.v1.InspectExecutionResponse
+	(*ReadArtifactRequest)(nil),      // 16: bdtui.daemon.v1.ReadArtifactRequest
+	(*TailExecutionLogRequest)(nil),  // 17: bdtui.daemon.v1.TailExecutionLogRequest
+	(*ExecutionLogChunk)(nil),        // 18: bdtui.daemon.v1.ExecutionLogChunk
+	(*ArtifactChunk)(nil),            // 19: bdtui.daemon.v1.ArtifactChunk
+	(*ListExecutionsRequest)(nil),    // 20: bdtui.daemon.v1.ListExecutionsRequest
+	(*ListExecutionsResponse)(nil),   // 21: bdtui.daemon.v1.ListExecutionsResponse
+	(*StepAttempt)(nil),              // 22: bdtui.daemon.v1.StepAttempt
+	(*ListStepAttemptsRequest)(nil),  // 23: bdtui.daemon.v1.ListStepAttemptsRequest
+	(*ListStepAttemptsResponse)(nil), // 24: bdtui.daemon.v1.ListStepAttemptsResponse
+	(*GetRunTimelineRequest)(nil),    // 25: bdtui.daemon.v1.GetRunTimelineRequest
+	(*TimelineEntry)(nil),            // 26: bdtui.daemon.v1.TimelineEntry
+	(*RunTimeline)(nil),              // 27: bdtui.daemon.v1.RunTimeline
+	(*StreamEventsRequest)(nil),      // 28: bdtui.daemon.v1.StreamEventsRequest
+	(*Event)(nil),                    // 29: bdtui.daemon.v1.Event
+	(*GetDaemonInfoRequest)(nil),     // 30: bdtui.daemon.v1.GetDaemonInfoRequest
+	(*DaemonInfo)(nil),               // 31: bdtui.daemon.v1.DaemonInfo
+	(*Session)(nil),                  // 32: bdtui.daemon.v1.Session
+	(*ListSessionsRequest)(nil),      // 33: bdtui.daemon.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),     // 34: bdtui.daemon.v1.ListSessionsResponse
+	(*SessionRequest)(nil),           // 35: bdtui.daemon.v1.SessionRequest
 }
 var file_orchestrator_proto_depIdxs = []int32{
-	0,  // 0: bdtui.daemon.v1.ListRunsResponse.runs:type_name -> bdtui.daemon.v1.Run
-	5,  // 1: bdtui.daemon.v1.ListHumanInputsResponse.human_inputs:type_name -> bdtui.daemon.v1.HumanInput
-	11, // 2: bdtui.daemon.v1.InspectExecutionResponse.execution:type_name -> bdtui.daemon.v1.Execution
-	12, // 3: bdtui.daemon.v1.InspectExecutionResponse.artifacts:type_name -> bdtui.daemon.v1.Artifact
-	11, // 4: bdtui.daemon.v1.ListExecutionsResponse.executions:type_name -> bdtui.daemon.v1.Execution
-	1,  // 5: bdtui.daemon.v1.Orchestrator.CreateRun:input_type -> bdtui.daemon.v1.CreateRunRequest
-	3,  // 6: bdtui.daemon.v1.Orchestrator.ListRuns:input_type -> bdtui.daemon.v1.ListRunsRequest
-	2,  // 7: bdtui.daemon.v1.Orchestrator.GetRun:input_type -> bdtui.daemon.v1.GetRunRequest
-	6,  // 8: bdtui.daemon.v1.Orchestrator.ListHumanInputs:input_type -> bdtui.daemon.v1.ListHumanInputsRequest
-	8,  // 9: bdtui.daemon.v1.Orchestrator.AnswerHumanInput:input_type -> bdtui.daemon.v1.AnswerHumanInputRequest
-	9,  // 10: bdtui.daemon.v1.Orchestrator.RetryRun:input_type -> bdtui.daemon.v1.RetryRunRequest
-	10, // 11: bdtui.daemon.v1.Orchestrator.CancelRun:input_type -> bdtui.daemon.v1.CancelRunRequest
-	13, // 12: bdtui.daemon.v1.Orchestrator.InspectExecution:input_type -> bdtui.daemon.v1.InspectExecutionRequest
-	15, // 13: bdtui.daemon.v1.Orchestrator.ListExecutions:input_type -> bdtui.daemon.v1.ListExecutionsRequest
-	17, // 14: bdtui.daemon.v1.Orchestrator.StreamEvents:input_type -> bdtui.daemon.v1.StreamEventsRequest
-	0,  // 15: bdtui.daemon.v1.Orchestrator.CreateRun:output_type -> bdtui.daemon.v1.Run
-	4,  // 16: bdtui.daemon.v1.Orchestrator.ListRuns:output_type -> bdtui.daemon.v1.ListRunsResponse
-	0,  // 17: bdtui.daemon.v1.Orchestrator.GetRun:output_type -> bdtui.daemon.v1.Run
-	7,  // 18: bdtui.daemon.v1.Orchestrator.ListHumanInputs:output_type -> bdtui.daemon.v1.ListHumanInputsResponse
-	5,  // 19: bdtui.daemon.v1.Orchestrator.AnswerHumanInput:output_type -> bdtui.daemon.v1.HumanInput
-	0,  // 20: bdtui.daemon.v1.Orchestrator.RetryRun:output_type -> bdtui.daemon.v1.Run
-	0,  // 21: bdtui.daemon.v1.Orchestrator.CancelRun:output_type -> bdtui.daemon.v1.Run
-	14, // 22: bdtui.daemon.v1.Orchestrator.InspectExecution:output_type -> bdtui.daemon.v1.InspectExecutionResponse
-	16, // 23: bdtui.daemon.v1.Orchestrator.ListExecutions:output_type -> bdtui.daemon.v1.ListExecutionsResponse
-	18, // 24: bdtui.daemon.v1.Orchestrator.StreamEvents:output_type -> bdtui.daemon.v1.Event
-	15, // [15:25] is the sub-list for method output_type
-	5,  // [5:15] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	1,  // 0: bdtui.daemon.v1.Run.usage:type_name -> bdtui.daemon.v1.Usage
+	0,  // 1: bdtui.daemon.v1.ListRunsResponse.runs:type_name -> bdtui.daemon.v1.Run
+	6,  // 2: bdtui.daemon.v1.ListHumanInputsResponse.human_inputs:type_name -> bdtui.daemon.v1.HumanInput
+	1,  // 3: bdtui.daemon.v1.Execution.usage:type_name -> bdtui.daemon.v1.Usage
+	12, // 4: bdtui.daemon.v1.InspectExecutionResponse.execution:type_name -> bdtui.daemon.v1.Execution
+	13, // 5: bdtui.daemon.v1.InspectExecutionResponse.artifacts:type_name -> bdtui.daemon.v1.Artifact
+	12, // 6: bdtui.daemon.v1.ListExecutionsResponse.executions:type_name -> bdtui.daemon.v1.Execution
+	22, // 7: bdtui.daemon.v1.ListStepAttemptsResponse.step_attempts:type_name -> bdtui.daemon.v1.StepAttempt
+	0,  // 8: bdtui.daemon.v1.RunTimeline.run:type_name -> bdtui.daemon.v1.Run
+	26, // 9: bdtui.daemon.v1.RunTimeline.entries:type_name -> bdtui.daemon.v1.TimelineEntry
+	32, // 10: bdtui.daemon.v1.ListSessionsResponse.sessions:type_name -> bdtui.daemon.v1.Session
+	2,  // 11: bdtui.daemon.v1.Orchestrator.CreateRun:input_type -> bdtui.daemon.v1.CreateRunRequest
+	4,  // 12: bdtui.daemon.v1.Orchestrator.ListRuns:input_type -> bdtui.daemon.v1.ListRunsRequest
+	3,  // 13: bdtui.daemon.v1.Orchestrator.GetRun:input_type -> bdtui.daemon.v1.GetRunRequest
+	7,  // 14: bdtui.daemon.v1.Orchestrator.ListHumanInputs:input_type -> bdtui.daemon.v1.ListHumanInputsRequest
+	9,  // 15: bdtui.daemon.v1.Orchestrator.AnswerHumanInput:input_type -> bdtui.daemon.v1.AnswerHumanInputRequest
+	10, // 16: bdtui.daemon.v1.Orchestrator.RetryRun:input_type -> bdtui.daemon.v1.RetryRunRequest
+	11, // 17: bdtui.daemon.v1.Orchestrator.CancelRun:input_type -> bdtui.daemon.v1.CancelRunRequest
+	14, // 18: bdtui.daemon.v1.Orchestrator.InspectExecution:input_type -> bdtui.daemon.v1.InspectExecutionRequest
+	16, // 19: bdtui.daemon.v1.Orchestrator.ReadArtifact:input_type -> bdtui.daemon.v1.ReadArtifactRequest
+	17, // 20: bdtui.daemon.v1.Orchestrator.TailExecutionLog:input_type -> bdtui.daemon.v1.TailExecutionLogRequest
+	20, // 21: bdtui.daemon.v1.Orchestrator.ListExecutions:input_type -> bdtui.daemon.v1.ListExecutionsRequest
+	23, // 22: bdtui.daemon.v1.Orchestrator.ListStepAttempts:input_type -> bdtui.daemon.v1.ListStepAttemptsRequest
+	25, // 23: bdtui.daemon.v1.Orchestrator.GetRunTimeline:input_type -> bdtui.daemon.v1.GetRunTimelineRequest
+	28, // 24: bdtui.daemon.v1.Orchestrator.StreamEvents:input_type -> bdtui.daemon.v1.StreamEventsRequest
+	30, // 25: bdtui.daemon.v1.Orchestrator.GetDaemonInfo:input_type -> bdtui.daemon.v1.GetDaemonInfoRequest
+	33, // 26: bdtui.daemon.v1.Orchestrator.ListSessions:input_type -> bdtui.daemon.v1.ListSessionsRequest
+	35, // 27: bdtui.daemon.v1.Orchestrator.ClearSession:input_type -> bdtui.daemon.v1.SessionRequest
+	35, // 28: bdtui.daemon.v1.Orchestrator.ForkSession:input_type -> bdtui.daemon.v1.SessionRequest
+	0,  // 29: bdtui.daemon.v1.Orchestrator.CreateRun:output_type -> bdtui.daemon.v1.Run
+	5,  // 30: bdtui.daemon.v1.Orchestrator.ListRuns:output_type -> bdtui.daemon.v1.ListRunsResponse
+	0,  // 31: bdtui.daemon.v1.Orchestrator.GetRun:output_type -> bdtui.daemon.v1.Run
+	8,  // 32: bdtui.daemon.v1.Orchestrator.ListHumanInputs:output_type -> bdtui.daemon.v1.ListHumanInputsResponse
+	6,  // 33: bdtui.daemon.v1.Orchestrator.AnswerHumanInput:output_type -> bdtui.daemon.v1.HumanInput
+	0,  // 34: bdtui.daemon.v1.Orchestrator.RetryRun:output_type -> bdtui.daemon.v1.Run
+	0,  // 35: bdtui.daemon.v1.Orchestrator.CancelRun:output_type -> bdtui.daemon.v1.Run
+	15, // 36: bdtui.daemon.v1.Orchestrator.InspectExecution:output_type -> bdtui.daemon.v1.InspectExecutionResponse
+	19, // 37: bdtui.daemon.v1.Orchestrator.ReadArtifact:output_type -> bdtui.daemon.v1.ArtifactChunk
+	18, // 38: bdtui.daemon.v1.Orchestrator.TailExecutionLog:output_type -> bdtui.daemon.v1.ExecutionLogChunk
+	21, // 39: bdtui.daemon.v1.Orchestrator.ListExecutions:output_type -> bdtui.daemon.v1.ListExecutionsResponse
+	24, // 40: bdtui.daemon.v1.Orchestrator.ListStepAttempts:output_type -> bdtui.daemon.v1.ListStepAttemptsResponse
+	27, // 41: bdtui.daemon.v1.Orchestrator.GetRunTimeline:output_type -> bdtui.daemon.v1.RunTimeline
+	29, // 42: bdtui.daemon.v1.Orchestrator.StreamEvents:output_type -> bdtui.daemon.v1.Event
+	31, // 43: bdtui.daemon.v1.Orchestrator.GetDaemonInfo:output_type -> bdtui.daemon.v1.DaemonInfo
+	34, // 44: bdtui.daemon.v1.Orchestrator.ListSessions:output_type -> bdtui.daemon.v1.ListSessionsResponse
+	34, // 45: bdtui.daemon.v1.Orchestrator.ClearSession:output_type -> bdtui.daemon.v1.ListSessionsResponse
+	34, // 46: bdtui.daemon.v1.Orchestrator.ForkSession:output_type -> bdtui.daemon.v1.ListSessionsResponse
+	29, // [29:47] is the sub-list for method output_type
+	11, // [11:29] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_orchestrator_proto_init() }
@@ -1496,19 +2991,22 @@ func file_orchestrator_proto_init() {
 		return
 	}
 	file_orchestrator_proto_msgTypes[0].OneofWrappers = []any{}
-	file_orchestrator_proto_msgTypes[3].OneofWrappers = []any{}
-	file_orchestrator_proto_msgTypes[5].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[4].OneofWrappers = []any{}
 	file_orchestrator_proto_msgTypes[6].OneofWrappers = []any{}
-	file_orchestrator_proto_msgTypes[11].OneofWrappers = []any{}
-	file_orchestrator_proto_msgTypes[15].OneofWrappers = []any{}
-	file_orchestrator_proto_msgTypes[18].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[7].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[12].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[20].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[22].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[26].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[29].OneofWrappers = []any{}
+	file_orchestrator_proto_msgTypes[32].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orchestrator_proto_rawDesc), len(file_orchestrator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
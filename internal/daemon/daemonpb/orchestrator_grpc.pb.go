@@ -27,8 +27,16 @@ const (
 	Orchestrator_RetryRun_FullMethodName         = "/bdtui.daemon.v1.Orchestrator/RetryRun"
 	Orchestrator_CancelRun_FullMethodName        = "/bdtui.daemon.v1.Orchestrator/CancelRun"
 	Orchestrator_InspectExecution_FullMethodName = "/bdtui.daemon.v1.Orchestrator/InspectExecution"
+	Orchestrator_ReadArtifact_FullMethodName     = "/bdtui.daemon.v1.Orchestrator/ReadArtifact"
+	Orchestrator_TailExecutionLog_FullMethodName = "/bdtui.daemon.v1.Orchestrator/TailExecutionLog"
 	Orchestrator_ListExecutions_FullMethodName   = "/bdtui.daemon.v1.Orchestrator/ListExecutions"
+	Orchestrator_ListStepAttempts_FullMethodName = "/bdtui.daemon.v1.Orchestrator/ListStepAttempts"
+	Orchestrator_GetRunTimeline_FullMethodName   = "/bdtui.daemon.v1.Orchestrator/GetRunTimeline"
 	Orchestrator_StreamEvents_FullMethodName     = "/bdtui.daemon.v1.Orchestrator/StreamEvents"
+	Orchestrator_GetDaemonInfo_FullMethodName    = "/bdtui.daemon.v1.Orchestrator/GetDaemonInfo"
+	Orchestrator_ListSessions_FullMethodName     = "/bdtui.daemon.v1.Orchestrator/ListSessions"
+	Orchestrator_ClearSession_FullMethodName     = "/bdtui.daemon.v1.Orchestrator/ClearSession"
+	Orchestrator_ForkSession_FullMethodName      = "/bdtui.daemon.v1.Orchestrator/ForkSession"
 )
 
 // OrchestratorClient is the client API for Orchestrator service.
@@ -50,8 +58,16 @@ type OrchestratorClient interface {
 	RetryRun(ctx context.Context, in *RetryRunRequest, opts ...grpc.CallOption) (*Run, error)
 	CancelRun(ctx context.Context, in *CancelRunRequest, opts ...grpc.CallOption) (*Run, error)
 	InspectExecution(ctx context.Context, in *InspectExecutionRequest, opts ...grpc.CallOption) (*InspectExecutionResponse, error)
+	ReadArtifact(ctx context.Context, in *ReadArtifactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactChunk], error)
+	TailExecutionLog(ctx context.Context, in *TailExecutionLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutionLogChunk], error)
 	ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error)
+	ListStepAttempts(ctx context.Context, in *ListStepAttemptsRequest, opts ...grpc.CallOption) (*ListStepAttemptsResponse, error)
+	GetRunTimeline(ctx context.Context, in *GetRunTimelineRequest, opts ...grpc.CallOption) (*RunTimeline, error)
 	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	GetDaemonInfo(ctx context.Context, in *GetDaemonInfoRequest, opts ...grpc.CallOption) (*DaemonInfo, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	ClearSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	ForkSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
 }
 
 type orchestratorClient struct {
@@ -142,6 +158,44 @@ func (c *orchestratorClient) InspectExecution(ctx context.Context, in *InspectEx
 	return out, nil
 }
 
+func (c *orchestratorClient) ReadArtifact(ctx context.Context, in *ReadArtifactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Orchestrator_ServiceDesc.Streams[0], Orchestrator_ReadArtifact_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReadArtifactRequest, ArtifactChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Orchestrator_ReadArtifactClient = grpc.ServerStreamingClient[ArtifactChunk]
+
+func (c *orchestratorClient) TailExecutionLog(ctx context.Context, in *TailExecutionLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutionLogChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Orchestrator_ServiceDesc.Streams[1], Orchestrator_TailExecutionLog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailExecutionLogRequest, ExecutionLogChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Orchestrator_TailExecutionLogClient = grpc.ServerStreamingClient[ExecutionLogChunk]
+
 func (c *orchestratorClient) ListExecutions(ctx context.Context, in *ListExecutionsRequest, opts ...grpc.CallOption) (*ListExecutionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListExecutionsResponse)
@@ -152,9 +206,29 @@ func (c *orchestratorClient) ListExecutions(ctx context.Context, in *ListExecuti
 	return out, nil
 }
 
+func (c *orchestratorClient) ListStepAttempts(ctx context.Context, in *ListStepAttemptsRequest, opts ...grpc.CallOption) (*ListStepAttemptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStepAttemptsResponse)
+	err := c.cc.Invoke(ctx, Orchestrator_ListStepAttempts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) GetRunTimeline(ctx context.Context, in *GetRunTimelineRequest, opts ...grpc.CallOption) (*RunTimeline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunTimeline)
+	err := c.cc.Invoke(ctx, Orchestrator_GetRunTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orchestratorClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Orchestrator_ServiceDesc.Streams[0], Orchestrator_StreamEvents_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Orchestrator_ServiceDesc.Streams[2], Orchestrator_StreamEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +245,46 @@ func (c *orchestratorClient) StreamEvents(ctx context.Context, in *StreamEventsR
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Orchestrator_StreamEventsClient = grpc.ServerStreamingClient[Event]
 
+func (c *orchestratorClient) GetDaemonInfo(ctx context.Context, in *GetDaemonInfoRequest, opts ...grpc.CallOption) (*DaemonInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonInfo)
+	err := c.cc.Invoke(ctx, Orchestrator_GetDaemonInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, Orchestrator_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) ClearSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, Orchestrator_ClearSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) ForkSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, Orchestrator_ForkSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServer is the server API for Orchestrator service.
 // All implementations must embed UnimplementedOrchestratorServer
 // for forward compatibility.
@@ -190,8 +304,16 @@ type OrchestratorServer interface {
 	RetryRun(context.Context, *RetryRunRequest) (*Run, error)
 	CancelRun(context.Context, *CancelRunRequest) (*Run, error)
 	InspectExecution(context.Context, *InspectExecutionRequest) (*InspectExecutionResponse, error)
+	ReadArtifact(*ReadArtifactRequest, grpc.ServerStreamingServer[ArtifactChunk]) error
+	TailExecutionLog(*TailExecutionLogRequest, grpc.ServerStreamingServer[ExecutionLogChunk]) error
 	ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error)
+	ListStepAttempts(context.Context, *ListStepAttemptsRequest) (*ListStepAttemptsResponse, error)
+	GetRunTimeline(context.Context, *GetRunTimelineRequest) (*RunTimeline, error)
 	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	GetDaemonInfo(context.Context, *GetDaemonInfoRequest) (*DaemonInfo, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	ClearSession(context.Context, *SessionRequest) (*ListSessionsResponse, error)
+	ForkSession(context.Context, *SessionRequest) (*ListSessionsResponse, error)
 	mustEmbedUnimplementedOrchestratorServer()
 }
 
@@ -226,12 +348,36 @@ func (UnimplementedOrchestratorServer) CancelRun(context.Context, *CancelRunRequ
 func (UnimplementedOrchestratorServer) InspectExecution(context.Context, *InspectExecutionRequest) (*InspectExecutionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method InspectExecution not implemented")
 }
+func (UnimplementedOrchestratorServer) ReadArtifact(*ReadArtifactRequest, grpc.ServerStreamingServer[ArtifactChunk]) error {
+	return status.Error(codes.Unimplemented, "method ReadArtifact not implemented")
+}
+func (UnimplementedOrchestratorServer) TailExecutionLog(*TailExecutionLogRequest, grpc.ServerStreamingServer[ExecutionLogChunk]) error {
+	return status.Error(codes.Unimplemented, "method TailExecutionLog not implemented")
+}
 func (UnimplementedOrchestratorServer) ListExecutions(context.Context, *ListExecutionsRequest) (*ListExecutionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListExecutions not implemented")
 }
+func (UnimplementedOrchestratorServer) ListStepAttempts(context.Context, *ListStepAttemptsRequest) (*ListStepAttemptsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStepAttempts not implemented")
+}
+func (UnimplementedOrchestratorServer) GetRunTimeline(context.Context, *GetRunTimelineRequest) (*RunTimeline, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRunTimeline not implemented")
+}
 func (UnimplementedOrchestratorServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
 	return status.Error(codes.Unimplemented, "method StreamEvents not implemented")
 }
+func (UnimplementedOrchestratorServer) GetDaemonInfo(context.Context, *GetDaemonInfoRequest) (*DaemonInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDaemonInfo not implemented")
+}
+func (UnimplementedOrchestratorServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedOrchestratorServer) ClearSession(context.Context, *SessionRequest) (*ListSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearSession not implemented")
+}
+func (UnimplementedOrchestratorServer) ForkSession(context.Context, *SessionRequest) (*ListSessionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForkSession not implemented")
+}
 func (UnimplementedOrchestratorServer) mustEmbedUnimplementedOrchestratorServer() {}
 func (UnimplementedOrchestratorServer) testEmbeddedByValue()                      {}
 
@@ -397,6 +543,28 @@ func _Orchestrator_InspectExecution_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Orchestrator_ReadArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadArtifactRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrchestratorServer).ReadArtifact(m, &grpc.GenericServerStream[ReadArtifactRequest, ArtifactChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Orchestrator_ReadArtifactServer = grpc.ServerStreamingServer[ArtifactChunk]
+
+func _Orchestrator_TailExecutionLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailExecutionLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrchestratorServer).TailExecutionLog(m, &grpc.GenericServerStream[TailExecutionLogRequest, ExecutionLogChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Orchestrator_TailExecutionLogServer = grpc.ServerStreamingServer[ExecutionLogChunk]
+
 func _Orchestrator_ListExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListExecutionsRequest)
 	if err := dec(in); err != nil {
@@ -415,6 +583,42 @@ func _Orchestrator_ListExecutions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Orchestrator_ListStepAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStepAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ListStepAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_ListStepAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ListStepAttempts(ctx, req.(*ListStepAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_GetRunTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).GetRunTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_GetRunTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).GetRunTimeline(ctx, req.(*GetRunTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Orchestrator_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamEventsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -426,6 +630,78 @@ func _Orchestrator_StreamEvents_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Orchestrator_StreamEventsServer = grpc.ServerStreamingServer[Event]
 
+func _Orchestrator_GetDaemonInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDaemonInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).GetDaemonInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_GetDaemonInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).GetDaemonInfo(ctx, req.(*GetDaemonInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_ClearSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ClearSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_ClearSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ClearSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_ForkSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ForkSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Orchestrator_ForkSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ForkSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Orchestrator_ServiceDesc is the grpc.ServiceDesc for Orchestrator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -469,8 +745,42 @@ var Orchestrator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListExecutions",
 			Handler:    _Orchestrator_ListExecutions_Handler,
 		},
+		{
+			MethodName: "ListStepAttempts",
+			Handler:    _Orchestrator_ListStepAttempts_Handler,
+		},
+		{
+			MethodName: "GetRunTimeline",
+			Handler:    _Orchestrator_GetRunTimeline_Handler,
+		},
+		{
+			MethodName: "GetDaemonInfo",
+			Handler:    _Orchestrator_GetDaemonInfo_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Orchestrator_ListSessions_Handler,
+		},
+		{
+			MethodName: "ClearSession",
+			Handler:    _Orchestrator_ClearSession_Handler,
+		},
+		{
+			MethodName: "ForkSession",
+			Handler:    _Orchestrator_ForkSession_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadArtifact",
+			Handler:       _Orchestrator_ReadArtifact_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TailExecutionLog",
+			Handler:       _Orchestrator_TailExecutionLog_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "StreamEvents",
 			Handler:       _Orchestrator_StreamEvents_Handler,
@@ -0,0 +1,113 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"bdtui/internal/orch"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// drainPollInterval is how often Drain re-checks for active executions.
+const drainPollInterval = 250 * time.Millisecond
+
+// errDraining is returned to CreateRun/RetryRun while the daemon drains.
+// Unavailable tells clients to retry; after a restart handoff the next
+// daemon on the same socket accepts the request.
+var errDraining = status.Error(codes.Unavailable, "daemon is draining; not accepting new runs")
+
+// Draining reports whether the daemon has stopped accepting new runs.
+func (s *Service) Draining() bool {
+	return s.draining.Load()
+}
+
+// drainDeadline returns when the current drain gives up waiting, or the
+// zero time when no drain deadline is set.
+func (s *Service) drainDeadline() time.Time {
+	if ns := s.drainUntil.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// Drain stops the daemon from accepting new runs, reports NOT_SERVING to
+// health checks (so EnsureDaemon does not hand out a daemon that refuses
+// CreateRun) and waits up to timeout for the queued and running executions
+// this process drives to finish. Read RPCs keep working throughout, and
+// GetDaemonInfo reports the deadline so a restarting client knows how long
+// to wait.
+//
+// An execution counts as driven by this process when it was last updated
+// after the daemon started and has not been detached yet. Older rows (left
+// behind by a crash or by a previous daemon) are not waited on. Executions are never killed: agent
+// processes outlive the daemon, so each one still active gets an
+// execution.detached event, once, and is left for the next daemon to
+// reattach. It returns how many executions were detached.
+func (s *Server) Drain(ctx context.Context, timeout time.Duration) (int, error) {
+	deadline := time.Now().Add(timeout)
+	s.service.drainUntil.Store(deadline.UnixNano())
+	s.service.draining.Store(true)
+	s.health.SetServingStatus(orchestratorServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	for {
+		active, err := s.store.ListActiveExecutions(ctx)
+		if err != nil {
+			return 0, err
+		}
+		owned, err := s.ownedExecutions(ctx, active)
+		if err != nil {
+			return 0, err
+		}
+		if owned == 0 || !time.Now().Before(deadline) {
+			return s.detach(ctx, active)
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(min(drainPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// ownedExecutions counts the active executions this process still drives:
+// updated since the daemon started and not already handed off by an
+// earlier drain.
+func (s *Server) ownedExecutions(ctx context.Context, active []orch.Execution) (int, error) {
+	n := 0
+	for i := range active {
+		if active[i].UpdatedAt.Before(s.service.startedAt) {
+			continue
+		}
+		done, err := s.store.ExecutionDetached(ctx, &active[i])
+		if err != nil {
+			return 0, err
+		}
+		if !done {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// detach records an execution.detached event for each execution that does
+// not already have one since its last update.
+func (s *Server) detach(ctx context.Context, active []orch.Execution) (int, error) {
+	n := 0
+	for i := range active {
+		done, err := s.store.ExecutionDetached(ctx, &active[i])
+		if err != nil {
+			return n, err
+		}
+		if done {
+			continue
+		}
+		if err := s.store.MarkExecutionDetached(ctx, &active[i], "daemon shutdown"); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,214 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// handoffEnv tells a freshly exec'd bdtuid which inherited file
+// descriptors carry the listeners and the singleton lock, e.g.
+// "ready:3,lock:4,unix:5,tcp:6".
+const handoffEnv = "BDTUID_HANDOFF_FDS"
+
+// Handoff is the state a restarting daemon passes to its successor: the
+// already-bound listeners (so clients never see a refused connection), the
+// flock'd lock file (so the singleton guarantee holds across the swap) and
+// a pipe the successor writes to once it is ready to serve.
+type Handoff struct {
+	Lock  *os.File
+	ready *os.File
+	unix  net.Listener
+	tcp   net.Listener
+}
+
+// InheritedHandoff returns the handoff passed by a parent daemon during
+// `bdtuid restart`, or nil when this process was started normally.
+func InheritedHandoff() (*Handoff, error) {
+	spec := os.Getenv(handoffEnv)
+	if spec == "" {
+		return nil, nil
+	}
+	_ = os.Unsetenv(handoffEnv)
+
+	fds := map[string]uintptr{}
+	for _, part := range strings.Split(spec, ",") {
+		name, num, ok := strings.Cut(part, ":")
+		fd, err := strconv.Atoi(num)
+		if !ok || err != nil || fd < 3 {
+			return nil, fmt.Errorf("%s: malformed entry %q", handoffEnv, part)
+		}
+		fds[name] = uintptr(fd)
+	}
+	for _, name := range []string{"ready", "lock", "unix"} {
+		if _, ok := fds[name]; !ok {
+			return nil, fmt.Errorf("%s: missing %s descriptor", handoffEnv, name)
+		}
+	}
+
+	h := &Handoff{
+		ready: os.NewFile(fds["ready"], "handoff-ready"),
+		Lock:  os.NewFile(fds["lock"], "handoff-lock"),
+	}
+	var err error
+	if h.unix, err = fileListener(fds["unix"], "handoff-unix"); err != nil {
+		return nil, err
+	}
+	if fd, ok := fds["tcp"]; ok {
+		if h.tcp, err = fileListener(fd, "handoff-tcp"); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+func fileListener(fd uintptr, name string) (net.Listener, error) {
+	f := os.NewFile(fd, name)
+	defer f.Close()
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("inherit %s: %w", name, err)
+	}
+	return ln, nil
+}
+
+// Ready tells the parent daemon this process is about to serve, letting
+// the parent stop accepting and exit.
+func (h *Handoff) Ready() error {
+	defer h.ready.Close()
+	_, err := h.ready.Write([]byte{1})
+	return err
+}
+
+// Inherit makes the server serve on the listeners from a handoff instead
+// of binding new ones. Call it before ListenTCP and Serve.
+func (s *Server) Inherit(h *Handoff) {
+	s.listener = h.unix
+	s.inheritedTCP = h.tcp
+}
+
+// Handoff re-executes the daemon binary with the current arguments and
+// passes it the bound listeners and lock. It returns once the successor
+// reports ready; the caller should then shut this server down, which
+// finishes in-flight RPCs without unlinking the shared socket. On error the
+// successor is killed and this server keeps serving.
+//
+// On success this server is marked draining, so a CreateRun that still
+// lands here during shutdown gets Unavailable and is retried against the
+// successor. Health stays SERVING because both processes answer on the same
+// socket. Long-lived StreamEvents calls are cut when the graceful stop times
+// out; clients reconnect and resume with after_seq set to the last seq they
+// saw. Unlike Drain, a handoff does not wait for executions: they keep
+// running and the successor observes them through the shared store.
+//
+// The binary is looked up again from os.Args[0], so an upgraded bdtuid at
+// the same path takes over.
+func (s *Server) Handoff(lock *os.File, timeout time.Duration) (int, error) {
+	ul, ok := s.listener.(*net.UnixListener)
+	if !ok {
+		return 0, errors.New("handoff: server is not serving a unix socket")
+	}
+	unixFile, err := ul.File()
+	if err != nil {
+		return 0, fmt.Errorf("handoff: %w", err)
+	}
+	defer unixFile.Close()
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return 0, fmt.Errorf("handoff: %w", err)
+	}
+	defer readyR.Close()
+
+	files := []*os.File{readyW, lock, unixFile}
+	spec := "ready:3,lock:4,unix:5"
+	if s.tcpListener != nil {
+		tl, ok := s.tcpListener.(*net.TCPListener)
+		if !ok {
+			readyW.Close()
+			return 0, errors.New("handoff: unexpected tcp listener type")
+		}
+		tcpFile, err := tl.File()
+		if err != nil {
+			readyW.Close()
+			return 0, fmt.Errorf("handoff: %w", err)
+		}
+		defer tcpFile.Close()
+		files = append(files, tcpFile)
+		spec += ",tcp:6"
+	}
+
+	exe, err := exec.LookPath(os.Args[0])
+	if err != nil {
+		readyW.Close()
+		return 0, fmt.Errorf("handoff: locate daemon binary: %w", err)
+	}
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), handoffEnv+"="+spec)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		readyW.Close()
+		return 0, fmt.Errorf("handoff: start %s: %w", exe, err)
+	}
+	// Only the child may hold the write end, so a child that dies early
+	// shows up as EOF instead of a hang.
+	readyW.Close()
+	// Passing the listeners via ExtraFiles put the shared sockets into
+	// blocking mode, which would leave our accept loop stuck in accept(2)
+	// where closing the listener cannot interrupt it and GracefulStop hangs.
+	restoreNonblock(ul)
+	if s.tcpListener != nil {
+		restoreNonblock(s.tcpListener.(*net.TCPListener))
+	}
+
+	readyCh := make(chan error, 1)
+	go func() {
+		var b [1]byte
+		_, err := io.ReadFull(readyR, b[:])
+		readyCh <- err
+	}()
+	select {
+	case err = <-readyCh:
+	case <-time.After(timeout):
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return 0, fmt.Errorf("handoff: successor did not become ready: %w", err)
+	}
+
+	ul.SetUnlinkOnClose(false)
+	s.handedOff.Store(true)
+	s.service.draining.Store(true)
+	pid := cmd.Process.Pid
+	_ = cmd.Process.Release()
+	return pid, nil
+}
+
+// restoreNonblock puts a listener's socket back into non-blocking mode
+// without going through os.File.Fd, which is what switched it off.
+func restoreNonblock(l syscall.Conn) {
+	rc, err := l.SyscallConn()
+	if err != nil {
+		return
+	}
+	_ = rc.Control(func(fd uintptr) {
+		_ = syscall.SetNonblock(int(fd), true)
+	})
+}
+
+// HandedOff reports whether a successor took over this server's listeners.
+func (s *Server) HandedOff() bool {
+	return s.handedOff.Load()
+}
@@ -12,6 +12,11 @@ func LockPath(socketPath string) string {
 	return socketPath + ".lock"
 }
 
+// PIDPath returns the pidfile path for a daemon bound to socketPath.
+func PIDPath(socketPath string) string {
+	return socketPath + ".pid"
+}
+
 // AcquireLock takes an exclusive, non-blocking flock on path, creating the
 // file if needed. The returned file must stay open for the daemon's lifetime;
 // closing it (or process exit) releases the lock. A second live daemon fails
@@ -28,6 +33,16 @@ func AcquireLock(path string) (*os.File, error) {
 	return f, nil
 }
 
+// lockFree reports whether no daemon holds the lock at path.
+func lockFree(path string) bool {
+	f, err := AcquireLock(path)
+	if err != nil {
+		return false
+	}
+	_ = ReleaseLock(f)
+	return true
+}
+
 // ReleaseLock releases the flock and closes the lock file.
 func ReleaseLock(f *os.File) error {
 	if f == nil {
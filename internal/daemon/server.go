@@ -2,16 +2,20 @@ package daemon
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"bdtui/internal/daemon/daemonpb"
 	"bdtui/internal/orch"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // gracefulStopTimeout bounds GracefulStop so an open server stream (e.g.
@@ -21,9 +25,23 @@ const gracefulStopTimeout = 5 * time.Second
 // Server owns the gRPC listener and service lifecycle for the daemon.
 type Server struct {
 	grpcServer *grpc.Server
+	health     *health.Server
+	service    *Service
 	store      *orch.Store
 	socketPath string
 	listener   net.Listener
+	// tcpServer/tcpListener are set by ListenTCP for the optional remote
+	// listener; nil when the daemon is Unix-socket only.
+	tcpServer   *grpc.Server
+	tcpListener net.Listener
+	tcpAuth     *authenticator
+	tcpCert     atomic.Pointer[tls.Certificate]
+	// inheritedTCP is the TCP listener passed in by a restart handoff;
+	// ListenTCP uses it instead of binding.
+	inheritedTCP net.Listener
+	// handedOff is set once a successor process owns the listeners, so
+	// shutdown must neither unlink the socket nor wait on new work.
+	handedOff atomic.Bool
 }
 
 // NewServer builds a server that serves the Orchestrator API over the given
@@ -31,10 +49,14 @@ type Server struct {
 func NewServer(store *orch.Store, socketPath string) *Server {
 	s := &Server{
 		grpcServer: grpc.NewServer(),
+		health:     health.NewServer(),
+		service:    NewService(store),
 		store:      store,
 		socketPath: socketPath,
 	}
-	daemonpb.RegisterOrchestratorServer(s.grpcServer, NewService(store))
+	daemonpb.RegisterOrchestratorServer(s.grpcServer, s.service)
+	grpc_health_v1.RegisterHealthServer(s.grpcServer, s.health)
+	s.health.SetServingStatus(orchestratorServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
 	return s
 }
 
@@ -46,7 +68,44 @@ func (s *Server) SocketPath() string {
 // Serve binds the Unix socket and serves until ctx is cancelled. A pre-existing
 // socket file is removed only after proving it is stale (no live listener);
 // a live socket returns an error instead of being clobbered.
+//
+// After Inherit the listener from a restart handoff is used as-is and the
+// socket file is left alone.
 func (s *Server) Serve(ctx context.Context) error {
+	if s.listener == nil {
+		if err := s.bindSocket(); err != nil {
+			return err
+		}
+	}
+	ln := s.listener
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- s.grpcServer.Serve(ln)
+	}()
+	if s.service.beads != nil {
+		go s.service.runBeadsSync(ctx)
+	}
+	if s.tcpServer != nil {
+		go func() {
+			errCh <- s.tcpServer.Serve(s.tcpListener)
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		s.shutdownGracefully()
+		if !s.handedOff.Load() {
+			_ = os.Remove(s.socketPath)
+		}
+		return nil
+	case err := <-errCh:
+		s.Stop()
+		return err
+	}
+}
+
+func (s *Server) bindSocket() error {
 	if err := os.MkdirAll(filepath.Dir(s.socketPath), 0o700); err != nil {
 		return err
 	}
@@ -56,40 +115,38 @@ func (s *Server) Serve(ctx context.Context) error {
 	if err := os.Remove(s.socketPath); err != nil && !os.IsNotExist(err) {
 		return err
 	}
-
 	ln, err := net.Listen("unix", s.socketPath)
 	if err != nil {
 		return err
 	}
 	s.listener = ln
-
-	errCh := make(chan error, 1)
-	go func() {
-		errCh <- s.grpcServer.Serve(ln)
-	}()
-
-	select {
-	case <-ctx.Done():
-		s.shutdownGracefully()
-		_ = os.Remove(s.socketPath)
-		return nil
-	case err := <-errCh:
-		return err
-	}
+	return nil
 }
 
 // shutdownGracefully stops the server, but only waits for in-flight RPCs up to
 // gracefulStopTimeout. Open streaming handlers that never return are forcibly
 // terminated after the timeout.
 func (s *Server) shutdownGracefully() {
+	// Flip health to NOT_SERVING first so clients polling Check stop
+	// routing new work here while in-flight RPCs drain. After a handoff
+	// the successor already answers on the shared socket, so stay SERVING.
+	if !s.handedOff.Load() {
+		s.health.Shutdown()
+	}
 	done := make(chan struct{})
 	go func() {
+		if s.tcpServer != nil {
+			s.tcpServer.GracefulStop()
+		}
 		s.grpcServer.GracefulStop()
 		close(done)
 	}()
 	select {
 	case <-done:
 	case <-time.After(gracefulStopTimeout):
+		if s.tcpServer != nil {
+			s.tcpServer.Stop()
+		}
 		s.grpcServer.Stop()
 	}
 }
@@ -97,10 +154,15 @@ func (s *Server) shutdownGracefully() {
 // Stop immediately terminates the gRPC server and removes the socket file.
 // It is primarily for tests and error paths; Serve handles graceful shutdown.
 func (s *Server) Stop() {
+	if s.tcpServer != nil {
+		s.tcpServer.Stop()
+	}
 	if s.grpcServer != nil {
 		s.grpcServer.Stop()
 	}
-	_ = os.Remove(s.socketPath)
+	if !s.handedOff.Load() {
+		_ = os.Remove(s.socketPath)
+	}
 }
 
 func socketInUse(path string) bool {
@@ -3,10 +3,14 @@ package daemon
 import (
 	"context"
 	"errors"
+	"path/filepath"
+	"sync/atomic"
 	"time"
 
+	"bdtui/internal/agent"
 	"bdtui/internal/daemon/daemonpb"
 	"bdtui/internal/orch"
+	"bdtui/internal/workflow"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,29 +24,72 @@ const eventPollInterval = 100 * time.Millisecond
 type Service struct {
 	daemonpb.UnimplementedOrchestratorServer
 	store *orch.Store
+	// artifactRoot is the directory ReadArtifact resolves artifact paths
+	// against: the state directory that holds the database.
+	artifactRoot string
+	// artifactHashes caches successful artifact hash checks.
+	artifactHashes artifactHashCache
+	// startedAt is reported as uptime by GetDaemonInfo.
+	startedAt time.Time
+	// draining rejects new runs ahead of a shutdown; see Server.Drain.
+	draining atomic.Bool
+	// drainUntil is the drain deadline in Unix nanoseconds (0 when unset).
+	drainUntil atomic.Int64
+	// stopper stops the processes of cancelled executions; nil when the
+	// daemon drives none. See SetExecutionStopper.
+	stopper ExecutionStopper
+	// beads syncs run lifecycles back to bd issues; nil disables the sync.
+	// See SetBeadsSync.
+	beads *beadsSync
 }
 
 func NewService(store *orch.Store) *Service {
-	return &Service{store: store}
+	s := &Service{store: store, startedAt: time.Now()}
+	if p := store.Path(); p != "" {
+		s.artifactRoot = filepath.Dir(p)
+	}
+	return s
 }
 
 func (s *Service) CreateRun(ctx context.Context, req *daemonpb.CreateRunRequest) (*daemonpb.Run, error) {
+	if s.Draining() {
+		return nil, errDraining
+	}
 	if req.TaskId == "" {
 		return nil, status.Error(codes.InvalidArgument, "task_id is required")
 	}
 	if req.ProjectId == "" {
 		return nil, status.Error(codes.InvalidArgument, "project_id is required")
 	}
-	if err := s.resolveOrCreateProject(ctx, req.ProjectId); err != nil {
+	if req.ProjectPath != "" && !filepath.IsAbs(req.ProjectPath) {
+		return nil, status.Error(codes.InvalidArgument, "project_path must be absolute")
+	}
+	if err := s.resolveOrCreateProject(ctx, req.ProjectId, req.ProjectPath); err != nil {
 		return nil, toStatus(err)
 	}
 
+	budget, err := snapshotBudget(req.WorkflowSnapshot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.TaskSnapshot != "" {
+		task, err := agent.ParseTaskSnapshot(req.TaskSnapshot)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if task.ID != req.TaskId {
+			return nil, status.Errorf(codes.InvalidArgument, "task_snapshot id %q does not match task_id %q", task.ID, req.TaskId)
+		}
+	}
+
 	r := &orch.Run{
 		ProjectID:           req.ProjectId,
 		TaskID:              req.TaskId,
 		Status:              orch.RunQueued,
 		WorkflowSnapshotRef: req.WorkflowSnapshotRef,
 		WorkflowSnapshot:    req.WorkflowSnapshot,
+		TaskSnapshot:        req.TaskSnapshot,
+		Budget:              budget,
 	}
 	if err := s.store.CreateRun(ctx, r); err != nil {
 		return nil, toStatus(err)
@@ -50,15 +97,34 @@ func (s *Service) CreateRun(ctx context.Context, req *daemonpb.CreateRunRequest)
 	return runToProto(r), nil
 }
 
+// snapshotBudget reads the run budget out of the workflow snapshot, so it
+// is as immutable as the workflow it came with. An empty snapshot has none.
+func snapshotBudget(snapshot string) (orch.Budget, error) {
+	if snapshot == "" {
+		return orch.Budget{}, nil
+	}
+	b, err := workflow.SnapshotBudget(snapshot)
+	if err != nil {
+		return orch.Budget{}, err
+	}
+	return orch.Budget{MaxTokens: b.MaxTokens, MaxCostUSD: b.MaxCostUSD}, nil
+}
+
 // resolveOrCreateProject treats project_id as the canonical project handle.
 // Idempotent: on a fresh id the row is created; on a repeat call the existing
-// row is returned without modification.
-func (s *Service) resolveOrCreateProject(ctx context.Context, id string) error {
-	_, err := s.store.EnsureProject(ctx, &orch.Project{
-		ID:   id,
-		Name: id,
+// row is kept, except that a new non-empty path replaces its fs_path (the
+// workspace moved).
+func (s *Service) resolveOrCreateProject(ctx context.Context, id, path string) error {
+	p, err := s.store.EnsureProject(ctx, &orch.Project{
+		ID:     id,
+		Name:   id,
+		FsPath: path,
 	})
-	return err
+	if err != nil || path == "" || p.FsPath == path {
+		return err
+	}
+	p.FsPath = path
+	return s.store.UpdateProject(ctx, p)
 }
 
 func (s *Service) ListRuns(ctx context.Context, req *daemonpb.ListRunsRequest) (*daemonpb.ListRunsResponse, error) {
@@ -137,6 +203,9 @@ func (s *Service) ListHumanInputs(ctx context.Context, req *daemonpb.ListHumanIn
 }
 
 func (s *Service) RetryRun(ctx context.Context, req *daemonpb.RetryRunRequest) (*daemonpb.Run, error) {
+	if s.Draining() {
+		return nil, errDraining
+	}
 	if err := s.store.RequestRunRetry(ctx, req.Id); err != nil {
 		return nil, toStatus(err)
 	}
@@ -147,10 +216,17 @@ func (s *Service) RetryRun(ctx context.Context, req *daemonpb.RetryRunRequest) (
 	return runToProto(r), nil
 }
 
+// CancelRun cancels the run together with its in-flight step attempts and
+// executions, then stops the executions' agent processes in the
+// background.
 func (s *Service) CancelRun(ctx context.Context, req *daemonpb.CancelRunRequest) (*daemonpb.Run, error) {
-	if err := s.store.TransitionRun(ctx, req.Id, orch.RunCancelled); err != nil {
+	active, err := s.store.CancelRun(ctx, req.Id)
+	if err != nil {
 		return nil, toStatus(err)
 	}
+	if s.stopper != nil && len(active) > 0 {
+		go s.stopExecutions(active)
+	}
 	r, err := s.store.GetRun(ctx, req.Id)
 	if err != nil {
 		return nil, toStatus(err)
@@ -200,6 +276,51 @@ func (s *Service) ListExecutions(ctx context.Context, req *daemonpb.ListExecutio
 	return resp, nil
 }
 
+// ListStepAttempts returns every attempt of every step of a run,
+// oldest-first, so clients can show "attempt 3 of step revise failed".
+func (s *Service) ListStepAttempts(ctx context.Context, req *daemonpb.ListStepAttemptsRequest) (*daemonpb.ListStepAttemptsResponse, error) {
+	if req.RunId == "" {
+		return nil, status.Error(codes.InvalidArgument, "run_id is required")
+	}
+	if _, err := s.store.GetRun(ctx, req.RunId); err != nil {
+		return nil, toStatus(err)
+	}
+	rows, err := s.store.ListStepAttemptsByRun(ctx, req.RunId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &daemonpb.ListStepAttemptsResponse{StepAttempts: make([]*daemonpb.StepAttempt, 0, len(rows))}
+	for i := range rows {
+		resp.StepAttempts = append(resp.StepAttempts, stepAttemptToProto(&rows[i]))
+	}
+	return resp, nil
+}
+
+// GetRunTimeline returns the run together with its joined history of step
+// attempts, executions, human inputs, artifacts and events on one time axis.
+// It backs the detail view of the Runs tab.
+func (s *Service) GetRunTimeline(ctx context.Context, req *daemonpb.GetRunTimelineRequest) (*daemonpb.RunTimeline, error) {
+	if req.RunId == "" {
+		return nil, status.Error(codes.InvalidArgument, "run_id is required")
+	}
+	r, err := s.store.GetRun(ctx, req.RunId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	entries, err := s.store.RunTimeline(ctx, req.RunId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &daemonpb.RunTimeline{
+		Run:     runToProto(r),
+		Entries: make([]*daemonpb.TimelineEntry, 0, len(entries)),
+	}
+	for i := range entries {
+		resp.Entries = append(resp.Entries, timelineEntryToProto(&entries[i]))
+	}
+	return resp, nil
+}
+
 func (s *Service) StreamEvents(req *daemonpb.StreamEventsRequest, stream daemonpb.Orchestrator_StreamEventsServer) error {
 	ctx := stream.Context()
 	if req.RunId == "" {
@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"context"
+
+	"bdtui/internal/daemon/daemonpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSessions returns the agent sessions recorded for a run, one per role.
+func (s *Service) ListSessions(ctx context.Context, req *daemonpb.ListSessionsRequest) (*daemonpb.ListSessionsResponse, error) {
+	if req.RunId == "" {
+		return nil, status.Error(codes.InvalidArgument, "run_id is required")
+	}
+	if _, err := s.store.GetRun(ctx, req.RunId); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.listSessions(ctx, req.RunId)
+}
+
+// ClearSession forgets a role's session so its next attempt starts a fresh
+// conversation.
+func (s *Service) ClearSession(ctx context.Context, req *daemonpb.SessionRequest) (*daemonpb.ListSessionsResponse, error) {
+	if req.RunId == "" || req.RoleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "run_id and role_id are required")
+	}
+	if err := s.store.ClearSession(ctx, req.RunId, req.RoleId); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.listSessions(ctx, req.RunId)
+}
+
+// ForkSession makes a role's next attempt continue its conversation under
+// a new session id, keeping the current one intact.
+func (s *Service) ForkSession(ctx context.Context, req *daemonpb.SessionRequest) (*daemonpb.ListSessionsResponse, error) {
+	if req.RunId == "" || req.RoleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "run_id and role_id are required")
+	}
+	if err := s.store.ForkSession(ctx, req.RunId, req.RoleId); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.listSessions(ctx, req.RunId)
+}
+
+func (s *Service) listSessions(ctx context.Context, runID string) (*daemonpb.ListSessionsResponse, error) {
+	sessions, err := s.store.ListSessionsByRun(ctx, runID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &daemonpb.ListSessionsResponse{Sessions: make([]*daemonpb.Session, 0, len(sessions))}
+	for i := range sessions {
+		resp.Sessions = append(resp.Sessions, sessionToProto(&sessions[i]))
+	}
+	return resp, nil
+}
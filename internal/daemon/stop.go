@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"bdtui/internal/agent"
+	"bdtui/internal/orch"
+)
+
+// ExecutionStopper is the part of agent.Runtime CancelRun needs to stop the
+// processes of a cancelled run. Stop is expected to give the agent its
+// grace period (SIGTERM, then SIGKILL) and return once it is gone.
+type ExecutionStopper interface {
+	Stop(ctx context.Context, exec agent.Execution) error
+}
+
+// SetExecutionStopper sets the runtime CancelRun stops executions with.
+// Without one CancelRun only cancels the rows. Call it before Serve.
+func (s *Server) SetExecutionStopper(stopper ExecutionStopper) {
+	s.service.stopper = stopper
+}
+
+// stopExecutions stops the agent processes behind executions a CancelRun
+// just cancelled, in parallel. The rows are already cancelled; a failed
+// stop is recorded as an execution.stop_failed event so the operator can
+// clean up by hand. It runs detached from the RPC, which does not wait out
+// the grace period.
+func (s *Service) stopExecutions(execs []orch.Execution) {
+	var wg sync.WaitGroup
+	for i := range execs {
+		e := &execs[i]
+		if e.Kind != orch.KindAgent {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.Background()
+			exec := agent.Execution{ID: e.ID}
+			if e.PaneID != nil {
+				exec.PaneID = *e.PaneID
+			}
+			if err := s.stopper.Stop(ctx, exec); err != nil {
+				payload, _ := json.Marshal(map[string]string{"execution_id": e.ID, "error": err.Error()})
+				_ = s.store.AppendEvent(ctx, &e.RunID, orch.EventExecStopFailed, string(payload))
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,157 @@
+package daemon
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+
+	"bdtui/internal/daemon/daemonpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// TCPConfig enables the optional remote listener. TLS is mandatory: the
+// listener never serves plaintext, because bearer tokens travel in
+// request metadata.
+type TCPConfig struct {
+	// Addr is the host:port to bind, e.g. "0.0.0.0:7443" or "127.0.0.1:0".
+	Addr string
+	// CertFile and KeyFile are the PEM server certificate and key.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile, when set, enables mTLS: client certificates signed by
+	// this CA are verified and granted ClientCertScope.
+	ClientCAFile    string
+	ClientCertScope Scope
+	// Tokens are the accepted bearer tokens and their scopes.
+	Tokens []TokenGrant
+}
+
+func (c TCPConfig) validate() error {
+	if c.Addr == "" {
+		return errors.New("tcp listener: address is required")
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return errors.New("tcp listener: TLS certificate and key are required")
+	}
+	if len(c.Tokens) == 0 && c.ClientCAFile == "" {
+		return errors.New("tcp listener: configure bearer tokens or a client CA; refusing to serve without authentication")
+	}
+	if c.ClientCertScope != "" && c.ClientCertScope != ScopeRead && c.ClientCertScope != ScopeOperator {
+		return fmt.Errorf("tcp listener: unknown client cert scope %q", c.ClientCertScope)
+	}
+	return nil
+}
+
+func (c TCPConfig) loadCertificate() (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tcp listener: load certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// tlsConfig builds the listener TLS config. The server certificate is read
+// from current on every handshake so ReloadTCP can rotate it in place.
+func (c TCPConfig) tlsConfig(current *atomic.Pointer[tls.Certificate]) (*tls.Config, error) {
+	cfg := &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return current.Load(), nil
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.ClientCAFile != "" {
+		pool, err := loadCertPool(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tcp listener: %w", err)
+		}
+		cfg.ClientCAs = pool
+		// Token-only clients stay allowed; a presented certificate must
+		// verify, and the interceptor decides what it grants.
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return cfg, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// ListenTCP binds the remote listener described by cfg. It runs on its own
+// gRPC server (TLS credentials plus the auth interceptors) that shares the
+// Service and health state with the Unix socket server, and starts
+// serving when Serve is called. It returns the bound address, which is
+// useful with port 0.
+func (s *Server) ListenTCP(cfg TCPConfig) (net.Addr, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	cert, err := cfg.loadCertificate()
+	if err != nil {
+		return nil, err
+	}
+	s.tcpCert.Store(cert)
+	tlsCfg, err := cfg.tlsConfig(&s.tcpCert)
+	if err != nil {
+		return nil, err
+	}
+	auth := &authenticator{tokens: cfg.Tokens}
+	if cfg.ClientCAFile != "" {
+		auth.certScope = cfg.ClientCertScope
+		if auth.certScope == "" {
+			auth.certScope = ScopeRead
+		}
+	}
+
+	// After a restart handoff the listener arrives already bound.
+	ln := s.inheritedTCP
+	if ln == nil {
+		if ln, err = net.Listen("tcp", cfg.Addr); err != nil {
+			return nil, err
+		}
+	}
+	s.tcpAuth = auth
+	s.tcpServer = grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsCfg)),
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+	)
+	daemonpb.RegisterOrchestratorServer(s.tcpServer, s.service)
+	grpc_health_v1.RegisterHealthServer(s.tcpServer, s.health)
+	s.tcpListener = ln
+	return ln.Addr(), nil
+}
+
+// ReloadTCP swaps the bearer tokens and server certificate of a running TCP
+// listener, e.g. on SIGHUP. The address and client CA are fixed for the
+// life of the listener; changing them needs a restart. On error nothing
+// is changed.
+func (s *Server) ReloadTCP(cfg TCPConfig) error {
+	if s.tcpServer == nil {
+		return errors.New("tcp listener is not enabled")
+	}
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	cert, err := cfg.loadCertificate()
+	if err != nil {
+		return err
+	}
+	s.tcpCert.Store(cert)
+	s.tcpAuth.setTokens(cfg.Tokens)
+	return nil
+}
@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"bdtui/internal/daemon/daemonpb"
+	"bdtui/internal/orch"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transcriptPollInterval is how often a following TailExecutionLog checks
+// the transcript for new output.
+const transcriptPollInterval = 250 * time.Millisecond
+
+// TailExecutionLog streams an execution's stdout transcript from
+// req.Offset. The transcript is resolved against the daemon state
+// directory like an artifact. With req.Follow the stream waits for the
+// file to appear and grow, and ends once the execution is terminal and
+// everything it wrote was sent; without it the stream ends at the current
+// end of file. The execution status is read before the file, so output
+// flushed before the execution finished is never cut off.
+func (s *Service) TailExecutionLog(req *daemonpb.TailExecutionLogRequest, stream daemonpb.Orchestrator_TailExecutionLogServer) error {
+	ctx := stream.Context()
+	if req.ExecutionId == "" {
+		return status.Error(codes.InvalidArgument, "execution_id is required")
+	}
+	if req.Offset < 0 {
+		return status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	off := req.Offset
+	var f *os.File
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+	ticker := time.NewTicker(transcriptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		e, err := s.store.GetExecution(ctx, req.ExecutionId)
+		if err != nil {
+			return toStatus(err)
+		}
+		if e.TranscriptRef == "" {
+			return status.Errorf(codes.FailedPrecondition, "execution %s has no transcript", e.ID)
+		}
+		finished := e.Status.Terminal()
+
+		if f == nil {
+			f, err = s.openTranscript(e)
+			if err != nil && (status.Code(err) != codes.NotFound || finished || !req.Follow) {
+				return err
+			}
+		}
+		if f != nil {
+			if off, err = sendTranscript(f, off, e.Status, stream); err != nil {
+				return err
+			}
+		}
+		if finished || !req.Follow {
+			return stream.Send(&daemonpb.ExecutionLogChunk{Offset: off, Eof: true, Status: string(e.Status)})
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// openTranscript opens the transcript of e. A transcript the runtime has
+// not created yet is NotFound.
+func (s *Service) openTranscript(e *orch.Execution) (*os.File, error) {
+	path, err := resolveArtifactPath(s.artifactRoot, e.TranscriptRef)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, status.Errorf(codes.NotFound, "transcript %s is missing", e.TranscriptRef)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return f, nil
+}
+
+// sendTranscript sends everything in f from off to the current end of
+// file and returns the new offset.
+func sendTranscript(f *os.File, off int64, st orch.ExecutionStatus, stream daemonpb.Orchestrator_TailExecutionLogServer) (int64, error) {
+	for {
+		// A fresh buffer per chunk, as in ReadArtifact.
+		data := make([]byte, artifactChunkSize)
+		n, err := f.ReadAt(data, off)
+		if n > 0 {
+			if serr := stream.Send(&daemonpb.ExecutionLogChunk{Data: data[:n], Offset: off, Status: string(st)}); serr != nil {
+				return off, serr
+			}
+			off += int64(n)
+		}
+		if err == io.EOF {
+			return off, nil
+		}
+		if err != nil {
+			return off, status.Error(codes.Internal, err.Error())
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"bdtui/internal/daemon/daemonpb"
+	"bdtui/internal/orch"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Version is the build version shared by bdtuid and the TUI. Release
+// builds set it with -ldflags "-X bdtui/internal/daemon.Version=v1.2.3".
+var Version = "dev"
+
+// APIVersion is the Orchestrator wire contract version. Bump it whenever a
+// client starts depending on an RPC or field an older daemon does not
+// serve; a client talking to a daemon with a lower APIVersion treats the
+// daemon as stale.
+const APIVersion = 3
+
+// orchestratorServiceName is the health-check service name registered for
+// the Orchestrator API, alongside the overall "" server status.
+const orchestratorServiceName = "bdtui.daemon.v1.Orchestrator"
+
+// ErrStaleDaemon reports that the running daemon is older than the client.
+var ErrStaleDaemon = errors.New("daemon: running daemon is older than this client")
+
+// GetDaemonInfo reports the daemon build, schema version and a snapshot of
+// run counts. It is cheap enough for clients to call on every connect.
+func (s *Service) GetDaemonInfo(ctx context.Context, _ *daemonpb.GetDaemonInfoRequest) (*daemonpb.DaemonInfo, error) {
+	schema, err := s.store.SchemaVersion(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	counts, err := s.store.CountRunsByStatus(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	info := &daemonpb.DaemonInfo{
+		Version:       Version,
+		ApiVersion:    APIVersion,
+		SchemaVersion: int64(schema),
+		StartedAt:     timeToProto(s.startedAt),
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		DbPath:        s.store.Path(),
+		ActiveRuns:    int64(counts[orch.RunRunning] + counts[orch.RunWaitingHuman]),
+		AttentionRuns: int64(counts[orch.RunNeedsAttention]),
+		QueueDepth:    int64(counts[orch.RunQueued]),
+		Pid:           int32(os.Getpid()),
+		Draining:      s.Draining(),
+	}
+	if d := s.drainDeadline(); !d.IsZero() {
+		info.DrainDeadline = timeToProto(d)
+	}
+	return info, nil
+}
+
+// Handshake fetches DaemonInfo and checks it against this client's
+// APIVersion. It returns ErrStaleDaemon (wrapped with both versions) when
+// the daemon is older or predates GetDaemonInfo altogether; the info is
+// still returned when available so callers can show it.
+func (c *Client) Handshake(ctx context.Context) (*daemonpb.DaemonInfo, error) {
+	info, err := c.GetDaemonInfo(ctx, &daemonpb.GetDaemonInfoRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, fmt.Errorf("%w: daemon has no GetDaemonInfo (client api %d)", ErrStaleDaemon, APIVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.ApiVersion < APIVersion {
+		return info, fmt.Errorf("%w: daemon %s api %d, client %s api %d",
+			ErrStaleDaemon, info.Version, info.ApiVersion, Version, APIVersion)
+	}
+	return info, nil
+}
+
+// CheckHealth queries the standard gRPC health service. Daemons that
+// predate the health service are treated as serving; Handshake is what
+// flags them as stale.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	resp, err := c.health.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: orchestratorServiceName})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("daemon is %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package orch
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// BeadsSync is a run whose bd issue needs updating: every run transition
+// bumps Generation, and the sync is done once a sync of that generation
+// succeeds. Attempts counts the failed syncs of the current generation.
+type BeadsSync struct {
+	RunID      string
+	Generation int64
+	Attempts   int
+}
+
+// markBeadsSyncTx queues a bd sync for a run inside the transaction that
+// changed its status, so a sync is never lost to a crash between the two.
+// A run already queued gets a new generation and is retried immediately.
+func markBeadsSyncTx(ctx context.Context, tx *sql.Tx, runID string, now time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO beads_sync(run_id, generation, synced_generation, attempts, next_attempt_at, updated_at)
+		 VALUES(?, 1, 0, 0, ?, ?)
+		 ON CONFLICT(run_id) DO UPDATE SET generation = generation + 1, attempts = 0,
+		                                   next_attempt_at = excluded.next_attempt_at, updated_at = excluded.updated_at`,
+		runID, now.UnixNano(), timeString(now),
+	)
+	return err
+}
+
+// PendingBeadsSyncs returns the runs with an unsynced transition whose
+// next attempt is due at now, oldest first.
+func (s *Store) PendingBeadsSyncs(ctx context.Context, now time.Time) ([]BeadsSync, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT run_id, generation, attempts FROM beads_sync
+		 WHERE generation > synced_generation AND next_attempt_at <= ?
+		 ORDER BY next_attempt_at, run_id`, now.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []BeadsSync
+	for rows.Next() {
+		var b BeadsSync
+		if err := rows.Scan(&b.RunID, &b.Generation, &b.Attempts); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// CompleteBeadsSync records a successful sync of b's generation and appends
+// a beads.synced event carrying payload. A transition that landed during
+// the sync keeps the run pending.
+func (s *Store) CompleteBeadsSync(ctx context.Context, b BeadsSync, payload map[string]any) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE beads_sync SET synced_generation = MAX(synced_generation, ?), last_error = NULL, updated_at = ?
+		 WHERE run_id = ?`,
+		b.Generation, timeString(nowUTC()), b.RunID,
+	); err != nil {
+		return err
+	}
+	if err := appendEventMapTx(ctx, tx, &b.RunID, EventBeadsSynced, payload); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FailBeadsSync records a failed sync of b's generation, schedules the next
+// attempt at retryAt and appends a beads.sync_failed event. It leaves a
+// newer generation alone: that one is already due.
+func (s *Store) FailBeadsSync(ctx context.Context, b BeadsSync, syncErr error, retryAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE beads_sync SET attempts = attempts + 1, next_attempt_at = ?, last_error = ?, updated_at = ?
+		 WHERE run_id = ? AND generation = ?`,
+		retryAt.UnixNano(), syncErr.Error(), timeString(nowUTC()), b.RunID, b.Generation,
+	); err != nil {
+		return err
+	}
+	if err := appendEventMapTx(ctx, tx, &b.RunID, EventBeadsSyncFailed, map[string]any{
+		"run_id": b.RunID, "attempt": b.Attempts + 1, "error": syncErr.Error(), "retry_at": timeString(retryAt),
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -1,6 +1,9 @@
 package orch
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Project is a durable, addressable workspace. Its ID is stable; FsPath and
 // GitRemote are mutable attributes that may change as the project moves.
@@ -18,7 +21,11 @@ type Project struct {
 // both are populated at Run start from the workflow dependency closure.
 //
 // TaskID references the source Kanban task (bd issue); at most one active
-// (non-terminal) run may exist per task.
+// (non-terminal) run may exist per task. TaskSnapshot is the JSON task
+// context (agent.TaskSnapshot) captured from bd at run start, empty for runs
+// created without one. Budget is fixed at creation from
+// the workflow snapshot; Usage is the sum over the run's executions and is
+// read-only.
 type Run struct {
 	ID                   string     `json:"id"`
 	ProjectID            string     `json:"project_id"`
@@ -26,15 +33,48 @@ type Run struct {
 	Status               RunStatus  `json:"status"`
 	WorkflowSnapshotRef  string     `json:"workflow_snapshot_ref"`
 	WorkflowSnapshot     string     `json:"workflow_snapshot"`
+	TaskSnapshot         string     `json:"task_snapshot"`
 	CurrentStepID        *string    `json:"current_step_id"`
 	NeedsAttentionReason *string    `json:"needs_attention_reason"`
 	Error                *string    `json:"error"`
+	Budget               Budget     `json:"budget"`
+	Usage                Usage      `json:"usage"`
 	CreatedAt            time.Time  `json:"created_at"`
 	UpdatedAt            time.Time  `json:"updated_at"`
 	StartedAt            *time.Time `json:"started_at"`
 	CompletedAt          *time.Time `json:"completed_at"`
 }
 
+// Usage is the token and cost accounting an agent reported for an
+// execution, or the total over a run.
+type Usage struct {
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Tokens is the total of input and output tokens.
+func (u Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens
+}
+
+// Budget caps a run's total Usage. A zero field is no limit.
+type Budget struct {
+	MaxTokens  int64   `json:"max_tokens"`
+	MaxCostUSD float64 `json:"max_cost_usd"`
+}
+
+// Exceeded describes the first limit u goes over, or returns "".
+func (b Budget) Exceeded(u Usage) string {
+	if b.MaxTokens > 0 && u.Tokens() > b.MaxTokens {
+		return fmt.Sprintf("token budget exceeded: %d of %d", u.Tokens(), b.MaxTokens)
+	}
+	if b.MaxCostUSD > 0 && u.CostUSD > b.MaxCostUSD {
+		return fmt.Sprintf("cost budget exceeded: $%.2f of $%.2f", u.CostUSD, b.MaxCostUSD)
+	}
+	return ""
+}
+
 // StepAttempt is one execution attempt of a workflow step (identified by
 // StepID) within a Run. Attempt numbers are 1-based and unique per
 // (run_id, step_id).
@@ -58,7 +98,9 @@ type StepAttempt struct {
 //
 // Prompt content lives outside the worktree in controller-managed Run storage
 // and is referenced by PromptRef (path) + PromptHash (content hash); only the
-// reference is durable here, not the prompt body.
+// reference is durable here, not the prompt body. TranscriptRef likewise
+// points at the agent's stdout stream, written as it arrives so clients can
+// tail a running execution.
 type Execution struct {
 	ID            string          `json:"id"`
 	RunID         string          `json:"run_id"`
@@ -69,6 +111,8 @@ type Execution struct {
 	ProcessID     *string         `json:"process_id"`
 	PromptRef     string          `json:"prompt_ref"`
 	PromptHash    string          `json:"prompt_hash"`
+	TranscriptRef string          `json:"transcript_ref"`
+	Usage         Usage           `json:"usage"`
 	ResultJSON    *string         `json:"result_json"`
 	ResultCommit  *string         `json:"result_commit"`
 	Error         *string         `json:"error"`
@@ -118,6 +162,20 @@ type HumanInput struct {
 	AnsweredAt    *time.Time       `json:"answered_at"`
 }
 
+// Session is the agent conversation a role reuses within one run, so
+// revise/review loops keep their context across daemon restarts.
+// ForkPending asks the next invocation to branch the conversation into a
+// new session id; ForkedFrom records the session it branched from.
+type Session struct {
+	RunID       string    `json:"run_id"`
+	RoleID      string    `json:"role_id"`
+	SessionID   string    `json:"session_id"`
+	ForkPending bool      `json:"fork_pending"`
+	ForkedFrom  *string   `json:"forked_from"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // Event is an append-only audit/TUI stream entry. Relational state is
 // authoritative; events are never mutated after append. Seq is monotonic per
 // run (NULL run_id means a project-scoped event).
@@ -3,6 +3,7 @@ package orch
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/google/uuid"
@@ -35,11 +36,11 @@ func (s *Store) CreateExecution(ctx context.Context, e *Execution) error {
 
 	if _, err := tx.ExecContext(ctx,
 		`INSERT INTO executions(id, run_id, step_attempt_id, kind, status, pane_id, process_id,
-		                        prompt_ref, prompt_hash, result_json, result_commit, error,
+		                        prompt_ref, prompt_hash, transcript_ref, input_tokens, output_tokens, cost_usd, result_json, result_commit, error,
 		                        created_at, updated_at, started_at, completed_at)
-		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		e.ID, e.RunID, e.StepAttemptID, string(e.Kind), string(e.Status), nullString(e.PaneID), nullString(e.ProcessID),
-		e.PromptRef, e.PromptHash, nullString(e.ResultJSON), nullString(e.ResultCommit), nullString(e.Error),
+		e.PromptRef, e.PromptHash, e.TranscriptRef, e.Usage.InputTokens, e.Usage.OutputTokens, e.Usage.CostUSD, nullString(e.ResultJSON), nullString(e.ResultCommit), nullString(e.Error),
 		timeString(e.CreatedAt), timeString(e.UpdatedAt), timeStringPtr(e.StartedAt), timeStringPtr(e.CompletedAt),
 	); err != nil {
 		return err
@@ -56,7 +57,7 @@ func (s *Store) CreateExecution(ctx context.Context, e *Execution) error {
 func (s *Store) GetExecution(ctx context.Context, id string) (*Execution, error) {
 	row := s.db.QueryRowContext(ctx,
 		`SELECT id, run_id, step_attempt_id, kind, status, pane_id, process_id,
-		        prompt_ref, prompt_hash, result_json, result_commit, error,
+		        prompt_ref, prompt_hash, transcript_ref, input_tokens, output_tokens, cost_usd, result_json, result_commit, error,
 		        created_at, updated_at, started_at, completed_at
 		 FROM executions WHERE id = ?`, id)
 
@@ -65,7 +66,8 @@ func (s *Store) GetExecution(ctx context.Context, id string) (*Execution, error)
 	var pane, proc, resultJSON, resultCommit, errStr, started, completed sql.NullString
 
 	if err := row.Scan(&e.ID, &e.RunID, &e.StepAttemptID, &kind, &status, &pane, &proc,
-		&e.PromptRef, &e.PromptHash, &resultJSON, &resultCommit, &errStr, &created, &updated, &started, &completed); err != nil {
+		&e.PromptRef, &e.PromptHash, &e.TranscriptRef,
+		&e.Usage.InputTokens, &e.Usage.OutputTokens, &e.Usage.CostUSD, &resultJSON, &resultCommit, &errStr, &created, &updated, &started, &completed); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
@@ -169,6 +171,77 @@ func (s *Store) SetExecutionResultJSON(ctx context.Context, id, jsonResult strin
 	return nil
 }
 
+// RecordExecutionUsage stores the usage an agent reported for an execution
+// (replacing any earlier report, so a retried report does not count twice)
+// and checks the run's budget against the new totals. A run that goes over
+// budget while it can still advance is moved to needs_attention with the
+// budget as its reason, and exceeded is returned so the caller stops the
+// run's live executions.
+func (s *Store) RecordExecutionUsage(ctx context.Context, id string, u Usage) (exceeded bool, err error) {
+	now := nowUTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var runID string
+	if err := tx.QueryRowContext(ctx, `SELECT run_id FROM executions WHERE id = ?`, id).Scan(&runID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrNotFound
+		}
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE executions SET input_tokens = ?, output_tokens = ?, cost_usd = ?, updated_at = ? WHERE id = ?`,
+		u.InputTokens, u.OutputTokens, u.CostUSD, timeString(now), id,
+	); err != nil {
+		return false, err
+	}
+	if err := appendEventMapTx(ctx, tx, &runID, EventExecUsage, map[string]any{
+		"run_id": runID, "execution_id": id,
+		"input_tokens": u.InputTokens, "output_tokens": u.OutputTokens, "cost_usd": u.CostUSD,
+	}); err != nil {
+		return false, err
+	}
+
+	var cur RunStatus
+	var budget Budget
+	var total Usage
+	if err := tx.QueryRowContext(ctx,
+		`SELECT status, max_tokens, max_cost_usd, `+runUsageSQL+` FROM runs WHERE id = ?`, runID,
+	).Scan(&cur, &budget.MaxTokens, &budget.MaxCostUSD, &total.InputTokens, &total.OutputTokens, &total.CostUSD); err != nil {
+		return false, err
+	}
+	reason := budget.Exceeded(total)
+	if reason == "" || cur == RunNeedsAttention || !CanTransitionRun(cur, RunNeedsAttention) {
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE runs SET status = ?, needs_attention_reason = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		string(RunNeedsAttention), reason, timeString(now), runID, cur,
+	); err != nil {
+		return false, err
+	}
+	if err := appendEventMapTx(ctx, tx, &runID, EventRunTransition, map[string]any{
+		"run_id": runID, "from": cur, "to": RunNeedsAttention,
+	}); err != nil {
+		return false, err
+	}
+	if err := appendEventMapTx(ctx, tx, &runID, EventRunBudgetExceeded, map[string]any{
+		"run_id": runID, "reason": reason,
+		"input_tokens": total.InputTokens, "output_tokens": total.OutputTokens, "cost_usd": total.CostUSD,
+	}); err != nil {
+		return false, err
+	}
+	if err := markBeadsSyncTx(ctx, tx, runID, now); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
 // ListExecutionsByRun returns every execution attached to a run,
 // oldest-first. The Runs tab calls this to fetch the pane_id of the
 // most-recent execution so the operator can follow the BIR-54
@@ -176,12 +249,86 @@ func (s *Store) SetExecutionResultJSON(ctx context.Context, id, jsonResult strin
 func (s *Store) ListExecutionsByRun(ctx context.Context, runID string) ([]Execution, error) {
 	rows, err := s.db.QueryContext(ctx,
 		`SELECT id, run_id, step_attempt_id, kind, status, pane_id, process_id,
-		        prompt_ref, prompt_hash, result_json, result_commit, error,
+		        prompt_ref, prompt_hash, transcript_ref, input_tokens, output_tokens, cost_usd, result_json, result_commit, error,
 		        created_at, updated_at, started_at, completed_at
 		 FROM executions WHERE run_id = ? ORDER BY created_at, id`, runID)
 	if err != nil {
 		return nil, err
 	}
+	return scanExecutions(rows)
+}
+
+// ListActiveExecutions returns every queued or running execution across all
+// runs, oldest first. The daemon uses it to decide when a drain is done.
+func (s *Store) ListActiveExecutions(ctx context.Context) ([]Execution, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, run_id, step_attempt_id, kind, status, pane_id, process_id,
+		        prompt_ref, prompt_hash, transcript_ref, input_tokens, output_tokens, cost_usd, result_json, result_commit, error,
+		        created_at, updated_at, started_at, completed_at
+		 FROM executions WHERE status IN ('queued','running') ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	return scanExecutions(rows)
+}
+
+// MarkExecutionDetached records that the daemon stopped while e was still
+// running. The execution row is left as-is (its pane/process reference is
+// what a later Runtime.Reattach needs); the event makes the hand-over
+// visible in the run history.
+func (s *Store) MarkExecutionDetached(ctx context.Context, e *Execution, reason string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	fields := map[string]any{"execution_id": e.ID, "status": e.Status, "reason": reason}
+	if e.PaneID != nil {
+		fields["pane_id"] = *e.PaneID
+	}
+	if e.ProcessID != nil {
+		fields["process_id"] = *e.ProcessID
+	}
+	if err := appendEventMapTx(ctx, tx, &e.RunID, EventExecDetached, fields); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ExecutionDetached reports whether e has an execution.detached event newer
+// than its last update, i.e. a daemon already handed it over and nothing
+// has touched it since.
+func (s *Store) ExecutionDetached(ctx context.Context, e *Execution) (bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT payload, created_at FROM events WHERE run_id = ? AND type = ?`, e.RunID, EventExecDetached)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var payload, created string
+		if err := rows.Scan(&payload, &created); err != nil {
+			return false, err
+		}
+		var p struct {
+			ExecutionID string `json:"execution_id"`
+		}
+		if json.Unmarshal([]byte(payload), &p) != nil || p.ExecutionID != e.ID {
+			continue
+		}
+		at, err := parseTime(created)
+		if err != nil {
+			return false, err
+		}
+		if !at.Before(e.UpdatedAt) {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+func scanExecutions(rows *sql.Rows) ([]Execution, error) {
 	defer rows.Close()
 
 	var out []Execution
@@ -190,7 +337,8 @@ func (s *Store) ListExecutionsByRun(ctx context.Context, runID string) ([]Execut
 		var kind, status, created, updated string
 		var pane, proc, resultJSON, resultCommit, errStr, started, completed sql.NullString
 		if err := rows.Scan(&e.ID, &e.RunID, &e.StepAttemptID, &kind, &status, &pane, &proc,
-			&e.PromptRef, &e.PromptHash, &resultJSON, &resultCommit, &errStr, &created, &updated, &started, &completed); err != nil {
+			&e.PromptRef, &e.PromptHash, &e.TranscriptRef,
+			&e.Usage.InputTokens, &e.Usage.OutputTokens, &e.Usage.CostUSD, &resultJSON, &resultCommit, &errStr, &created, &updated, &started, &completed); err != nil {
 			return nil, err
 		}
 		e.Kind = ExecutionKind(kind)
@@ -236,6 +384,25 @@ func (s *Store) CreateArtifact(ctx context.Context, a *Artifact) error {
 	return err
 }
 
+// GetArtifact returns a single artifact by id.
+func (s *Store) GetArtifact(ctx context.Context, id string) (*Artifact, error) {
+	var a Artifact
+	var created string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, execution_id, name, path, hash, created_at FROM artifacts WHERE id = ?`, id,
+	).Scan(&a.ID, &a.ExecutionID, &a.Name, &a.Path, &a.Hash, &created)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if a.CreatedAt, err = parseTime(created); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
 // ListArtifactsByExecution returns artifacts for an execution ordered by name.
 func (s *Store) ListArtifactsByExecution(ctx context.Context, executionID string) ([]Artifact, error) {
 	rows, err := s.db.QueryContext(ctx,
@@ -261,3 +428,32 @@ func (s *Store) ListArtifactsByExecution(ctx context.Context, executionID string
 	}
 	return artifacts, rows.Err()
 }
+
+// ListArtifactsByRun returns every artifact produced by any execution of a
+// run, ordered by creation time then name.
+func (s *Store) ListArtifactsByRun(ctx context.Context, runID string) ([]Artifact, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT a.id, a.execution_id, a.name, a.path, a.hash, a.created_at
+		 FROM artifacts a JOIN executions e ON e.id = a.execution_id
+		 WHERE e.run_id = ? ORDER BY a.created_at, a.name`,
+		runID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var artifacts []Artifact
+	for rows.Next() {
+		var a Artifact
+		var created string
+		if err := rows.Scan(&a.ID, &a.ExecutionID, &a.Name, &a.Path, &a.Hash, &created); err != nil {
+			return nil, err
+		}
+		if a.CreatedAt, err = parseTime(created); err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, rows.Err()
+}
@@ -11,6 +11,12 @@ import (
 // activeRunStatusesSQL is the SQL list of non-terminal run statuses.
 const activeRunStatusesSQL = "('queued','running','waiting_human','needs_attention')"
 
+// runUsageSQL selects a run's Usage totals from its executions; it expects
+// the runs table unaliased in the enclosing query.
+const runUsageSQL = `(SELECT COALESCE(SUM(input_tokens), 0) FROM executions WHERE run_id = runs.id),
+		        (SELECT COALESCE(SUM(output_tokens), 0) FROM executions WHERE run_id = runs.id),
+		        (SELECT COALESCE(SUM(cost_usd), 0) FROM executions WHERE run_id = runs.id)`
+
 func (s *Store) CreateRun(ctx context.Context, r *Run) error {
 	if r.ID == "" {
 		r.ID = uuid.NewString()
@@ -47,11 +53,13 @@ func (s *Store) CreateRun(ctx context.Context, r *Run) error {
 	}
 
 	if _, err := tx.ExecContext(ctx,
-		`INSERT INTO runs(id, project_id, task_id, status, workflow_snapshot_ref, workflow_snapshot,
-		                  current_step_id, needs_attention_reason, error, created_at, updated_at, started_at, completed_at)
-		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		r.ID, r.ProjectID, r.TaskID, string(r.Status), r.WorkflowSnapshotRef, r.WorkflowSnapshot,
+		`INSERT INTO runs(id, project_id, task_id, status, workflow_snapshot_ref, workflow_snapshot, task_snapshot,
+		                  current_step_id, needs_attention_reason, error, max_tokens, max_cost_usd,
+		                  created_at, updated_at, started_at, completed_at)
+		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.ProjectID, r.TaskID, string(r.Status), r.WorkflowSnapshotRef, r.WorkflowSnapshot, r.TaskSnapshot,
 		nullString(r.CurrentStepID), nullString(r.NeedsAttentionReason), nullString(r.Error),
+		r.Budget.MaxTokens, r.Budget.MaxCostUSD,
 		timeString(r.CreatedAt), timeString(r.UpdatedAt), timeStringPtr(r.StartedAt), timeStringPtr(r.CompletedAt),
 	); err != nil {
 		return err
@@ -62,13 +70,18 @@ func (s *Store) CreateRun(ctx context.Context, r *Run) error {
 	}); err != nil {
 		return err
 	}
+	if err := markBeadsSyncTx(ctx, tx, r.ID, now); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
 func (s *Store) GetRun(ctx context.Context, id string) (*Run, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, project_id, task_id, status, workflow_snapshot_ref, workflow_snapshot,
-		        current_step_id, needs_attention_reason, error, created_at, updated_at, started_at, completed_at
+		`SELECT id, project_id, task_id, status, workflow_snapshot_ref, workflow_snapshot, task_snapshot,
+		        current_step_id, needs_attention_reason, error, max_tokens, max_cost_usd,
+		        `+runUsageSQL+`,
+		        created_at, updated_at, started_at, completed_at
 		 FROM runs WHERE id = ?`, id)
 
 	r := &Run{}
@@ -77,8 +90,10 @@ func (s *Store) GetRun(ctx context.Context, id string) (*Run, error) {
 	var currentStep, reason, errStr sql.NullString
 	var started, completed sql.NullString
 
-	if err := row.Scan(&r.ID, &r.ProjectID, &r.TaskID, &status, &r.WorkflowSnapshotRef, &r.WorkflowSnapshot,
-		&currentStep, &reason, &errStr, &created, &updated, &started, &completed); err != nil {
+	if err := row.Scan(&r.ID, &r.ProjectID, &r.TaskID, &status, &r.WorkflowSnapshotRef, &r.WorkflowSnapshot, &r.TaskSnapshot,
+		&currentStep, &reason, &errStr, &r.Budget.MaxTokens, &r.Budget.MaxCostUSD,
+		&r.Usage.InputTokens, &r.Usage.OutputTokens, &r.Usage.CostUSD,
+		&created, &updated, &started, &completed); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
@@ -170,6 +185,27 @@ func (s *Store) ListRuns(ctx context.Context) ([]Run, error) {
 	return runs, nil
 }
 
+// CountRunsByStatus returns the number of runs in each status. Statuses
+// with no runs are absent from the map.
+func (s *Store) CountRunsByStatus(ctx context.Context) (map[RunStatus]int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM runs GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[RunStatus]int)
+	for rows.Next() {
+		var st string
+		var n int
+		if err := rows.Scan(&st, &n); err != nil {
+			return nil, err
+		}
+		out[RunStatus(st)] = n
+	}
+	return out, rows.Err()
+}
+
 // TransitionRun atomically moves a run to `to` if that transition is legal per
 // the Run state machine. It updates only lifecycle timestamps and audit state;
 // metadata fields (current_step_id, needs_attention_reason, error) are managed
@@ -224,6 +260,9 @@ func (s *Store) TransitionRun(ctx context.Context, id string, to RunStatus) erro
 	}); err != nil {
 		return err
 	}
+	if err := markBeadsSyncTx(ctx, tx, id, now); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
@@ -269,9 +308,128 @@ func (s *Store) RequestRunRetry(ctx context.Context, id string) error {
 	}); err != nil {
 		return err
 	}
+	if err := markBeadsSyncTx(ctx, tx, id, now); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
+// CancelRun cancels a run and everything still in flight under it, in one
+// transaction: the run, then each non-terminal step attempt, then each
+// non-terminal execution moves to cancelled with its transition event, and
+// pending human inputs are withdrawn. It returns the executions that were
+// active (with their pre-cancel status) so the caller can stop their
+// processes; the rows are already cancelled by then.
+func (s *Store) CancelRun(ctx context.Context, id string) ([]Execution, error) {
+	now := timeString(nowUTC())
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var cur RunStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM runs WHERE id = ?`, id).Scan(&cur); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !CanTransitionRun(cur, RunCancelled) {
+		return nil, ErrInvalidTransition
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE runs SET status = ?, updated_at = ?, completed_at = ? WHERE id = ? AND status = ?`,
+		string(RunCancelled), now, now, id, cur,
+	); err != nil {
+		return nil, err
+	}
+	if err := appendEventMapTx(ctx, tx, &id, EventRunTransition, map[string]any{
+		"run_id": id, "from": cur, "to": RunCancelled,
+	}); err != nil {
+		return nil, err
+	}
+	if err := markBeadsSyncTx(ctx, tx, id, nowUTC()); err != nil {
+		return nil, err
+	}
+
+	type attempt struct {
+		id     string
+		status StepAttemptStatus
+	}
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, status FROM step_attempts WHERE run_id = ? AND status NOT IN ('completed','failed','cancelled')
+		 ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, err
+	}
+	var attempts []attempt
+	for rows.Next() {
+		var a attempt
+		if err := rows.Scan(&a.id, &a.status); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, a := range attempts {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE step_attempts SET status = ?, updated_at = ?, completed_at = ? WHERE id = ?`,
+			string(StepCancelled), now, now, a.id,
+		); err != nil {
+			return nil, err
+		}
+		if err := appendEventMapTx(ctx, tx, &id, EventStepTransition, map[string]any{
+			"run_id": id, "step_attempt_id": a.id, "from": a.status, "to": StepCancelled,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err = tx.QueryContext(ctx,
+		`SELECT id, run_id, step_attempt_id, kind, status, pane_id, process_id,
+		        prompt_ref, prompt_hash, transcript_ref, input_tokens, output_tokens, cost_usd, result_json, result_commit, error,
+		        created_at, updated_at, started_at, completed_at
+		 FROM executions WHERE run_id = ? AND status NOT IN ('completed','failed','cancelled')
+		 ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, err
+	}
+	active, err := scanExecutions(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range active {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE executions SET status = ?, updated_at = ?, completed_at = ? WHERE id = ?`,
+			string(ExecCancelled), now, now, e.ID,
+		); err != nil {
+			return nil, err
+		}
+		if err := appendEventMapTx(ctx, tx, &id, EventExecTransition, map[string]any{
+			"run_id": id, "execution_id": e.ID, "from": e.Status, "to": ExecCancelled,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE human_inputs SET status = ? WHERE run_id = ? AND status = ?`,
+		string(HumanCancelled), id, string(HumanPending),
+	); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
 // SetRunCurrentStep sets (or clears, when stepID is nil) the current step of a
 // run without changing its status.
 func (s *Store) SetRunCurrentStep(ctx context.Context, id string, stepID *string) error {
@@ -140,6 +140,63 @@ CREATE TABLE step_attempt_counters (
     next_attempt INTEGER NOT NULL DEFAULT 2,
     PRIMARY KEY (run_id, step_id)
 );
+`,
+	},
+	{
+		version: 2,
+		name:    "sessions",
+		sql: `
+CREATE TABLE sessions (
+    run_id       TEXT NOT NULL REFERENCES runs(id),
+    role_id      TEXT NOT NULL,
+    session_id   TEXT NOT NULL,
+    fork_pending INTEGER NOT NULL DEFAULT 0,
+    forked_from  TEXT,
+    created_at   TEXT NOT NULL,
+    updated_at   TEXT NOT NULL,
+    PRIMARY KEY (run_id, role_id)
+);
+`,
+	},
+	{
+		version: 3,
+		name:    "execution_transcripts",
+		sql: `
+ALTER TABLE executions ADD COLUMN transcript_ref TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		version: 4,
+		name:    "usage_and_budgets",
+		sql: `
+ALTER TABLE executions ADD COLUMN input_tokens INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE executions ADD COLUMN output_tokens INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE executions ADD COLUMN cost_usd REAL NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN max_tokens INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN max_cost_usd REAL NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		version: 5,
+		name:    "task_snapshots",
+		sql: `
+ALTER TABLE runs ADD COLUMN task_snapshot TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		version: 6,
+		name:    "beads_sync",
+		sql: `
+CREATE TABLE beads_sync (
+    run_id            TEXT PRIMARY KEY REFERENCES runs(id),
+    generation        INTEGER NOT NULL,
+    synced_generation INTEGER NOT NULL DEFAULT 0,
+    attempts          INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at   INTEGER NOT NULL,
+    last_error        TEXT,
+    updated_at        TEXT NOT NULL
+);
+CREATE INDEX idx_beads_sync_pending ON beads_sync(next_attempt_at);
 `,
 	},
 }
@@ -0,0 +1,208 @@
+package orch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// SessionKey is the agent.SessionStore key for a role's session in a run.
+func SessionKey(runID, roleID string) string {
+	return runID + "/" + roleID
+}
+
+// splitSessionKey parses a SessionKey. Role ids never contain "/", so the
+// last separator splits the key.
+func splitSessionKey(key string) (runID, roleID string, ok bool) {
+	i := strings.LastIndexByte(key, '/')
+	if i <= 0 || i == len(key)-1 {
+		return "", "", false
+	}
+	return key[:i], key[i+1:], true
+}
+
+// GetSession returns the session recorded for a role in a run.
+func (s *Store) GetSession(ctx context.Context, runID, roleID string) (*Session, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT run_id, role_id, session_id, fork_pending, forked_from, created_at, updated_at
+		 FROM sessions WHERE run_id = ? AND role_id = ?`, runID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sessions, err := scanSessions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, ErrNotFound
+	}
+	return &sessions[0], nil
+}
+
+// ListSessionsByRun returns the sessions of a run ordered by role id.
+func (s *Store) ListSessionsByRun(ctx context.Context, runID string) ([]Session, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT run_id, role_id, session_id, fork_pending, forked_from, created_at, updated_at
+		 FROM sessions WHERE run_id = ? ORDER BY role_id`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSessions(rows)
+}
+
+func scanSessions(rows *sql.Rows) ([]Session, error) {
+	var out []Session
+	for rows.Next() {
+		var se Session
+		var forkedFrom sql.NullString
+		var created, updated string
+		if err := rows.Scan(&se.RunID, &se.RoleID, &se.SessionID, &se.ForkPending, &forkedFrom, &created, &updated); err != nil {
+			return nil, err
+		}
+		se.ForkedFrom = strPtr(forkedFrom)
+		var err error
+		if se.CreatedAt, err = parseTime(created); err != nil {
+			return nil, err
+		}
+		if se.UpdatedAt, err = parseTime(updated); err != nil {
+			return nil, err
+		}
+		out = append(out, se)
+	}
+	return out, rows.Err()
+}
+
+// PutSession records the session id an agent reported for a role. When a
+// fork was pending and the agent reported a new id, the previous id is kept
+// as ForkedFrom; either way the fork request is consumed.
+func (s *Store) PutSession(ctx context.Context, runID, roleID, sessionID string) error {
+	if sessionID == "" {
+		return errors.New("orch: session id is required")
+	}
+	now := timeString(nowUTC())
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO sessions(run_id, role_id, session_id, fork_pending, forked_from, created_at, updated_at)
+		 VALUES(?, ?, ?, 0, NULL, ?, ?)
+		 ON CONFLICT(run_id, role_id) DO UPDATE SET
+		     forked_from  = CASE WHEN sessions.fork_pending = 1 AND sessions.session_id <> excluded.session_id
+		                         THEN sessions.session_id ELSE sessions.forked_from END,
+		     session_id   = excluded.session_id,
+		     fork_pending = 0,
+		     updated_at   = excluded.updated_at`,
+		runID, roleID, sessionID, now, now,
+	)
+	return err
+}
+
+// ClearSession forgets a role's session so its next invocation starts a
+// fresh conversation.
+func (s *Store) ClearSession(ctx context.Context, runID, roleID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sid string
+	if err := tx.QueryRowContext(ctx,
+		`SELECT session_id FROM sessions WHERE run_id = ? AND role_id = ?`, runID, roleID,
+	).Scan(&sid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE run_id = ? AND role_id = ?`, runID, roleID); err != nil {
+		return err
+	}
+	if err := appendEventMapTx(ctx, tx, &runID, EventSessionCleared, map[string]any{
+		"run_id": runID, "role_id": roleID, "session_id": sid,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ForkSession asks the next invocation of a role to branch its session:
+// the agent resumes the conversation under a new session id and the
+// current one is left as it is.
+func (s *Store) ForkSession(ctx context.Context, runID, roleID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sid string
+	if err := tx.QueryRowContext(ctx,
+		`SELECT session_id FROM sessions WHERE run_id = ? AND role_id = ?`, runID, roleID,
+	).Scan(&sid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE sessions SET fork_pending = 1, updated_at = ? WHERE run_id = ? AND role_id = ?`,
+		timeString(nowUTC()), runID, roleID,
+	); err != nil {
+		return err
+	}
+	if err := appendEventMapTx(ctx, tx, &runID, EventSessionForked, map[string]any{
+		"run_id": runID, "role_id": roleID, "session_id": sid,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SessionStore adapts the sessions table to agent.SessionStore (and its
+// optional fork extension) for keys built with SessionKey. That interface
+// has no error results: a failed read reports no session, so the agent
+// starts a fresh conversation, and a failed write is dropped.
+type SessionStore struct {
+	store *Store
+}
+
+// Sessions returns the store's sessions table as an agent session store.
+func (s *Store) Sessions() SessionStore {
+	return SessionStore{store: s}
+}
+
+func (ss SessionStore) Get(key string) (string, bool) {
+	se, ok := ss.lookup(key)
+	if !ok {
+		return "", false
+	}
+	return se.SessionID, true
+}
+
+func (ss SessionStore) Put(key, sessionID string) {
+	runID, roleID, ok := splitSessionKey(key)
+	if !ok {
+		return
+	}
+	_ = ss.store.PutSession(context.Background(), runID, roleID, sessionID)
+}
+
+// ForkPending reports whether ForkSession was requested for key and not yet
+// consumed by a Put.
+func (ss SessionStore) ForkPending(key string) bool {
+	se, ok := ss.lookup(key)
+	return ok && se.ForkPending
+}
+
+func (ss SessionStore) lookup(key string) (*Session, bool) {
+	runID, roleID, ok := splitSessionKey(key)
+	if !ok {
+		return nil, false
+	}
+	se, err := ss.store.GetSession(context.Background(), runID, roleID)
+	if err != nil {
+		return nil, false
+	}
+	return se, true
+}
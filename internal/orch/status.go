@@ -174,16 +174,24 @@ func (s LaunchIntentStatus) Valid() bool {
 
 // Event types are open-ended; these constants cover the MVP transitions.
 const (
-	EventProjectUpserted = "project.upserted"
-	EventRunCreated      = "run.created"
-	EventRunTransition   = "run.transition"
-	EventRunRetryRequest = "run.retry_requested"
-	EventStepCreated     = "step.created"
-	EventStepTransition  = "step.transition"
-	EventExecCreated     = "execution.created"
-	EventExecTransition  = "execution.transition"
-	EventHumanRequested  = "human.input_requested"
-	EventHumanAnswered   = "human.input_answered"
-	EventIntentCreated   = "launch_intent.created"
-	EventIntentResolved  = "launch_intent.resolved"
+	EventProjectUpserted   = "project.upserted"
+	EventRunCreated        = "run.created"
+	EventRunTransition     = "run.transition"
+	EventRunRetryRequest   = "run.retry_requested"
+	EventRunBudgetExceeded = "run.budget_exceeded"
+	EventStepCreated       = "step.created"
+	EventStepTransition    = "step.transition"
+	EventExecCreated       = "execution.created"
+	EventExecTransition    = "execution.transition"
+	EventExecDetached      = "execution.detached"
+	EventExecUsage         = "execution.usage"
+	EventExecStopFailed    = "execution.stop_failed"
+	EventHumanRequested    = "human.input_requested"
+	EventHumanAnswered     = "human.input_answered"
+	EventIntentCreated     = "launch_intent.created"
+	EventIntentResolved    = "launch_intent.resolved"
+	EventSessionCleared    = "session.cleared"
+	EventSessionForked     = "session.fork_requested"
+	EventBeadsSynced       = "beads.synced"
+	EventBeadsSyncFailed   = "beads.sync_failed"
 )
@@ -255,3 +255,45 @@ func (s *Store) CompleteStepAttempt(ctx context.Context, id, result string) erro
 	}
 	return tx.Commit()
 }
+
+// ListStepAttemptsByRun returns every step attempt recorded for a run,
+// oldest-first. Attempts of the same step sort by attempt number so a
+// revise/review loop reads in the order it actually ran.
+func (s *Store) ListStepAttemptsByRun(ctx context.Context, runID string) ([]StepAttempt, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, run_id, step_id, attempt, status, inputs, result, error,
+		        created_at, updated_at, started_at, completed_at
+		 FROM step_attempts WHERE run_id = ? ORDER BY created_at, attempt, id`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []StepAttempt
+	for rows.Next() {
+		var sa StepAttempt
+		var status, created, updated string
+		var result, errStr, started, completed sql.NullString
+		if err := rows.Scan(&sa.ID, &sa.RunID, &sa.StepID, &sa.Attempt, &status, &sa.Inputs,
+			&result, &errStr, &created, &updated, &started, &completed); err != nil {
+			return nil, err
+		}
+		sa.Status = StepAttemptStatus(status)
+		sa.Result = strPtr(result)
+		sa.Error = strPtr(errStr)
+		if sa.CreatedAt, err = parseTime(created); err != nil {
+			return nil, err
+		}
+		if sa.UpdatedAt, err = parseTime(updated); err != nil {
+			return nil, err
+		}
+		if sa.StartedAt, err = timePtr(started); err != nil {
+			return nil, err
+		}
+		if sa.CompletedAt, err = timePtr(completed); err != nil {
+			return nil, err
+		}
+		out = append(out, sa)
+	}
+	return out, rows.Err()
+}
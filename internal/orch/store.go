@@ -31,7 +31,8 @@ var (
 // concurrent writers serialize cleanly and read-modify-write transitions are
 // safe.
 type Store struct {
-	db *sql.DB
+	db   *sql.DB
+	path string
 }
 
 // Open opens (or creates) the SQLite database at path and applies migrations.
@@ -57,7 +58,7 @@ func Open(ctx context.Context, path string) (*Store, error) {
 		return nil, err
 	}
 
-	s := &Store{db: db}
+	s := &Store{db: db, path: abs}
 	if err := s.Migrate(ctx); err != nil {
 		db.Close()
 		return nil, err
@@ -65,11 +66,26 @@ func Open(ctx context.Context, path string) (*Store, error) {
 	return s, nil
 }
 
+// Path returns the absolute filesystem path of the database file.
+func (s *Store) Path() string {
+	return s.path
+}
+
 // Close closes the underlying database.
 func (s *Store) Close() error {
 	return s.db.Close()
 }
 
+// SchemaVersion returns the highest applied migration version, or 0 on a
+// database that has not been migrated.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	var v sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&v); err != nil {
+		return 0, err
+	}
+	return int(v.Int64), nil
+}
+
 // Migrate applies any pending migrations in order and verifies the checksum of
 // already-applied migrations.
 func (s *Store) Migrate(ctx context.Context) error {
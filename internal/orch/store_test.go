@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
 )
 
 func newTestStore(t *testing.T) *Store {
@@ -45,6 +47,37 @@ func TestMigrateIdempotent(t *testing.T) {
 	}
 }
 
+func TestSchemaVersion(t *testing.T) {
+	s := newTestStore(t)
+	v, err := s.SchemaVersion(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := migrations[len(migrations)-1].version; v != want {
+		t.Fatalf("SchemaVersion = %d, want %d", v, want)
+	}
+}
+
+func TestCountRunsByStatus(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	newRun(t, s, p.ID, "task-1")
+	newRun(t, s, p.ID, "task-2")
+	r := newRun(t, s, p.ID, "task-3")
+	if err := s.TransitionRun(ctx, r.ID, RunRunning); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.CountRunsByStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[RunQueued] != 2 || got[RunRunning] != 1 || len(got) != 2 {
+		t.Fatalf("unexpected counts: %v", got)
+	}
+}
+
 func TestProjectCreateGetUpdate(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -429,6 +462,7 @@ func TestExecutionLifecycle(t *testing.T) {
 		Kind:          KindAgent,
 		Status:        ExecQueued,
 		PromptRef:     "runs/" + r.ID + "/exec/prompt.md",
+		TranscriptRef: "runs/" + r.ID + "/exec/transcript.jsonl",
 		PromptHash:    "sha256:abc",
 	}
 	if err := s.CreateExecution(ctx, e); err != nil {
@@ -448,8 +482,8 @@ func TestExecutionLifecycle(t *testing.T) {
 	if got.Status != ExecCompleted || got.CompletedAt == nil {
 		t.Fatalf("unexpected execution: %+v", got)
 	}
-	if got.PromptRef != e.PromptRef || got.PromptHash != e.PromptHash {
-		t.Fatalf("prompt ref/hash not round-tripped: %+v", got)
+	if got.PromptRef != e.PromptRef || got.PromptHash != e.PromptHash || got.TranscriptRef != e.TranscriptRef {
+		t.Fatalf("prompt/transcript refs not round-tripped: %+v", got)
 	}
 
 	if err := s.TransitionExecution(ctx, e.ID, ExecRunning); !errors.Is(err, ErrInvalidTransition) {
@@ -479,6 +513,108 @@ func TestArtifactLifecycle(t *testing.T) {
 	}
 }
 
+func TestListStepAttemptsByRun(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	r := newRun(t, s, p.ID, "task-1")
+	other := newRun(t, s, p.ID, "task-2")
+
+	if _, err := s.StartStepAttempt(ctx, r.ID, "review", "{}"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartStepAttempt(ctx, r.ID, "revise", "{}"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartStepAttempt(ctx, r.ID, "revise", "{}"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StartStepAttempt(ctx, other.ID, "review", "{}"); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := s.ListStepAttemptsByRun(ctx, r.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(rows))
+	}
+	if rows[0].StepID != "review" || rows[1].StepID != "revise" || rows[1].Attempt != 1 || rows[2].Attempt != 2 {
+		t.Fatalf("unexpected order: %+v", rows)
+	}
+}
+
+func TestRunTimeline(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	r := newRun(t, s, p.ID, "task-1")
+
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	at := func(sec int) *time.Time {
+		ts := base.Add(time.Duration(sec) * time.Second)
+		return &ts
+	}
+	failed := &StepAttempt{
+		RunID: r.ID, StepID: "revise", Attempt: 1, Status: StepFailed,
+		Error:     strPtrTo("tests failed"),
+		CreatedAt: *at(0), StartedAt: at(1), CompletedAt: at(31),
+	}
+	if err := s.CreateStepAttempt(ctx, failed); err != nil {
+		t.Fatal(err)
+	}
+	e := &Execution{
+		RunID: r.ID, StepAttemptID: failed.ID, Kind: KindAgent, Status: ExecFailed,
+		CreatedAt: *at(1), StartedAt: at(2), CompletedAt: at(30),
+	}
+	if err := s.CreateExecution(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateArtifact(ctx, &Artifact{ExecutionID: e.ID, Name: "report.md", Path: "a/report.md", Hash: "h1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := s.RunTimeline(ctx, r.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attempt, exec, artifact *TimelineEntry
+	events := 0
+	for i := range entries {
+		switch entries[i].Kind {
+		case TimelineStepAttempt:
+			attempt = &entries[i]
+		case TimelineExecution:
+			exec = &entries[i]
+		case TimelineArtifact:
+			artifact = &entries[i]
+		case TimelineEvent:
+			events++
+		}
+		if i > 0 && entries[i].At.Before(entries[i-1].At) {
+			t.Fatalf("timeline not ordered at %d: %+v", i, entries)
+		}
+	}
+	if attempt == nil || attempt.Duration != 30*time.Second || attempt.Error == nil || *attempt.Error != "tests failed" {
+		t.Fatalf("unexpected attempt entry: %+v", attempt)
+	}
+	if exec == nil || exec.StepID != "revise" || exec.Attempt != 1 || exec.Duration != 28*time.Second {
+		t.Fatalf("unexpected execution entry: %+v", exec)
+	}
+	if artifact == nil || artifact.StepAttemptID != failed.ID || artifact.Summary != "report.md" {
+		t.Fatalf("unexpected artifact entry: %+v", artifact)
+	}
+	if events == 0 {
+		t.Fatal("expected run events in timeline")
+	}
+
+	if _, err := s.RunTimeline(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
 func TestLaunchIntentResolve(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -671,3 +807,258 @@ func TestEnsureProjectNoTOCTOUBetweenConcurrentEnsure(t *testing.T) {
 		t.Fatalf("projects with id=%q: %d, want 1", id, count)
 	}
 }
+
+func TestSessionStoreForkAndClear(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	r := newRun(t, s, p.ID, "task-1")
+	ss := s.Sessions()
+	key := SessionKey(r.ID, "coder")
+
+	if _, ok := ss.Get(key); ok {
+		t.Fatal("expected no session before Put")
+	}
+	if err := s.ForkSession(ctx, r.ID, "coder"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ForkSession without session: %v", err)
+	}
+	ss.Put(key, "sid-1")
+	if sid, ok := ss.Get(key); !ok || sid != "sid-1" {
+		t.Fatalf("Get = %q, %v", sid, ok)
+	}
+
+	// A fork stays pending until the agent reports the new session id.
+	if err := s.ForkSession(ctx, r.ID, "coder"); err != nil {
+		t.Fatal(err)
+	}
+	if !ss.ForkPending(key) {
+		t.Fatal("expected fork pending")
+	}
+	ss.Put(key, "sid-2")
+	got, err := s.GetSession(ctx, r.ID, "coder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SessionID != "sid-2" || got.ForkPending || got.ForkedFrom == nil || *got.ForkedFrom != "sid-1" {
+		t.Fatalf("session after fork = %+v", got)
+	}
+
+	if err := s.ClearSession(ctx, r.ID, "coder"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ss.Get(key); ok {
+		t.Fatal("expected no session after ClearSession")
+	}
+	if err := s.ClearSession(ctx, r.ID, "coder"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("second ClearSession: %v", err)
+	}
+
+	events, err := s.ListEventsByRun(ctx, r.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	if fmt.Sprint(types) != fmt.Sprint([]string{EventRunCreated, EventSessionForked, EventSessionCleared}) {
+		t.Fatalf("events = %v", types)
+	}
+}
+
+// TestRecordExecutionUsageBudget records usage against a run budget: the
+// run total is the sum over executions, a repeated report replaces rather
+// than adds, and crossing the budget moves the run to needs_attention once.
+func TestRecordExecutionUsageBudget(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	r := &Run{ProjectID: p.ID, TaskID: "task-1", Status: RunQueued, Budget: Budget{MaxTokens: 1000, MaxCostUSD: 1}}
+	if err := s.CreateRun(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.TransitionRun(ctx, r.ID, RunRunning); err != nil {
+		t.Fatal(err)
+	}
+	sa, _ := s.StartStepAttempt(ctx, r.ID, "plan", "{}")
+	newExec := func() *Execution {
+		e := &Execution{RunID: r.ID, StepAttemptID: sa.ID, Kind: KindAgent, Status: ExecRunning}
+		if err := s.CreateExecution(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	e1, e2 := newExec(), newExec()
+
+	for i := 0; i < 2; i++ {
+		exceeded, err := s.RecordExecutionUsage(ctx, e1.ID, Usage{InputTokens: 300, OutputTokens: 100, CostUSD: 0.25})
+		if err != nil || exceeded {
+			t.Fatalf("RecordExecutionUsage #%d = %v, %v", i, exceeded, err)
+		}
+	}
+	got, _ := s.GetRun(ctx, r.ID)
+	if got.Budget != r.Budget || got.Usage != (Usage{InputTokens: 300, OutputTokens: 100, CostUSD: 0.25}) || got.Status != RunRunning {
+		t.Fatalf("run after first execution: %+v", got)
+	}
+	if e, _ := s.GetExecution(ctx, e1.ID); e.Usage.Tokens() != 400 {
+		t.Fatalf("execution usage = %+v", e.Usage)
+	}
+
+	exceeded, err := s.RecordExecutionUsage(ctx, e2.ID, Usage{InputTokens: 500, OutputTokens: 200, CostUSD: 0.5})
+	if err != nil || !exceeded {
+		t.Fatalf("RecordExecutionUsage over budget = %v, %v", exceeded, err)
+	}
+	got, _ = s.GetRun(ctx, r.ID)
+	if got.Status != RunNeedsAttention || got.NeedsAttentionReason == nil || *got.NeedsAttentionReason != "token budget exceeded: 1100 of 1000" {
+		t.Fatalf("run over budget: %+v", got)
+	}
+	if exceeded, _ := s.RecordExecutionUsage(ctx, e2.ID, Usage{InputTokens: 600}); exceeded {
+		t.Fatal("a run already in needs_attention should not be flagged again")
+	}
+	events, _ := s.ListEventsByRun(ctx, r.ID)
+	n := 0
+	for _, ev := range events {
+		if ev.Type == EventRunBudgetExceeded {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Fatalf("%d budget events, want 1", n)
+	}
+
+	if _, err := s.RecordExecutionUsage(ctx, "missing", Usage{}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("unknown execution = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCancelRunPropagates(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	r := &Run{ProjectID: p.ID, TaskID: "task-1", Status: RunQueued}
+	if err := s.CreateRun(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.TransitionRun(ctx, r.ID, RunRunning); err != nil {
+		t.Fatal(err)
+	}
+	done, _ := s.StartStepAttempt(ctx, r.ID, "plan", "{}")
+	if err := s.TransitionStepAttempt(ctx, done.ID, StepRunning); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CompleteStepAttempt(ctx, done.ID, "{}"); err != nil {
+		t.Fatal(err)
+	}
+	sa, _ := s.StartStepAttempt(ctx, r.ID, "implement", "{}")
+	finished := &Execution{RunID: r.ID, StepAttemptID: done.ID, Kind: KindAgent, Status: ExecCompleted}
+	running := &Execution{RunID: r.ID, StepAttemptID: sa.ID, Kind: KindAgent, Status: ExecRunning}
+	for _, e := range []*Execution{finished, running} {
+		if err := s.CreateExecution(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	active, err := s.CancelRun(ctx, r.ID)
+	if err != nil {
+		t.Fatalf("CancelRun: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != running.ID || active[0].Status != ExecRunning {
+		t.Fatalf("active executions = %+v, want only the running one", active)
+	}
+	if got, _ := s.GetRun(ctx, r.ID); got.Status != RunCancelled || got.CompletedAt == nil {
+		t.Fatalf("run = %+v, want cancelled", got)
+	}
+	if got, _ := s.GetStepAttempt(ctx, sa.ID); got.Status != StepCancelled {
+		t.Fatalf("attempt status = %s, want cancelled", got.Status)
+	}
+	if got, _ := s.GetStepAttempt(ctx, done.ID); got.Status != StepCompleted {
+		t.Fatalf("completed attempt status = %s, want it untouched", got.Status)
+	}
+	if got, _ := s.GetExecution(ctx, running.ID); got.Status != ExecCancelled {
+		t.Fatalf("execution status = %s, want cancelled", got.Status)
+	}
+	if got, _ := s.GetExecution(ctx, finished.ID); got.Status != ExecCompleted {
+		t.Fatalf("finished execution status = %s, want it untouched", got.Status)
+	}
+
+	events, _ := s.ListEventsByRun(ctx, r.ID)
+	var order []string
+	for _, ev := range events[len(events)-3:] {
+		order = append(order, ev.Type)
+	}
+	if want := []string{EventRunTransition, EventStepTransition, EventExecTransition}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("cancel events = %v, want %v", order, want)
+	}
+
+	if _, err := s.CancelRun(ctx, r.ID); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("second CancelRun = %v, want ErrInvalidTransition", err)
+	}
+	if _, err := s.CancelRun(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("unknown run = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBeadsSyncOutbox(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	p := newProject(t, s, "p")
+	r := &Run{ProjectID: p.ID, TaskID: "task-1", Status: RunQueued}
+	if err := s.CreateRun(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := s.PendingBeadsSyncs(ctx, time.Now())
+	if err != nil || len(pending) != 1 || pending[0].RunID != r.ID || pending[0].Generation != 1 {
+		t.Fatalf("pending after create = %+v, %v", pending, err)
+	}
+	stale := pending[0]
+
+	// A transition during the sync keeps the run pending.
+	if err := s.TransitionRun(ctx, r.ID, RunRunning); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CompleteBeadsSync(ctx, stale, map[string]any{"run_id": r.ID}); err != nil {
+		t.Fatal(err)
+	}
+	pending, _ = s.PendingBeadsSyncs(ctx, time.Now())
+	if len(pending) != 1 || pending[0].Generation != 2 {
+		t.Fatalf("pending after stale completion = %+v", pending)
+	}
+
+	// A failure postpones the retry.
+	retryAt := time.Now().Add(time.Minute)
+	if err := s.FailBeadsSync(ctx, pending[0], errors.New("bd down"), retryAt); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := s.PendingBeadsSyncs(ctx, time.Now()); len(pending) != 0 {
+		t.Fatalf("pending before retry = %+v", pending)
+	}
+	pending, _ = s.PendingBeadsSyncs(ctx, retryAt)
+	if len(pending) != 1 || pending[0].Attempts != 1 {
+		t.Fatalf("pending at retry = %+v", pending)
+	}
+	if err := s.CompleteBeadsSync(ctx, pending[0], map[string]any{"run_id": r.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := s.PendingBeadsSyncs(ctx, retryAt); len(pending) != 0 {
+		t.Fatalf("pending after sync = %+v", pending)
+	}
+
+	if _, err := s.CancelRun(ctx, r.ID); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := s.PendingBeadsSyncs(ctx, time.Now()); len(pending) != 1 || pending[0].Attempts != 0 {
+		t.Fatalf("pending after cancel = %+v", pending)
+	}
+
+	events, _ := s.ListEventsByRun(ctx, r.ID)
+	var types []string
+	for _, e := range events {
+		if e.Type == EventBeadsSynced || e.Type == EventBeadsSyncFailed {
+			types = append(types, e.Type)
+		}
+	}
+	if want := []string{EventBeadsSynced, EventBeadsSyncFailed, EventBeadsSynced}; !reflect.DeepEqual(types, want) {
+		t.Fatalf("sync events = %v, want %v", types, want)
+	}
+}
@@ -0,0 +1,224 @@
+package orch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TimelineKind discriminates which durable row a TimelineEntry was built from.
+type TimelineKind string
+
+const (
+	TimelineStepAttempt TimelineKind = "step_attempt"
+	TimelineExecution   TimelineKind = "execution"
+	TimelineHumanInput  TimelineKind = "human_input"
+	TimelineArtifact    TimelineKind = "artifact"
+	TimelineEvent       TimelineKind = "event"
+)
+
+// timelineKindRank breaks ties between entries that share a timestamp so the
+// parent row (the attempt) always precedes the rows it owns.
+var timelineKindRank = map[TimelineKind]int{
+	TimelineStepAttempt: 0,
+	TimelineExecution:   1,
+	TimelineHumanInput:  2,
+	TimelineArtifact:    3,
+	TimelineEvent:       4,
+}
+
+// TimelineEntry is one row of a run's history. It is a read-only projection
+// that joins step attempts, executions, human inputs, artifacts and events on
+// a single time axis; relational state stays authoritative.
+//
+// At is when the row became active (started_at when known, otherwise
+// created_at). EndedAt is set once the row reached a terminal state (or was
+// answered, for human inputs); Duration is EndedAt-At and stays zero while
+// the row is still open.
+type TimelineEntry struct {
+	Kind          TimelineKind  `json:"kind"`
+	ID            string        `json:"id"`
+	At            time.Time     `json:"at"`
+	EndedAt       *time.Time    `json:"ended_at"`
+	Duration      time.Duration `json:"duration"`
+	StepAttemptID string        `json:"step_attempt_id"`
+	ExecutionID   string        `json:"execution_id"`
+	StepID        string        `json:"step_id"`
+	Attempt       int           `json:"attempt"`
+	Status        string        `json:"status"`
+	Summary       string        `json:"summary"`
+	Detail        string        `json:"detail"`
+	Error         *string       `json:"error"`
+	Seq           int64         `json:"seq"`
+}
+
+// RunTimeline returns the ordered history of a run. Entries are sorted by At,
+// then by kind (attempt before execution before human input before artifact
+// before event) and finally by id/seq so the order is stable across calls.
+// Executions, human inputs and artifacts carry the step id and attempt
+// number of the attempt that owns them.
+func (s *Store) RunTimeline(ctx context.Context, runID string) ([]TimelineEntry, error) {
+	if _, err := s.GetRun(ctx, runID); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.ListStepAttemptsByRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := s.ListExecutionsByRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := s.ListHumanInputsByRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := s.ListArtifactsByRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.ListEventsByRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	attemptByID := make(map[string]*StepAttempt, len(attempts))
+	for i := range attempts {
+		attemptByID[attempts[i].ID] = &attempts[i]
+	}
+	execByID := make(map[string]*Execution, len(executions))
+	for i := range executions {
+		execByID[executions[i].ID] = &executions[i]
+	}
+
+	out := make([]TimelineEntry, 0, len(attempts)+len(executions)+len(inputs)+len(artifacts)+len(events))
+
+	for _, sa := range attempts {
+		e := TimelineEntry{
+			Kind:          TimelineStepAttempt,
+			ID:            sa.ID,
+			StepAttemptID: sa.ID,
+			StepID:        sa.StepID,
+			Attempt:       sa.Attempt,
+			Status:        string(sa.Status),
+			Summary:       fmt.Sprintf("%s attempt %d", sa.StepID, sa.Attempt),
+			Error:         sa.Error,
+		}
+		if sa.Result != nil {
+			e.Detail = *sa.Result
+		}
+		setTimelineSpan(&e, sa.CreatedAt, sa.StartedAt, sa.CompletedAt)
+		out = append(out, e)
+	}
+
+	for _, x := range executions {
+		e := TimelineEntry{
+			Kind:          TimelineExecution,
+			ID:            x.ID,
+			StepAttemptID: x.StepAttemptID,
+			ExecutionID:   x.ID,
+			Status:        string(x.Status),
+			Summary:       string(x.Kind) + " execution",
+			Error:         x.Error,
+		}
+		if x.ResultCommit != nil {
+			e.Detail = "commit " + *x.ResultCommit
+		}
+		attachAttempt(&e, attemptByID)
+		setTimelineSpan(&e, x.CreatedAt, x.StartedAt, x.CompletedAt)
+		out = append(out, e)
+	}
+
+	for _, h := range inputs {
+		e := TimelineEntry{
+			Kind:          TimelineHumanInput,
+			ID:            h.ID,
+			StepAttemptID: h.StepAttemptID,
+			Status:        string(h.Status),
+			Summary:       h.Prompt,
+			At:            h.CreatedAt,
+		}
+		if h.ExecutionID != nil {
+			e.ExecutionID = *h.ExecutionID
+		}
+		if h.Response != nil {
+			e.Detail = *h.Response
+		}
+		if h.AnsweredAt != nil {
+			ended := *h.AnsweredAt
+			e.EndedAt = &ended
+			e.Duration = ended.Sub(h.CreatedAt)
+		}
+		attachAttempt(&e, attemptByID)
+		out = append(out, e)
+	}
+
+	for _, a := range artifacts {
+		e := TimelineEntry{
+			Kind:        TimelineArtifact,
+			ID:          a.ID,
+			ExecutionID: a.ExecutionID,
+			Summary:     a.Name,
+			Detail:      a.Hash,
+			At:          a.CreatedAt,
+		}
+		if x, ok := execByID[a.ExecutionID]; ok {
+			e.StepAttemptID = x.StepAttemptID
+			attachAttempt(&e, attemptByID)
+		}
+		out = append(out, e)
+	}
+
+	for _, ev := range events {
+		out = append(out, TimelineEntry{
+			Kind:    TimelineEvent,
+			ID:      strconv.FormatInt(ev.ID, 10),
+			At:      ev.CreatedAt,
+			Summary: ev.Type,
+			Detail:  ev.Payload,
+			Seq:     ev.Seq,
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if !a.At.Equal(b.At) {
+			return a.At.Before(b.At)
+		}
+		if timelineKindRank[a.Kind] != timelineKindRank[b.Kind] {
+			return timelineKindRank[a.Kind] < timelineKindRank[b.Kind]
+		}
+		if a.Seq != b.Seq {
+			return a.Seq < b.Seq
+		}
+		return a.ID < b.ID
+	})
+	return out, nil
+}
+
+// setTimelineSpan fills At/EndedAt/Duration from a row's lifecycle
+// timestamps. A row that never started is measured from its creation.
+func setTimelineSpan(e *TimelineEntry, created time.Time, started, completed *time.Time) {
+	e.At = created
+	if started != nil {
+		e.At = *started
+	}
+	if completed != nil {
+		ended := *completed
+		e.EndedAt = &ended
+		if d := ended.Sub(e.At); d > 0 {
+			e.Duration = d
+		}
+	}
+}
+
+// attachAttempt copies the owning attempt's step id and number onto e.
+func attachAttempt(e *TimelineEntry, attempts map[string]*StepAttempt) {
+	if sa, ok := attempts[e.StepAttemptID]; ok {
+		e.StepID = sa.StepID
+		e.Attempt = sa.Attempt
+	}
+}
@@ -30,6 +30,11 @@ func (g *GitWorktree) DiffEmpty(ctx context.Context, workdir string) (bool, erro
 	return true, ErrCheckpointNotGitRepo
 }
 
+// RefState always returns ErrCheckpointNotGitRepo.
+func (g *GitWorktree) RefState(ctx context.Context, workdir string) (string, error) {
+	return "", ErrCheckpointNotGitRepo
+}
+
 // Commit always returns ErrCheckpointNotGitRepo.
 func (g *GitWorktree) Commit(ctx context.Context, workdir, subject, body string) (string, error) {
 	return "", ErrCheckpointNotGitRepo
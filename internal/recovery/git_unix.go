@@ -83,6 +83,25 @@ func (g *GitWorktree) DiffEmpty(ctx context.Context, workdir string) (bool, erro
 	return unstagedEmpty && stagedEmpty, nil
 }
 
+// RefState lists HEAD (the branch it names, if any, and the commit) and
+// every ref with its object. An empty repository has just the symbolic
+// HEAD.
+func (g *GitWorktree) RefState(ctx context.Context, workdir string) (string, error) {
+	if workdir == "" {
+		return "", ErrCheckpointNotGitRepo
+	}
+	head, err := g.ResolveHead(ctx, workdir)
+	if err != nil {
+		return "", err
+	}
+	branch, _, _ := runGitRaw(ctx, workdir, "symbolic-ref", "-q", "HEAD")
+	refs, stderr, err := runGitRaw(ctx, workdir, "for-each-ref", "--format=%(objectname) %(refname)")
+	if err != nil {
+		return "", fmt.Errorf("git for-each-ref: %s", strings.TrimSpace(stderr))
+	}
+	return "HEAD " + strings.TrimSpace(branch) + " " + head + "\n" + refs, nil
+}
+
 // diffExitClean returns true when `git diff --exit-code` exits 0 (no diff).
 // `git diff --exit-code` always exits 1 when there is a real diff and 128
 // for actual git failures (missing object, bad reference, etc.). We must
@@ -78,6 +78,10 @@ type Worktree interface {
 	// changes) is empty. It does not error on an empty repo.
 	DiffEmpty(ctx context.Context, workdir string) (bool, error)
 
+	// RefState fingerprints HEAD and every ref of the repository, so a
+	// caller can tell whether branches, tags or commits were added.
+	RefState(ctx context.Context, workdir string) (string, error)
+
 	// Commit writes a checkpoint commit with the given subject/body and
 	// returns the resulting commit SHA. The commit is empty when there are
 	// no changes (callers should consult DiffEmpty first to decide whether
@@ -85,6 +89,10 @@ type Worktree interface {
 	Commit(ctx context.Context, workdir, subject, body string) (string, error)
 }
 
+// A Worktree is what agent.Sandbox uses to check a read-only role left
+// the worktree alone.
+var _ agent.DiffChecker = Worktree(nil)
+
 // Inspect abstracts the runtime inspection/recovery probe. The agent.Runtime
 // is the production implementation; tests provide a fake.
 type Inspect interface {
@@ -51,6 +51,10 @@ func (f *fakeWorktree) DiffEmpty(ctx context.Context, w string) (bool, error) {
 	return true, nil
 }
 
+func (f *fakeWorktree) RefState(context.Context, string) (string, error) {
+	return "", nil
+}
+
 func (f *fakeWorktree) Commit(ctx context.Context, w, subject, body string) (string, error) {
 	if f.commit != nil {
 		return f.commit(ctx, w, subject, body)
@@ -293,3 +297,20 @@ func TestGitWorktree_RealCheckpoint(t *testing.T) {
 		t.Fatalf("unexpected checkpoint: %+v (before=%s)", cp, before)
 	}
 }
+
+func TestGitWorktree_RefStateSeesNewBranch(t *testing.T) {
+	dir := t.TempDir()
+	runCmd(t, dir, "git", "init", "-q")
+	runCmd(t, dir, "git", "config", "user.email", "test@example.com")
+	runCmd(t, dir, "git", "config", "user.name", "test")
+	runCmd(t, dir, "git", "commit", "--allow-empty", "-q", "-m", "init")
+	g := NewGitWorktree()
+	before, err := g.RefState(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("RefState: %v", err)
+	}
+	runCmd(t, dir, "git", "branch", "sneaky")
+	if after, err := g.RefState(context.Background(), dir); err != nil || after == before {
+		t.Fatalf("RefState after a new branch = %q, %v; want it changed from %q", after, err, before)
+	}
+}
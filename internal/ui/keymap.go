@@ -14,12 +14,18 @@ func DefaultKeymap() Keymap {
 			"h/l, ←/→: switch column",
 			"0 / G: first/last issue in column",
 			"Mouse left-click: select issue (ghost row => focus parent, board only)",
-			"Enter/Space: focus details panel",
+			"Mouse drag: card to column sets status; Ctrl onto card sets parent, Alt adds blocker",
+			"Mouse wheel: scroll column under pointer",
+			"Enter: focus details panel",
+			"Space: mark/unmark issue for bulk actions",
+			"V: mark a range (j/k extend, V keep, Esc cancel); Esc clears marks",
 			"details: d open description, n open notes, Ctrl+X ext edit, Esc close",
 			"description/notes view: j/k or ↑/↓ scroll, Ctrl+X ext edit, Esc close",
 			"/: focus search",
 			"f: focus search",
 			"Ctrl+F: expand filters (in search)",
+			"Tab / Ctrl+S: complete query term / save search as a view (in search)",
+			"v / 1-9: saved views picker / apply view N",
 			"Ctrl+C / c: clear search/filters",
 			"n: create issue",
 			"N: create issue with parent = selected issue (closed => confirm)",
@@ -30,6 +36,10 @@ func DefaultKeymap() Keymap {
 			"x: close/reopen",
 			"p/P: cycle priority forward/back",
 			"s/S: cycle status forward/back",
+			"a / L: set assignee / labels",
+			"marked issues: p/P, s/S, a, L, x, g p and R apply to all of them",
+			"u / Ctrl+R: undo/redo last change",
+			"U: change history",
 			"z: toggle hide/show children",
 			"y: copy selected issue id",
 			"r: refresh",
@@ -47,6 +57,8 @@ func DefaultKeymap() Keymap {
 			"g d: show dependencies",
 			"g D: toggle dim override (auto → bright → dim → auto)",
 			"g o: toggle sort mode",
+			"g w: cycle swimlanes (off → assignee → epic → label)",
+			"g c: edit board layout in $EDITOR",
 		},
 		Mux: []string{
 			"t s: send selected issue to attached herdr target",
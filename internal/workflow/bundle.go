@@ -8,14 +8,23 @@ import (
 )
 
 // Bundle is the fully resolved dependency closure of a workflow at Run start:
-// the workflow, its raw source YAML, the role contracts it references, and the
-// raw contents of referenced prompt/schema/instruction files. Files keys are
+// the workflow, its raw source YAML, the role contracts it references, the
+// runner definitions those roles select, and the raw contents of referenced
+// prompt/schema/instruction files. Files keys are
 // namespaced logical refs (roles/<id>/prompt, roles/<id>/schema, etc.).
 type Bundle struct {
 	Spec  WorkflowSpec
 	Roles map[string]RoleContract
 	Files map[string]string
 
+	// Instructions names the project instruction files snapshotted under
+	// Files (instructions/<name>), in discovery order. See AddInstructions.
+	Instructions []string
+
+	// Runners holds the declarative runner definitions referenced by Roles.
+	// Roles on the built-in DefaultRunner need no entry.
+	Runners map[string]RunnerSpec
+
 	// WorkflowSource is the immutable launch-time source YAML that produced
 	// Spec. It is preserved for audit/debugging, not for re-parsing.
 	WorkflowSource string
@@ -57,6 +66,22 @@ func (b *Bundle) Validate() error {
 		}
 	}
 
+	for id, role := range b.Roles {
+		runner, ok := b.Runners[role.RunnerID()]
+		if !ok {
+			if role.RunnerID() == DefaultRunner {
+				continue
+			}
+			return fmt.Errorf("workflow: role %q: runner %q not found", id, role.RunnerID())
+		}
+		if err := runner.Validate(); err != nil {
+			return fmt.Errorf("workflow: role %q: %w", id, err)
+		}
+		if runner.ID != role.RunnerID() {
+			return fmt.Errorf("workflow: role %q: runner %q declares id %q", id, role.RunnerID(), runner.ID)
+		}
+	}
+
 	for i := range b.Spec.Steps {
 		st := &b.Spec.Steps[i]
 		if st.Type != StepAgent {
@@ -111,6 +136,11 @@ func (b *Bundle) Validate() error {
 			}
 		}
 	}
+	for _, name := range b.Instructions {
+		if _, ok := b.Files[instructionKey(name)]; !ok {
+			return fmt.Errorf("workflow: missing instruction dependency %q", instructionKey(name))
+		}
+	}
 	return nil
 }
 
@@ -161,12 +191,16 @@ func BuildSnapshot(b Bundle) (Snapshot, error) {
 		Workflow       WorkflowSpec            `json:"workflow"`
 		WorkflowSource string                  `json:"workflow_source"`
 		Roles          map[string]RoleContract `json:"roles"`
+		Runners        map[string]RunnerSpec   `json:"runners,omitempty"`
 		Files          map[string]string       `json:"files"`
+		Instructions   []string                `json:"instructions,omitempty"`
 	}{
 		Workflow:       b.Spec.forJSON(),
 		WorkflowSource: b.WorkflowSource,
 		Roles:          roles,
+		Runners:        b.Runners,
 		Files:          b.Files,
+		Instructions:   b.Instructions,
 	}
 
 	data, err := json.Marshal(payload)
@@ -177,3 +211,43 @@ func BuildSnapshot(b Bundle) (Snapshot, error) {
 	sum := sha256.Sum256([]byte(jsonStr))
 	return Snapshot{Ref: hex.EncodeToString(sum[:]), JSON: jsonStr}, nil
 }
+
+// SnapshotBudget returns the budget recorded in a snapshot's workflow, the
+// zero Budget (no limit) when it has none.
+func SnapshotBudget(snapshotJSON string) (Budget, error) {
+	var doc struct {
+		Workflow struct {
+			Budget *Budget `json:"budget"`
+		} `json:"workflow"`
+	}
+	if err := json.Unmarshal([]byte(snapshotJSON), &doc); err != nil {
+		return Budget{}, fmt.Errorf("workflow snapshot: %w", err)
+	}
+	if doc.Workflow.Budget == nil {
+		return Budget{}, nil
+	}
+	if err := doc.Workflow.Budget.Validate(); err != nil {
+		return Budget{}, fmt.Errorf("workflow snapshot: %w", err)
+	}
+	return *doc.Workflow.Budget, nil
+}
+
+// SnapshotClosesTask reports whether a run of the snapshot's workflow that
+// completes at stepID closes its task (the step is an end step with
+// close_task set).
+func SnapshotClosesTask(snapshotJSON, stepID string) (bool, error) {
+	var doc struct {
+		Workflow struct {
+			Steps []StepSpec `json:"steps"`
+		} `json:"workflow"`
+	}
+	if err := json.Unmarshal([]byte(snapshotJSON), &doc); err != nil {
+		return false, fmt.Errorf("workflow snapshot: %w", err)
+	}
+	for _, st := range doc.Workflow.Steps {
+		if st.ID == stepID {
+			return st.Type == StepEnd && st.CloseTask, nil
+		}
+	}
+	return false, nil
+}
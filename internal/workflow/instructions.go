@@ -0,0 +1,265 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Default size limits of instruction discovery.
+const (
+	DefaultInstructionFileBytes  = 64 << 10
+	DefaultInstructionTotalBytes = 256 << 10
+)
+
+// instructionTruncated ends an instruction cut at a size limit.
+const instructionTruncated = "\n[truncated]\n"
+
+// Instruction is a project instruction file snapshotted for a run. Name is
+// its slash-separated path relative to the project root.
+type Instruction struct {
+	Name    string
+	Content string
+}
+
+// InstructionDiscovery collects the project instruction files an agent is
+// given, from a project root. The order is fixed:
+//
+//  1. AGENTS.md, then CLAUDE.md, at the root;
+//  2. skills/<name>/SKILL.md for each skill, by name — the skills listed in
+//     skills-lock.json when the root has one, else every directory under
+//     skills/;
+//  3. Paths (the workflow's instructions), in declared order; a directory
+//     contributes its *.md files by name.
+//
+// A file found twice is kept at its first position. A file over
+// MaxFileBytes is truncated; once MaxTotalBytes is reached the file that
+// crosses it is truncated and later files are dropped. Zero limits mean the
+// defaults.
+type InstructionDiscovery struct {
+	Root          string
+	Paths         []string
+	MaxFileBytes  int
+	MaxTotalBytes int
+}
+
+// Discover reads the instruction files. Conventional files that do not
+// exist are skipped; a configured path that does not exist is an error.
+func (d InstructionDiscovery) Discover() ([]Instruction, error) {
+	if d.Root == "" {
+		return nil, nil
+	}
+	names := []string{"AGENTS.md", "CLAUDE.md"}
+	skills, err := d.skillNames()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range skills {
+		names = append(names, "skills/"+s+"/SKILL.md")
+	}
+	var configured []string
+	for _, p := range d.Paths {
+		files, err := d.expand(p)
+		if err != nil {
+			return nil, err
+		}
+		configured = append(configured, files...)
+	}
+
+	fileLimit := d.MaxFileBytes
+	if fileLimit <= 0 {
+		fileLimit = DefaultInstructionFileBytes
+	}
+	remaining := d.MaxTotalBytes
+	if remaining <= 0 {
+		remaining = DefaultInstructionTotalBytes
+	}
+
+	var out []Instruction
+	seen := map[string]bool{}
+	for i, name := range append(names, configured...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if remaining <= 0 {
+			break
+		}
+		data, err := os.ReadFile(filepath.Join(d.Root, filepath.FromSlash(name)))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && i < len(names) {
+				continue
+			}
+			return nil, fmt.Errorf("workflow: instructions: %w", err)
+		}
+		limit := min(fileLimit, remaining)
+		content := truncateInstruction(string(data), limit)
+		remaining -= len(content)
+		if limit < fileLimit && len(data) > limit {
+			remaining = 0
+		}
+		out = append(out, Instruction{Name: name, Content: content})
+	}
+	return out, nil
+}
+
+// skillNames returns the installed skills: the skills-lock.json entries if
+// the root has a lock file, else the directories under skills/.
+func (d InstructionDiscovery) skillNames() ([]string, error) {
+	var names []string
+	data, err := os.ReadFile(filepath.Join(d.Root, "skills-lock.json"))
+	switch {
+	case err == nil:
+		var lock struct {
+			Skills map[string]json.RawMessage `json:"skills"`
+		}
+		if err := json.Unmarshal(data, &lock); err != nil {
+			return nil, fmt.Errorf("workflow: instructions: skills-lock.json: %w", err)
+		}
+		for name := range lock.Skills {
+			if validateID(name) == nil {
+				names = append(names, name)
+			}
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		entries, err := os.ReadDir(filepath.Join(d.Root, "skills"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("workflow: instructions: %w", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				names = append(names, e.Name())
+			}
+		}
+	default:
+		return nil, fmt.Errorf("workflow: instructions: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// expand resolves a configured path to the files it names.
+func (d InstructionDiscovery) expand(p string) ([]string, error) {
+	if err := validateRelPath(p); err != nil {
+		return nil, fmt.Errorf("workflow: instructions: %w", err)
+	}
+	p = path.Clean(filepath.ToSlash(p))
+	full := filepath.Join(d.Root, filepath.FromSlash(p))
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, fmt.Errorf("workflow: instructions: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{p}, nil
+	}
+	entries, err := os.ReadDir(full)
+	if err != nil {
+		return nil, fmt.Errorf("workflow: instructions: %w", err)
+	}
+	var files []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && strings.HasSuffix(e.Name(), ".md") {
+			files = append(files, path.Join(p, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// truncateInstruction cuts content to at most limit bytes, marker included,
+// at a line boundary when there is one.
+func truncateInstruction(content string, limit int) string {
+	if len(content) <= limit {
+		return content
+	}
+	keep := max(limit-len(instructionTruncated), 0)
+	cut := content[:keep]
+	if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+		cut = cut[:i]
+	}
+	return cut + instructionTruncated
+}
+
+// instructionKey is the Bundle.Files key of an instruction file.
+func instructionKey(name string) string {
+	return "instructions/" + name
+}
+
+// AddInstructions snapshots discovered instruction files into the bundle,
+// after any already added, so they are part of its content hash.
+func (b *Bundle) AddInstructions(ins []Instruction) {
+	if b.Files == nil {
+		b.Files = map[string]string{}
+	}
+	for _, in := range ins {
+		if _, ok := b.Files[instructionKey(in.Name)]; !ok {
+			b.Instructions = append(b.Instructions, in.Name)
+		}
+		b.Files[instructionKey(in.Name)] = in.Content
+	}
+}
+
+// WantsInstruction reports whether the role receives the named instruction
+// file. Role.Instructions holds path patterns, "!"-prefixed to exclude; a
+// pattern matches a file or any directory above it, so "skills" covers
+// every skill. With no including pattern a role gets every file not
+// excluded.
+func (r RoleContract) WantsInstruction(name string) bool {
+	included, hasInclude := false, false
+	for _, p := range r.Instructions {
+		if ex, ok := strings.CutPrefix(p, "!"); ok {
+			if instructionMatch(ex, name) {
+				return false
+			}
+			continue
+		}
+		hasInclude = true
+		if instructionMatch(p, name) {
+			included = true
+		}
+	}
+	return included || !hasInclude
+}
+
+func instructionMatch(pattern, name string) bool {
+	for n := name; n != "." && n != "/"; n = path.Dir(n) {
+		if ok, _ := path.Match(pattern, n); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SnapshotInstructions returns the instruction files recorded in a snapshot
+// that the role receives, in discovery order.
+func SnapshotInstructions(snapshotJSON, roleID string) ([]Instruction, error) {
+	var doc struct {
+		Roles        map[string]RoleContract `json:"roles"`
+		Files        map[string]string       `json:"files"`
+		Instructions []string                `json:"instructions"`
+	}
+	if err := json.Unmarshal([]byte(snapshotJSON), &doc); err != nil {
+		return nil, fmt.Errorf("workflow snapshot: %w", err)
+	}
+	role, ok := doc.Roles[roleID]
+	if !ok {
+		return nil, fmt.Errorf("workflow snapshot: role %q not found", roleID)
+	}
+	var out []Instruction
+	for _, name := range doc.Instructions {
+		if !role.WantsInstruction(name) {
+			continue
+		}
+		content, ok := doc.Files[instructionKey(name)]
+		if !ok {
+			return nil, fmt.Errorf("workflow snapshot: missing instruction %q", name)
+		}
+		out = append(out, Instruction{Name: name, Content: content})
+	}
+	return out, nil
+}
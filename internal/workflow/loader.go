@@ -29,8 +29,10 @@ type ListEntry struct {
 //
 //	<root>/workflows/<name>.yaml
 //	<root>/roles/<id>.yaml
+//	<root>/runners/<id>.yaml
 //
 // Role prompt and result_schema paths are relative to the role's root.
+// Runners resolve like roles, by id, project first.
 type Loader struct {
 	Global  string
 	Project string
@@ -97,9 +99,10 @@ func (l Loader) scanDir(root string) []ListEntry {
 
 // Load resolves a named workflow and assembles its bundle: the workflow, the
 // resolved role contracts it references, and the raw contents of referenced
-// prompt and schema files. Controller-discovered project instructions
-// (AGENTS.md/CLAUDE.md/skills) are not added here; callers append them to the
-// returned Bundle.Files before building a snapshot.
+// prompt and schema files. Project instructions (AGENTS.md/CLAUDE.md/skills)
+// live in the project worktree, not the definitions roots, so they are not
+// added here; callers discover them with InstructionDiscovery and add them
+// with Bundle.AddInstructions before building a snapshot.
 func (l Loader) Load(ctx context.Context, name string) (*Bundle, error) {
 	if err := validateID(name); err != nil {
 		return nil, fmt.Errorf("workflow: name: %w", err)
@@ -120,7 +123,12 @@ func (l Loader) Load(ctx context.Context, name string) (*Bundle, error) {
 		return nil, err
 	}
 
-	bundle := &Bundle{Spec: *spec, Roles: roles, Files: files, WorkflowSource: source}
+	runners, err := l.resolveRunners(roles)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{Spec: *spec, Roles: roles, Runners: runners, Files: files, WorkflowSource: source}
 	if err := bundle.Validate(); err != nil {
 		return nil, err
 	}
@@ -187,6 +195,47 @@ func (l Loader) resolveRoles(spec *WorkflowSpec) (map[string]RoleContract, map[s
 	return roles, files, nil
 }
 
+// resolveRunners loads the runner definition of every role that names a
+// runner. DefaultRunner is built in and only needs a file to override it.
+func (l Loader) resolveRunners(roles map[string]RoleContract) (map[string]RunnerSpec, error) {
+	runners := map[string]RunnerSpec{}
+	for _, role := range roles {
+		id := role.RunnerID()
+		if _, ok := runners[id]; ok {
+			continue
+		}
+		dir, ok := l.resolveDefinitionDir("runners", id)
+		if !ok {
+			if id == DefaultRunner {
+				continue
+			}
+			return nil, fmt.Errorf("workflow: role %q: runner %q not found", role.ID, id)
+		}
+		runner, err := parseRunnerFile(filepath.Join(dir, "runners", id+".yaml"))
+		if err != nil {
+			return nil, err
+		}
+		if runner.ID != id {
+			return nil, fmt.Errorf("workflow: runner file for %q declares id %q", id, runner.ID)
+		}
+		runners[id] = *runner
+	}
+	return runners, nil
+}
+
+// resolveDefinitionDir returns the root holding <kind>/<id>.yaml, project
+// first.
+func (l Loader) resolveDefinitionDir(kind, id string) (string, bool) {
+	rel := filepath.Join(kind, id+".yaml")
+	if l.Project != "" && fileExists(filepath.Join(l.Project, rel)) {
+		return l.Project, true
+	}
+	if l.Global != "" && fileExists(filepath.Join(l.Global, rel)) {
+		return l.Global, true
+	}
+	return "", false
+}
+
 // rolePromptKey and roleSchemaKey namespace dependency files by role id so a
 // prompt/schema with the same relative path from a global and a project root
 // cannot collide in the snapshot closure.
@@ -223,6 +272,21 @@ func parseRoleFile(path string) (*RoleContract, error) {
 	return role, nil
 }
 
+func parseRunnerFile(path string) (*RunnerSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("workflow: read %s: %w", path, err)
+	}
+	runner, err := ParseRunner(data)
+	if err != nil {
+		return nil, fmt.Errorf("workflow: %s: %w", path, err)
+	}
+	if err := runner.Validate(); err != nil {
+		return nil, fmt.Errorf("workflow: %s: %w", path, err)
+	}
+	return runner, nil
+}
+
 func addDependency(files map[string]string, key, dir, rel string) error {
 	if err := validateRelPath(rel); err != nil {
 		return err
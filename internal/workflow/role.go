@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,17 +26,94 @@ func (m WorkspaceMode) Valid() bool {
 }
 
 // RoleContract is the resolved contract for a role id. It owns the prompt
-// reference, allowed outcomes, declared outputs, result JSON schema, and
-// workspace access mode. Role contracts resolve independently of workflows:
+// reference, allowed outcomes, declared outputs, result JSON schema,
+// workspace access mode and the runner (agent CLI) that executes it; an
+// empty Runner means DefaultRunner. Timeout caps an execution's wall-clock
+// time and IdleTimeout how long it may go without output (Go durations such
+// as "30m"); empty means no limit. A read-only role runs sandboxed with a
+// scrubbed environment; Env names the extra variables (NAME, or PREFIX*
+// for a family) it keeps. Instructions selects the project instruction
+// files the role is given (see WantsInstruction), and Context the task
+// context beyond the task itself (parent, siblings, blockers, notes) its
+// envelope includes. Role contracts resolve independently of workflows:
 // a project role with a given id replaces the global role with the same id.
 type RoleContract struct {
-	ID          string        `yaml:"id" json:"id"`
-	Description string        `yaml:"description,omitempty" json:"description,omitempty"`
-	Prompt      string        `yaml:"prompt" json:"prompt"`
-	Outcomes    []string      `yaml:"outcomes,omitempty" json:"outcomes,omitempty"`
-	Outputs     []string      `yaml:"outputs,omitempty" json:"outputs,omitempty"`
-	ResultSchema string       `yaml:"result_schema,omitempty" json:"result_schema,omitempty"`
-	Workspace   WorkspaceMode `yaml:"workspace" json:"workspace"`
+	ID           string        `yaml:"id" json:"id"`
+	Description  string        `yaml:"description,omitempty" json:"description,omitempty"`
+	Prompt       string        `yaml:"prompt" json:"prompt"`
+	Outcomes     []string      `yaml:"outcomes,omitempty" json:"outcomes,omitempty"`
+	Outputs      []string      `yaml:"outputs,omitempty" json:"outputs,omitempty"`
+	ResultSchema string        `yaml:"result_schema,omitempty" json:"result_schema,omitempty"`
+	Workspace    WorkspaceMode `yaml:"workspace" json:"workspace"`
+	Runner       string        `yaml:"runner,omitempty" json:"runner,omitempty"`
+	Timeout      string        `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	IdleTimeout  string        `yaml:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`
+	Env          []string      `yaml:"env,omitempty" json:"env,omitempty"`
+	Instructions []string      `yaml:"instructions,omitempty" json:"instructions,omitempty"`
+	Context      []TaskContext `yaml:"context,omitempty" json:"context,omitempty"`
+}
+
+// TaskContext names a part of the surrounding plan a role may see besides
+// its task: the parent epic's description, the titles of the parent's other
+// children, the tasks blocking it and the task's notes.
+type TaskContext string
+
+const (
+	ContextParent   TaskContext = "parent"
+	ContextSiblings TaskContext = "siblings"
+	ContextBlockers TaskContext = "blockers"
+	ContextNotes    TaskContext = "notes"
+)
+
+// Valid reports whether c is a defined task context.
+func (c TaskContext) Valid() bool {
+	switch c {
+	case ContextParent, ContextSiblings, ContextBlockers, ContextNotes:
+		return true
+	default:
+		return false
+	}
+}
+
+// WantsContext reports whether the role asks for the task context c.
+func (r RoleContract) WantsContext(c TaskContext) bool {
+	for _, x := range r.Context {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Limits returns the role's wall-clock and idle timeouts; zero means no
+// limit. The role must have passed Validate.
+func (r RoleContract) Limits() (timeout, idle time.Duration) {
+	timeout, _ = parseLimit(r.Timeout)
+	idle, _ = parseLimit(r.IdleTimeout)
+	return timeout, idle
+}
+
+// parseLimit parses an optional positive duration.
+func parseLimit(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got %s", s)
+	}
+	return d, nil
+}
+
+// RunnerID returns the runner that executes the role.
+func (r RoleContract) RunnerID() string {
+	if r.Runner == "" {
+		return DefaultRunner
+	}
+	return r.Runner
 }
 
 // ParseRole decodes a role contract strictly; unknown fields are an error.
@@ -105,9 +184,54 @@ func (r *RoleContract) Validate() error {
 	if err := validateRelPath(r.ResultSchema); err != nil {
 		return fmt.Errorf("role: result_schema: %w", err)
 	}
+	if r.Runner != "" {
+		if err := validateID(r.Runner); err != nil {
+			return fmt.Errorf("role: runner: %w", err)
+		}
+	}
+	if _, err := parseLimit(r.Timeout); err != nil {
+		return fmt.Errorf("role: timeout: %w", err)
+	}
+	if _, err := parseLimit(r.IdleTimeout); err != nil {
+		return fmt.Errorf("role: idle_timeout: %w", err)
+	}
+	for _, name := range r.Env {
+		if !validEnvPattern(name) {
+			return fmt.Errorf("role: env: invalid variable name %q", name)
+		}
+	}
+	for _, p := range r.Instructions {
+		pattern := strings.TrimPrefix(p, "!")
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			return fmt.Errorf("role: instructions: invalid pattern %q", p)
+		}
+	}
+	for _, c := range r.Context {
+		if !c.Valid() {
+			return fmt.Errorf("role: context: invalid value %q", c)
+		}
+	}
 	return nil
 }
 
+// validEnvPattern accepts an environment variable name, optionally ending
+// in "*" to match every name with that prefix.
+func validEnvPattern(name string) bool {
+	name = strings.TrimSuffix(name, "*")
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // forJSON returns a copy with nil slices normalized to empty slices.
 func (r RoleContract) forJSON() RoleContract {
 	if r.Outcomes == nil {
@@ -3,6 +3,7 @@ package workflow
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 const validRole = `
@@ -68,3 +69,139 @@ func TestValidateRoleErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRoleRunner(t *testing.T) {
+	r, err := ParseRole([]byte(validRole + "runner: acme\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if r.RunnerID() != "acme" {
+		t.Fatalf("RunnerID = %q", r.RunnerID())
+	}
+	r.Runner = ""
+	if r.RunnerID() != DefaultRunner {
+		t.Fatalf("default RunnerID = %q", r.RunnerID())
+	}
+	r.Runner = "../x"
+	if err := r.Validate(); err == nil || !strings.Contains(err.Error(), "runner") {
+		t.Fatalf("validate bad runner = %v", err)
+	}
+}
+
+func TestParseRoleLimits(t *testing.T) {
+	r, err := ParseRole([]byte(validRole + "timeout: 45m\nidle_timeout: 5m\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if timeout, idle := r.Limits(); timeout != 45*time.Minute || idle != 5*time.Minute {
+		t.Fatalf("Limits = %v, %v", timeout, idle)
+	}
+	for _, bad := range []string{"soon", "0s", "-1m"} {
+		r.IdleTimeout = bad
+		if err := r.Validate(); err == nil || !strings.Contains(err.Error(), "idle_timeout") {
+			t.Fatalf("validate idle_timeout %q = %v", bad, err)
+		}
+	}
+}
+
+func TestParseRoleEnv(t *testing.T) {
+	r, err := ParseRole([]byte(validRole + "env: [GH_TOKEN, ANTHROPIC_*]\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(r.Env) != 2 || r.Env[1] != "ANTHROPIC_*" {
+		t.Fatalf("Env = %v", r.Env)
+	}
+	for _, bad := range []string{"", "*", "1PASS", "A-B", "A*B"} {
+		r.Env = []string{bad}
+		if err := r.Validate(); err == nil || !strings.Contains(err.Error(), "env") {
+			t.Fatalf("validate env %q = %v", bad, err)
+		}
+	}
+}
+
+func TestParseRoleContext(t *testing.T) {
+	r, err := ParseRole([]byte(validRole + "context: [parent, blockers]\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if !r.WantsContext(ContextParent) || !r.WantsContext(ContextBlockers) || r.WantsContext(ContextNotes) {
+		t.Fatalf("Context = %v", r.Context)
+	}
+	r.Context = []TaskContext{"children"}
+	if err := r.Validate(); err == nil || !strings.Contains(err.Error(), "context") {
+		t.Fatalf("validate unknown context = %v", err)
+	}
+}
+
+const validRunner = `
+id: acme
+protocol: prompt-file
+bin: acme-agent
+args: [run, --cwd, "{workdir}", --prompt, "{prompt_file}"]
+resume_args: [--resume, "{session}"]
+result:
+  result_type: done
+  text_field: summary
+  session_field: meta.session
+`
+
+func TestParseRunnerValid(t *testing.T) {
+	r, err := ParseRunner([]byte(validRunner))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	p := r.Result.WithDefaults()
+	if p.Format != ResultJSONL || p.TypeField != "type" || p.ResultType != "done" || p.SessionField != "meta.session" {
+		t.Fatalf("parser defaults = %+v", p)
+	}
+	if _, err := ParseRunner([]byte(validRunner + "wat: 1\n")); err == nil {
+		t.Fatal("expected unknown-field error")
+	}
+}
+
+func TestValidateRunnerErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"missing id", "protocol: stdin-jsonl\nbin: x\n", "id is required"},
+		{"bad protocol", "id: x\nprotocol: carrier-pigeon\nbin: x\n", "invalid protocol"},
+		{"missing bin", "id: x\nprotocol: stdin-jsonl\n", "bin is required"},
+		{"prompt file unused", "id: x\nprotocol: prompt-file\nbin: x\nargs: [go]\n", "requires {prompt_file}"},
+		{"unknown placeholder", "id: x\nprotocol: stdin-jsonl\nbin: x\nargs: [\"{model}\"]\n", "unknown placeholder {model}"},
+		{"session in args", "id: x\nprotocol: stdin-jsonl\nbin: x\nargs: [\"{session}\"]\n", "unknown placeholder {session}"},
+		{"resume without session", "id: x\nprotocol: stdin-jsonl\nbin: x\nresume_args: [--continue]\n", "must contain {session}"},
+		{"text resume", "id: x\nprotocol: stdin-jsonl\nbin: x\nresume_args: [-r, \"{session}\"]\nresult: {format: text}\n", "need a jsonl result"},
+		{"bad format", "id: x\nprotocol: stdin-jsonl\nbin: x\nresult: {format: xml}\n", "invalid result format"},
+		{"fork without resume", "id: x\nprotocol: stdin-jsonl\nbin: x\nfork_args: [--fork]\n", "fork_args require resume_args"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := ParseRunner([]byte(tc.yaml))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			err = r.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error = %v, want substring %q", err, tc.want)
+			}
+		})
+	}
+}
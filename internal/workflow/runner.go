@@ -0,0 +1,207 @@
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRunner is the runner used by roles that do not name one. It is
+// built into the agent package and needs no runner definition file.
+const DefaultRunner = "maki"
+
+// RunnerProtocol selects how a declaratively configured runner receives its
+// prompt.
+type RunnerProtocol string
+
+const (
+	// RunnerStdinJSONL writes the prompt to stdin and reads a JSONL event
+	// stream from stdout.
+	RunnerStdinJSONL RunnerProtocol = "stdin-jsonl"
+	// RunnerPromptFile writes the prompt to a file and passes its path as
+	// an argument (the {prompt_file} placeholder).
+	RunnerPromptFile RunnerProtocol = "prompt-file"
+)
+
+// Valid reports whether p is a defined runner protocol.
+func (p RunnerProtocol) Valid() bool {
+	return p == RunnerStdinJSONL || p == RunnerPromptFile
+}
+
+// Placeholders expanded in RunnerSpec.Args and RunnerSpec.ResumeArgs.
+const (
+	PlaceholderPromptFile = "{prompt_file}"
+	PlaceholderWorkdir    = "{workdir}"
+	PlaceholderResult     = "{result}"
+	PlaceholderSession    = "{session}"
+)
+
+var placeholderRE = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// ResultFormat selects how a runner's stdout is turned into a result.
+type ResultFormat string
+
+const (
+	// ResultJSONL scans stdout for a result event (see ResultParser).
+	ResultJSONL ResultFormat = "jsonl"
+	// ResultText takes the whole of stdout as the result text; success is
+	// the exit status and no session id is captured.
+	ResultText ResultFormat = "text"
+)
+
+// ResultParser describes where a JSONL runner reports its outcome and,
+// optionally, its token and cost usage. Field names may be dotted paths
+// into nested objects ("message.session.id").
+// Empty fields take the defaults of the built-in runner's stream-json
+// format, so a compatible CLI needs no result section at all.
+type ResultParser struct {
+	Format            ResultFormat `yaml:"format,omitempty" json:"format,omitempty"`
+	TypeField         string       `yaml:"type_field,omitempty" json:"type_field,omitempty"`
+	ResultType        string       `yaml:"result_type,omitempty" json:"result_type,omitempty"`
+	TextField         string       `yaml:"text_field,omitempty" json:"text_field,omitempty"`
+	SessionField      string       `yaml:"session_field,omitempty" json:"session_field,omitempty"`
+	ErrorField        string       `yaml:"error_field,omitempty" json:"error_field,omitempty"`
+	StopField         string       `yaml:"stop_field,omitempty" json:"stop_field,omitempty"`
+	InputTokensField  string       `yaml:"input_tokens_field,omitempty" json:"input_tokens_field,omitempty"`
+	OutputTokensField string       `yaml:"output_tokens_field,omitempty" json:"output_tokens_field,omitempty"`
+	CostField         string       `yaml:"cost_field,omitempty" json:"cost_field,omitempty"`
+}
+
+// WithDefaults returns p with empty fields filled in.
+func (p ResultParser) WithDefaults() ResultParser {
+	def := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	if p.Format == "" {
+		p.Format = ResultJSONL
+	}
+	def(&p.TypeField, "type")
+	def(&p.ResultType, "result")
+	def(&p.TextField, "result")
+	def(&p.SessionField, "session_id")
+	def(&p.ErrorField, "is_error")
+	def(&p.StopField, "subtype")
+	def(&p.InputTokensField, "usage.input_tokens")
+	def(&p.OutputTokensField, "usage.output_tokens")
+	def(&p.CostField, "total_cost_usd")
+	return p
+}
+
+// RunnerSpec declares an agent CLI that the generic adapters can drive
+// without Go code. Runner definitions live next to roles:
+//
+//	<root>/runners/<id>.yaml
+//
+// and a role selects one with its `runner:` field. Example:
+//
+//	id: acme
+//	protocol: prompt-file
+//	bin: acme-agent
+//	args: [run, --cwd, "{workdir}", --prompt, "{prompt_file}"]
+//	resume_args: [--resume, "{session}"]
+//	fork_args: [--fork]
+//	result: {format: jsonl, result_type: done, text_field: summary}
+type RunnerSpec struct {
+	ID         string         `yaml:"id" json:"id"`
+	Protocol   RunnerProtocol `yaml:"protocol" json:"protocol"`
+	Bin        string         `yaml:"bin" json:"bin"`
+	Args       []string       `yaml:"args,omitempty" json:"args,omitempty"`
+	ResumeArgs []string       `yaml:"resume_args,omitempty" json:"resume_args,omitempty"`
+	// ForkArgs follow ResumeArgs when an operator forked the session; they
+	// make the CLI continue the conversation under a new session id.
+	ForkArgs []string     `yaml:"fork_args,omitempty" json:"fork_args,omitempty"`
+	Result   ResultParser `yaml:"result,omitempty" json:"result,omitempty"`
+}
+
+// ParseRunner decodes a runner definition strictly; unknown fields are an
+// error.
+func ParseRunner(data []byte) (*RunnerSpec, error) {
+	if err := validateYAMLSubset(data); err != nil {
+		return nil, fmt.Errorf("runner: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var r RunnerSpec
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("decode runner: %w", err)
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		if err == nil {
+			return nil, errors.New("decode runner: multiple YAML documents")
+		}
+		return nil, fmt.Errorf("decode runner: %w", err)
+	}
+	return &r, nil
+}
+
+// Validate checks the runner definition: a known protocol, a binary, only
+// known placeholders, and {prompt_file} where the protocol needs it.
+func (r *RunnerSpec) Validate() error {
+	if r == nil {
+		return errors.New("runner: nil spec")
+	}
+	if err := validateID(r.ID); err != nil {
+		return fmt.Errorf("runner: id: %w", err)
+	}
+	if !r.Protocol.Valid() {
+		return fmt.Errorf("runner: invalid protocol %q", r.Protocol)
+	}
+	if strings.TrimSpace(r.Bin) == "" {
+		return errors.New("runner: bin is required")
+	}
+	known := map[string]bool{PlaceholderPromptFile: true, PlaceholderWorkdir: true, PlaceholderResult: true}
+	usesPromptFile := false
+	for _, a := range r.Args {
+		for _, ph := range placeholderRE.FindAllString(a, -1) {
+			if !known[ph] {
+				return fmt.Errorf("runner: args: unknown placeholder %s", ph)
+			}
+			usesPromptFile = usesPromptFile || ph == PlaceholderPromptFile
+		}
+	}
+	if r.Protocol == RunnerPromptFile && !usesPromptFile {
+		return fmt.Errorf("runner: protocol %s requires %s in args", r.Protocol, PlaceholderPromptFile)
+	}
+	known[PlaceholderSession] = true
+	usesSession := false
+	for _, a := range r.ResumeArgs {
+		for _, ph := range placeholderRE.FindAllString(a, -1) {
+			if !known[ph] {
+				return fmt.Errorf("runner: resume_args: unknown placeholder %s", ph)
+			}
+			usesSession = usesSession || ph == PlaceholderSession
+		}
+	}
+	if len(r.ResumeArgs) > 0 && !usesSession {
+		return fmt.Errorf("runner: resume_args must contain %s", PlaceholderSession)
+	}
+	if len(r.ForkArgs) > 0 && len(r.ResumeArgs) == 0 {
+		return errors.New("runner: fork_args require resume_args")
+	}
+	for _, a := range r.ForkArgs {
+		for _, ph := range placeholderRE.FindAllString(a, -1) {
+			if !known[ph] {
+				return fmt.Errorf("runner: fork_args: unknown placeholder %s", ph)
+			}
+		}
+	}
+	switch r.Result.Format {
+	case "", ResultJSONL:
+	case ResultText:
+		if len(r.ResumeArgs) > 0 {
+			return errors.New("runner: resume_args need a jsonl result to capture the session id")
+		}
+	default:
+		return fmt.Errorf("runner: invalid result format %q", r.Result.Format)
+	}
+	return nil
+}
@@ -49,7 +49,36 @@ type InputRef struct {
 type WorkflowSpec struct {
 	Version int        `yaml:"version" json:"version"`
 	Name    string     `yaml:"name" json:"name"`
+	Budget  *Budget    `yaml:"budget,omitempty" json:"budget,omitempty"`
 	Steps   []StepSpec `yaml:"steps" json:"steps"`
+
+	// Instructions lists extra project instruction files or directories,
+	// relative to the project root, given to agents after the conventional
+	// ones (see InstructionDiscovery).
+	Instructions []string `yaml:"instructions,omitempty" json:"instructions,omitempty"`
+}
+
+// Budget caps what one run of a workflow may spend across all of its agent
+// executions. A zero field is no limit. The controller adds up the usage
+// each execution reports and stops the run (needs_attention) once a limit
+// is exceeded.
+type Budget struct {
+	MaxTokens  int64   `yaml:"max_tokens,omitempty" json:"max_tokens,omitempty"`
+	MaxCostUSD float64 `yaml:"max_cost_usd,omitempty" json:"max_cost_usd,omitempty"`
+}
+
+// Validate rejects negative limits.
+func (b *Budget) Validate() error {
+	if b == nil {
+		return nil
+	}
+	if b.MaxTokens < 0 {
+		return fmt.Errorf("budget: max_tokens must not be negative, got %d", b.MaxTokens)
+	}
+	if b.MaxCostUSD < 0 {
+		return fmt.Errorf("budget: max_cost_usd must not be negative, got %g", b.MaxCostUSD)
+	}
+	return nil
 }
 
 // StepSpec is a single workflow step.
@@ -76,6 +105,10 @@ type StepSpec struct {
 	// Prompt is an optional static prompt for human steps. It supplements
 	// inputs; it never replaces explicit dataflow.
 	Prompt string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
+
+	// CloseTask marks an end step that finishes the task: a run completing
+	// there closes its bd issue. Only end steps may set it.
+	CloseTask bool `yaml:"close_task,omitempty" json:"close_task,omitempty"`
 }
 
 // Parse decodes a workflow definition strictly: any unknown YAML field is an
@@ -118,6 +151,14 @@ func (s *WorkflowSpec) Validate() error {
 	if len(s.Steps) == 0 {
 		return errors.New("workflow: at least one step is required")
 	}
+	if err := s.Budget.Validate(); err != nil {
+		return fmt.Errorf("workflow: %w", err)
+	}
+	for _, p := range s.Instructions {
+		if err := validateRelPath(p); err != nil {
+			return fmt.Errorf("workflow: instructions: %w", err)
+		}
+	}
 
 	byID := make(map[string]int, len(s.Steps))
 	for i := range s.Steps {
@@ -202,6 +243,9 @@ func (st *StepSpec) validateFields() error {
 			return errors.New("end step must not set role, prompt, inputs, or on")
 		}
 	}
+	if st.CloseTask && st.Type != StepEnd {
+		return errors.New("only an end step may set close_task")
+	}
 	return nil
 }
 
@@ -303,7 +347,7 @@ func (s *WorkflowSpec) CanonicalJSON() (string, error) {
 // forJSON returns a copy with nil maps normalized to empty maps so the
 // canonical representation is stable regardless of how the spec was built.
 func (s *WorkflowSpec) forJSON() WorkflowSpec {
-	out := WorkflowSpec{Version: s.Version, Name: s.Name, Steps: make([]StepSpec, len(s.Steps))}
+	out := WorkflowSpec{Version: s.Version, Name: s.Name, Budget: s.Budget, Steps: make([]StepSpec, len(s.Steps))}
 	for i := range s.Steps {
 		st := s.Steps[i]
 		if st.Inputs == nil {
@@ -253,6 +253,7 @@ func TestValidateErrors(t *testing.T) {
 		{"human sets role", "version: 1\nname: x\nsteps:\n  - id: a\n    type: human\n    role: r\n    on: {go: b}\n  - id: b\n    type: end\n", "human step must not set role"},
 		{"human missing on", "version: 1\nname: x\nsteps:\n  - id: a\n    type: human\n    prompt: hi\n  - id: b\n    type: end\n", "at least one outcome"},
 		{"end has on", "version: 1\nname: x\nsteps:\n  - id: a\n    type: agent\n    role: r\n    on: {go: b}\n  - id: b\n    type: end\n    on: {x: a}\n", "end step must not set"},
+		{"agent sets close_task", "version: 1\nname: x\nsteps:\n  - id: a\n    type: agent\n    role: r\n    close_task: true\n    on: {go: b}\n  - id: b\n    type: end\n", "only an end step may set close_task"},
 		{"on target not found", "version: 1\nname: x\nsteps:\n  - id: a\n    type: agent\n    role: r\n    on: {go: missing}\n", "not found"},
 		{"input step not found", "version: 1\nname: x\nsteps:\n  - id: a\n    type: agent\n    role: r\n    inputs:\n      x: {step: missing, output: y}\n    on: {go: b}\n  - id: b\n    type: end\n", "not found"},
 		{"input step is end", "version: 1\nname: x\nsteps:\n  - id: a\n    type: agent\n    role: r\n    inputs:\n      x: {step: b, output: y}\n    on: {go: b}\n  - id: b\n    type: end\n", "end step"},
@@ -508,6 +509,178 @@ func TestBuildSnapshotDeterministicAndSensitive(t *testing.T) {
 	}
 }
 
+func TestSnapshotBudget(t *testing.T) {
+	spec, err := Parse([]byte(strings.Replace(validWorkflow, "name: ship\n", "name: ship\nbudget:\n  max_tokens: 200000\n  max_cost_usd: 2.5\n", 1)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	snap, err := BuildSnapshot(Bundle{Spec: *spec, Roles: validRoles(), Files: completeFiles(), WorkflowSource: validWorkflow})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	b, err := SnapshotBudget(snap.JSON)
+	if err != nil || b != (Budget{MaxTokens: 200000, MaxCostUSD: 2.5}) {
+		t.Fatalf("SnapshotBudget = %+v, %v", b, err)
+	}
+
+	spec.Budget = nil
+	snap, err = BuildSnapshot(Bundle{Spec: *spec, Roles: validRoles(), Files: completeFiles(), WorkflowSource: validWorkflow})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if strings.Contains(snap.JSON, "budget") {
+		t.Fatalf("a workflow without a budget should not change its snapshot: %s", snap.JSON)
+	}
+	if b, err := SnapshotBudget(snap.JSON); err != nil || b != (Budget{}) {
+		t.Fatalf("SnapshotBudget without budget = %+v, %v", b, err)
+	}
+
+	spec.Budget = &Budget{MaxTokens: -1}
+	if err := spec.Validate(); err == nil || !strings.Contains(err.Error(), "max_tokens") {
+		t.Fatalf("validate negative budget = %v", err)
+	}
+}
+
+func TestSnapshotClosesTask(t *testing.T) {
+	src := strings.Replace(validWorkflow, "  - id: end\n    type: end\n", "  - id: end\n    type: end\n    close_task: true\n", 1)
+	spec, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	snap, err := BuildSnapshot(Bundle{Spec: *spec, Roles: validRoles(), Files: completeFiles(), WorkflowSource: src})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	for step, want := range map[string]bool{"end": true, "plan": false, "missing": false} {
+		if got, err := SnapshotClosesTask(snap.JSON, step); err != nil || got != want {
+			t.Fatalf("SnapshotClosesTask(%s) = %v, %v; want %v", step, got, err, want)
+		}
+	}
+}
+
+func TestInstructionDiscovery(t *testing.T) {
+	root := t.TempDir()
+	mustWriteDir(t, root, "CLAUDE.md", "claude\n")
+	mustWriteDir(t, root, "AGENTS.md", "agents\n")
+	mustWriteDir(t, root, "skills-lock.json", `{"version":1,"skills":{"zeta":{},"alpha":{}}}`)
+	mustWriteDir(t, root, "skills/zeta/SKILL.md", "zeta skill\n")
+	mustWriteDir(t, root, "skills/alpha/SKILL.md", "alpha skill\n")
+	mustWriteDir(t, root, "skills/unlocked/SKILL.md", "not in the lock file\n")
+	mustWriteDir(t, root, "docs/agents/b.md", "b\n")
+	mustWriteDir(t, root, "docs/agents/a.md", "a\n")
+	mustWriteDir(t, root, "docs/agents/notes.txt", "skipped\n")
+
+	d := InstructionDiscovery{Root: root, Paths: []string{"docs/agents", "AGENTS.md"}}
+	got, err := d.Discover()
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	var names []string
+	for _, in := range got {
+		names = append(names, in.Name)
+	}
+	want := []string{"AGENTS.md", "CLAUDE.md", "skills/alpha/SKILL.md", "skills/zeta/SKILL.md", "docs/agents/a.md", "docs/agents/b.md"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	if got[2].Content != "alpha skill\n" {
+		t.Fatalf("skill content = %q", got[2].Content)
+	}
+
+	// Without a lock file every skills/ directory counts.
+	if err := os.Remove(filepath.Join(root, "skills-lock.json")); err != nil {
+		t.Fatal(err)
+	}
+	got, err = InstructionDiscovery{Root: root}.Discover()
+	if err != nil || len(got) != 5 || got[3].Name != "skills/unlocked/SKILL.md" {
+		t.Fatalf("Discover without lock = %+v, %v", got, err)
+	}
+
+	if _, err := (InstructionDiscovery{Root: root, Paths: []string{"missing.md"}}).Discover(); err == nil {
+		t.Fatal("a missing configured path should be an error")
+	}
+	if _, err := (InstructionDiscovery{Root: root, Paths: []string{"../outside.md"}}).Discover(); err == nil {
+		t.Fatal("a configured path outside the root should be an error")
+	}
+}
+
+func TestInstructionDiscoveryLimits(t *testing.T) {
+	root := t.TempDir()
+	mustWriteDir(t, root, "AGENTS.md", strings.Repeat("agents line\n", 10))
+	mustWriteDir(t, root, "CLAUDE.md", strings.Repeat("claude line\n", 10))
+	mustWriteDir(t, root, "extra.md", "extra\n")
+
+	got, err := InstructionDiscovery{Root: root, Paths: []string{"extra.md"}, MaxFileBytes: 50, MaxTotalBytes: 80}.Discover()
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d files, want the total limit to drop the last: %+v", len(got), got)
+	}
+	if len(got[0].Content) > 50 || !strings.HasSuffix(got[0].Content, instructionTruncated) {
+		t.Fatalf("AGENTS.md = %q, want truncated to 50 bytes", got[0].Content)
+	}
+	if len(got[0].Content)+len(got[1].Content) > 80 || !strings.HasSuffix(got[1].Content, instructionTruncated) {
+		t.Fatalf("CLAUDE.md = %q, want truncated to the remaining total", got[1].Content)
+	}
+}
+
+func TestSnapshotInstructions(t *testing.T) {
+	spec, err := Parse([]byte(validWorkflow))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	roles := validRoles()
+	reviewer := roles["reviewer"]
+	reviewer.Instructions = []string{"!skills"}
+	roles["reviewer"] = reviewer
+	implementer := roles["implementer"]
+	implementer.Instructions = []string{"skills/*", "!skills/beta"}
+	roles["implementer"] = implementer
+
+	bundle := Bundle{Spec: *spec, Roles: roles, Files: completeFiles(), WorkflowSource: validWorkflow}
+	plain, err := BuildSnapshot(bundle)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	bundle.AddInstructions([]Instruction{
+		{Name: "AGENTS.md", Content: "agents"},
+		{Name: "skills/alpha/SKILL.md", Content: "alpha"},
+		{Name: "skills/beta/SKILL.md", Content: "beta"},
+	})
+	snap, err := BuildSnapshot(bundle)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if snap.Ref == plain.Ref {
+		t.Fatal("snapshot ref should change with instructions")
+	}
+
+	for role, want := range map[string]string{
+		"planner":     "AGENTS.md,skills/alpha/SKILL.md,skills/beta/SKILL.md",
+		"reviewer":    "AGENTS.md",
+		"implementer": "skills/alpha/SKILL.md",
+	} {
+		ins, err := SnapshotInstructions(snap.JSON, role)
+		if err != nil {
+			t.Fatalf("SnapshotInstructions(%s): %v", role, err)
+		}
+		var names []string
+		for _, in := range ins {
+			names = append(names, in.Name)
+		}
+		if strings.Join(names, ",") != want {
+			t.Fatalf("%s instructions = %v, want %s", role, names, want)
+		}
+	}
+
+	bad := roles["planner"]
+	bad.Instructions = []string{"skills/["}
+	if err := bad.Validate(); err == nil || !strings.Contains(err.Error(), "instructions") {
+		t.Fatalf("validate bad pattern = %v", err)
+	}
+}
+
 func TestLoaderWorkflowAndRoleOverride(t *testing.T) {
 	dir := t.TempDir()
 	global := filepath.Join(dir, "global")
@@ -635,6 +808,46 @@ workspace: read
 	}
 }
 
+func TestLoaderResolvesRunners(t *testing.T) {
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global")
+	project := filepath.Join(dir, "project")
+
+	mustWriteDir(t, global, "workflows/wf.yaml", validWorkflow)
+	mustWriteDir(t, global, "roles/planner.yaml", plannerRole+"runner: acme\n")
+	mustWriteDir(t, global, "roles/reviewer.yaml", reviewerRole+"runner: acme\n")
+	mustWriteDir(t, global, "roles/implementer.yaml", implementerRole)
+	for _, f := range []string{"prompts/planner.md", "prompts/reviewer.md", "prompts/implementer.md", "schemas/plan.json", "schemas/review.json", "schemas/patch.json"} {
+		mustWriteDir(t, global, f, "x")
+	}
+	loader := Loader{Global: global, Project: project}
+	if _, err := loader.Load(context.Background(), "wf"); err == nil || !strings.Contains(err.Error(), `runner "acme" not found`) {
+		t.Fatalf("load without runner = %v", err)
+	}
+
+	mustWriteDir(t, global, "runners/acme.yaml", "id: acme\nprotocol: stdin-jsonl\nbin: global-acme\n")
+	mustWriteDir(t, project, "runners/acme.yaml", "id: acme\nprotocol: stdin-jsonl\nbin: project-acme\n")
+	bundle, err := loader.Load(context.Background(), "wf")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(bundle.Runners) != 1 || bundle.Runners["acme"].Bin != "project-acme" {
+		t.Fatalf("runners = %+v, want the project acme only", bundle.Runners)
+	}
+	snap, err := BuildSnapshot(*bundle)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if !strings.Contains(snap.JSON, `"bin":"project-acme"`) {
+		t.Fatalf("snapshot does not pin the runner: %s", snap.JSON)
+	}
+
+	delete(bundle.Runners, "acme")
+	if err := bundle.Validate(); err == nil {
+		t.Fatal("bundle without its runner should not validate")
+	}
+}
+
 func mustWriteDir(t *testing.T, dir, rel, content string) {
 	t.Helper()
 	p := filepath.Join(dir, rel)
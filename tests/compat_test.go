@@ -28,6 +28,7 @@ type PluginRegistry = b.PluginRegistry
 type PluginToggles = b.PluginToggles
 type MuxTarget = b.MuxTarget
 type BoardRow = b.BoardRow
+type IssueWindow = b.IssueWindow
 type formEditorPayload = b.FormEditorPayload
 type formEditorMsg = b.FormEditorMsg
 type reopenParentForCreateMsg = b.ReopenParentForCreateMsg
@@ -67,6 +68,9 @@ const (
 
 	DeleteModeForce   = b.DeleteModeForce
 	DeleteModeCascade = b.DeleteModeCascade
+
+	DropLinkParent  = b.DropLinkParent
+	DropLinkBlocker = b.DropLinkBlocker
 )
 
 var (
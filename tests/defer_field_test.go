@@ -39,9 +39,9 @@ func TestBdClientListIssuesParsesDeferUntil(t *testing.T) {
 	runIn("create", "smoke deferred", "--defer", "2099-12-30", "--silent")
 
 	client := NewBdClient(tmp)
-	issues, _, err := client.ListIssues()
+	issues, _, _, err := client.LoadIssues(IssueWindow{})
 	if err != nil {
-		t.Fatalf("ListIssues: %v", err)
+		t.Fatalf("LoadIssues: %v", err)
 	}
 
 	if len(issues) == 0 {
@@ -1,6 +1,8 @@
 package bdtui_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -189,6 +191,140 @@ func TestHandleMouseIgnoredOutsideBoardMode(t *testing.T) {
 	}
 }
 
+func TestHandleMouseDragToColumnChangesStatus(t *testing.T) {
+	t.Parallel()
+
+	m, _, _ := newMouseTestModel()
+	anotherID := "bdtui-open-2"
+	_, issueRowIndex := m.BuildColumnRows(StatusOpen)
+
+	x, y := mouseClickCoordsForRow(m, StatusOpen, issueRowIndex[anotherID])
+	next, _ := m.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = next.(model)
+	if m.Drag == nil || m.Drag.IssueID != anotherID {
+		t.Fatalf("expected drag of %q to start, got %+v", anotherID, m.Drag)
+	}
+
+	x, y = mouseClickCoordsForRow(m, StatusInProgress, 3)
+	next, _ = m.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	m = next.(model)
+	if m.Drag.DropStatus != StatusInProgress || !m.Drag.Moved {
+		t.Fatalf("expected in_progress drop target, got %+v", m.Drag)
+	}
+	if view := m.View(); !strings.Contains(view, "═") {
+		t.Fatalf("expected the drop target column highlighted, got %q", view)
+	}
+
+	next, cmd := m.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	got := next.(model)
+	if cmd == nil || got.Drag != nil {
+		t.Fatalf("expected queued update and finished drag, cmd=%v drag=%+v", cmd, got.Drag)
+	}
+	if issue := got.ByID[anotherID]; issue.Status != StatusInProgress || !issue.Pending {
+		t.Fatalf("expected pending in_progress status, got %+v", issue)
+	}
+	if got.CurrentIssue() == nil || got.CurrentIssue().ID != anotherID {
+		t.Fatalf("expected selection to follow the dropped card, got %+v", got.CurrentIssue())
+	}
+}
+
+func TestHandleMouseDropOnSameColumnDoesNothing(t *testing.T) {
+	t.Parallel()
+
+	m, parentID, _ := newMouseTestModel()
+	x, y := mouseClickCoordsForRow(m, StatusOpen, 0)
+	next, _ := m.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = next.(model)
+
+	next, cmd := m.HandleMouse(tea.MouseMsg{X: x, Y: y + 1, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	got := next.(model)
+	if cmd != nil || len(got.PendingOps) != 0 || got.Drag != nil {
+		t.Fatalf("expected no op for a drop in the same column, cmd=%v ops=%d", cmd, len(got.PendingOps))
+	}
+	if got.ByID[parentID].Status != StatusOpen {
+		t.Fatalf("expected status unchanged, got %s", got.ByID[parentID].Status)
+	}
+}
+
+func TestHandleMouseModifierDropOnCardLinksIssues(t *testing.T) {
+	t.Parallel()
+
+	m, parentID, _ := newMouseTestModel()
+	anotherID := "bdtui-open-2"
+	_, openRows := m.BuildColumnRows(StatusOpen)
+
+	x, y := mouseClickCoordsForRow(m, StatusOpen, openRows[anotherID])
+	next, _ := m.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = next.(model)
+
+	x, y = mouseClickCoordsForRow(m, StatusOpen, openRows[parentID])
+	next, _ = m.HandleMouse(tea.MouseMsg{X: x, Y: y, Ctrl: true, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	m = next.(model)
+	if m.Drag.DropIssueID != parentID || m.Drag.Link != DropLinkParent {
+		t.Fatalf("expected parent drop on %q, got %+v", parentID, m.Drag)
+	}
+	if view := m.View(); strings.Contains(view, "═") {
+		t.Fatalf("expected no column drop highlight for a card drop, got %q", view)
+	}
+
+	next, cmd := m.HandleMouse(tea.MouseMsg{X: x, Y: y, Ctrl: true, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	got := next.(model)
+	if cmd == nil || got.ByID[anotherID].Parent != parentID {
+		t.Fatalf("expected parent set to %q, got %+v", parentID, got.ByID[anotherID])
+	}
+
+	next, _ = got.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = next.(model)
+	_, openRows = m.BuildColumnRows(StatusOpen)
+	x, y = mouseClickCoordsForRow(m, StatusOpen, openRows[anotherID])
+	next, _ = m.HandleMouse(tea.MouseMsg{X: x, Y: y, Alt: true, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	got = next.(model)
+	if len(got.PendingOps) != 2 {
+		t.Fatalf("expected a queued blocker op, got %d ops", len(got.PendingOps))
+	}
+}
+
+func TestHandleMouseWheelScrollsColumnUnderPointer(t *testing.T) {
+	t.Parallel()
+
+	m, parentID, _ := newMouseTestModel()
+	m.Height = 12
+	for i := 0; i < 20; i++ {
+		issue := Issue{ID: fmt.Sprintf("bdtui-ip-%02d", i), Title: "Work", Status: StatusInProgress, Display: StatusInProgress, IssueType: "task"}
+		m.Issues = append(m.Issues, issue)
+		m.ByID[issue.ID] = &m.Issues[len(m.Issues)-1]
+	}
+	m.ComputeColumns()
+	m.NormalizeSelectionBounds()
+	m.SelectIssueByID(parentID)
+
+	x, y := mouseClickCoordsForRow(m, StatusInProgress, 0)
+	next, _ := m.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	got := next.(model)
+	if got.ScrollOffset[StatusInProgress] != 3 || got.ScrollOffset[StatusOpen] != 0 {
+		t.Fatalf("expected only in_progress scrolled by 3, got %+v", got.ScrollOffset)
+	}
+	if got.CurrentIssue() == nil || got.CurrentIssue().ID != parentID {
+		t.Fatalf("expected selection unchanged by the wheel, got %+v", got.CurrentIssue())
+	}
+
+	for i := 0; i < 10; i++ {
+		next, _ = got.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+		got = next.(model)
+	}
+	rows, _ := got.BuildColumnRows(StatusInProgress)
+	if offset := got.ScrollOffset[StatusInProgress]; offset >= len(rows) || offset <= 3 {
+		t.Fatalf("expected offset clamped below %d rows, got %d", len(rows), offset)
+	}
+
+	bottom := got.ScrollOffset[StatusInProgress]
+	next, _ = got.HandleMouse(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp})
+	up := next.(model)
+	if up.ScrollOffset[StatusInProgress] != bottom-3 {
+		t.Fatalf("expected wheel up to scroll back by 3, got %d", up.ScrollOffset[StatusInProgress])
+	}
+}
+
 func newMouseTestModel() (model, string, string) {
 	search := textinput.New()
 	search.Prompt = "search> "